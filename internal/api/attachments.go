@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AttachmentsService provides methods for interacting with the Google Chat
@@ -19,7 +18,7 @@ func NewAttachmentsService(client *Client) *AttachmentsService {
 // Get returns metadata for a message attachment.
 // name is the full attachment resource name,
 // e.g. "spaces/{space}/messages/{message}/attachments/{attachment}".
-func (s *AttachmentsService) Get(ctx context.Context, name string) (json.RawMessage, error) {
+func (s *AttachmentsService) Get(ctx context.Context, name string) (*Attachment, error) {
 	name = NormalizeName(name, "spaces/")
-	return s.client.Get(ctx, name, nil)
+	return decode[Attachment](s.client.Get(ctx, name, nil))
 }
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/telemetry"
+)
+
+// batchPath is the batch endpoint declared by the Chat API discovery
+// document, relative to the API root (BaseURL without its version).
+const batchPath = "batch"
+
+// MaxBatchSize is the number of calls sent per batch request. Larger
+// batches are split into several requests transparently.
+const MaxBatchSize = 100
+
+// Batch packs many API calls into multipart/mixed batch requests, so that N
+// calls cost one round trip per MaxBatchSize calls instead of N.
+//
+// Queue calls with Get, Post, Patch, Put and Delete, send them with Do, and
+// then read each call's outcome with BatchCall.Result. A failing call does
+// not fail the batch; its APIError is reported by Result.
+type Batch struct {
+	client *Client
+	calls  []*BatchCall
+}
+
+// BatchCall is a single call queued in a Batch.
+type BatchCall struct {
+	method string
+	path   string
+	params url.Values
+	body   []byte
+
+	done   bool
+	direct bool // sent on its own; send has already retried it
+	raw    json.RawMessage
+	err    error
+}
+
+// BatchResult is the decoded outcome of one call in a batch.
+type BatchResult[T any] struct {
+	Value *T
+	Err   error
+}
+
+// NewBatch returns an empty Batch that sends its calls through c.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// Get queues a GET request.
+func (b *Batch) Get(path string, params url.Values) *BatchCall {
+	return b.add(http.MethodGet, path, params, nil)
+}
+
+// Post queues a POST request with a JSON body.
+func (b *Batch) Post(path string, params url.Values, body interface{}) *BatchCall {
+	return b.addJSON(http.MethodPost, path, params, body)
+}
+
+// Patch queues a PATCH request with a JSON body.
+func (b *Batch) Patch(path string, params url.Values, body interface{}) *BatchCall {
+	return b.addJSON(http.MethodPatch, path, params, body)
+}
+
+// Put queues a PUT request with a JSON body.
+func (b *Batch) Put(path string, params url.Values, body interface{}) *BatchCall {
+	return b.addJSON(http.MethodPut, path, params, body)
+}
+
+// Delete queues a DELETE request.
+func (b *Batch) Delete(path string, params url.Values) *BatchCall {
+	return b.add(http.MethodDelete, path, params, nil)
+}
+
+// Len returns the number of queued calls.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+func (b *Batch) add(method, path string, params url.Values, body []byte) *BatchCall {
+	call := &BatchCall{method: method, path: path, params: params, body: body}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+func (b *Batch) addJSON(method, path string, params url.Values, body interface{}) *BatchCall {
+	data, err := json.Marshal(body)
+	call := b.add(method, path, params, data)
+	if err != nil {
+		call.done, call.err = true, fmt.Errorf("marshaling request body: %w", err)
+	}
+	return call
+}
+
+// Result returns the raw JSON response of the call, or its error. It is
+// only meaningful once Batch.Do has returned.
+func (c *BatchCall) Result() (json.RawMessage, error) {
+	if !c.done {
+		return nil, errors.New("batch call was not sent")
+	}
+	return c.raw, c.err
+}
+
+// idempotent reports whether the call may be sent more than once, by the
+// same rules send applies to single requests.
+func (c *BatchCall) idempotent() bool {
+	switch c.method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return c.params.Get("requestId") != ""
+	}
+	return false
+}
+
+// retryable reports whether the call failed transiently and may be resent.
+func (c *BatchCall) retryable() bool {
+	var apiErr *APIError
+	return !c.direct && c.idempotent() && errors.As(c.err, &apiErr) && retryableStatus(apiErr.Code)
+}
+
+// Do sends every queued call that has not been sent yet. Calls that fail
+// with a retryable status (429, 5xx) are resent in a later batch according
+// to the client's retry policy, provided they are idempotent.
+//
+// Do returns an error only when a batch request as a whole fails, e.g. on a
+// network or authentication error; the calls it carried then report that
+// error from Result.
+func (b *Batch) Do(ctx context.Context) (err error) {
+	var pending []*BatchCall
+	for _, call := range b.calls {
+		if !call.done {
+			pending = append(pending, call)
+		}
+	}
+
+	sent := pending
+	ctx, span := telemetry.Start(ctx, "batch", telemetry.KindInternal, telemetry.Attr{Key: "calls", Value: len(sent)})
+	defer func() {
+		failed := 0
+		for _, call := range sent {
+			if call.err != nil {
+				failed++
+			}
+		}
+		span.SetAttr("failed", failed)
+		span.SetError(err)
+		span.End()
+	}()
+
+	c := b.client
+	maxAttempts := c.Retry.attempts()
+	for attempt := 1; len(pending) > 0; attempt++ {
+		span.SetAttr("rounds", attempt)
+		for start := 0; start < len(pending); {
+			n, err := b.admit(ctx, pending[start:min(start+MaxBatchSize, len(pending))])
+			if err == nil {
+				err = b.send(withPaced(ctx), pending[start:start+n])
+			}
+			if err != nil {
+				for _, call := range pending[start:] {
+					call.done, call.raw, call.err = true, nil, err
+				}
+				return err
+			}
+			start += n
+		}
+
+		if attempt >= maxAttempts {
+			return nil
+		}
+		var retry []*BatchCall
+		for _, call := range pending {
+			if call.retryable() {
+				retry = append(retry, call)
+			}
+		}
+		if len(retry) == 0 {
+			return nil
+		}
+
+		delay := c.Retry.backoff(attempt)
+		c.log().Warn("retrying batched calls", "count", len(retry), "delay", delay.Round(time.Millisecond))
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+		pending = retry
+	}
+	return nil
+}
+
+// admit takes the rate limiter tokens for the leading calls of chunk and
+// returns how many of them may be sent now. It waits for the first call,
+// then adds calls for as long as their tokens are available right away, so
+// that each batch carries only as many calls as the quotas allow at the
+// moment it is sent.
+func (b *Batch) admit(ctx context.Context, chunk []*BatchCall) (int, error) {
+	c := b.client
+	for i, call := range chunk {
+		path := c.requestPath(call.path)
+		if i == 0 {
+			if err := c.Limiter.Wait(ctx, call.method, path); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if !c.Limiter.TryTake(call.method, path) {
+			return i, nil
+		}
+	}
+	return len(chunk), nil
+}
+
+// send delivers one chunk of calls and records their outcomes. A lone call
+// is sent as a plain request, which saves the multipart overhead. The calls
+// must have been admitted; ctx is marked as paced.
+func (b *Batch) send(ctx context.Context, calls []*BatchCall) error {
+	c := b.client
+
+	if len(calls) == 1 {
+		call := calls[0]
+		var body io.Reader
+		contentType := ""
+		if call.body != nil {
+			body, contentType = bytes.NewReader(call.body), "application/json"
+		}
+		raw, err := c.do(ctx, call.method, call.path, call.params, body, contentType)
+		var apiErr *APIError
+		if err != nil && !errors.As(err, &apiErr) {
+			return err
+		}
+		call.done, call.direct, call.raw, call.err = true, true, raw, err
+		return nil
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	idempotent := true
+	for i, call := range calls {
+		target, err := url.Parse(c.buildURL(call.path, withFields(ctx, call.params)))
+		if err != nil {
+			return fmt.Errorf("building batch request: %w", err)
+		}
+		idempotent = idempotent && call.idempotent()
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/http")
+		header.Set("Content-ID", fmt.Sprintf("<item%d>", i))
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return fmt.Errorf("building batch request: %w", err)
+		}
+		fmt.Fprintf(part, "%s %s HTTP/1.1\r\n", call.method, target.RequestURI())
+		if call.body != nil {
+			fmt.Fprintf(part, "Content-Type: application/json\r\nContent-Length: %d\r\n", len(call.body))
+		}
+		fmt.Fprint(part, "\r\n")
+		part.Write(call.body)
+	}
+	if err := mw.Close(); err != nil {
+		return fmt.Errorf("building batch request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.batchURL(), bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+
+	resp, err := c.sendRetrying(ctx, req, idempotent)
+	if err != nil {
+		return fmt.Errorf("executing batch request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		c.log().Debug("error response body", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "body", string(respBody))
+		if apiErr := parseAPIErrorFromBody(resp.StatusCode, respBody); apiErr != nil {
+			return apiErr
+		}
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return demuxBatch(resp, calls)
+}
+
+// demuxBatch splits a multipart/mixed batch response into the outcomes of
+// calls. Parts are matched by their Content-ID ("<response-itemN>"), or by
+// position when the server omits it.
+func demuxBatch(resp *http.Response, calls []*BatchCall) error {
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return fmt.Errorf("unexpected batch response content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	answered := make([]bool, len(calls))
+	mr := multipart.NewReader(resp.Body, params["boundary"])
+	for next := 0; ; next++ {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading batch response: %w", err)
+		}
+
+		i := next
+		if id := part.Header.Get("Content-ID"); id != "" {
+			n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(id, "<response-item"), ">"))
+			if err != nil {
+				return fmt.Errorf("unexpected batch response Content-ID %q", id)
+			}
+			i, next = n, n
+		}
+		if i < 0 || i >= len(calls) {
+			continue
+		}
+
+		call := calls[i]
+		answered[i] = true
+		call.done, call.raw, call.err = true, nil, nil
+
+		inner, err := http.ReadResponse(bufio.NewReader(part), nil)
+		if err != nil {
+			call.err = fmt.Errorf("parsing batch response item: %w", err)
+			continue
+		}
+		body, err := io.ReadAll(inner.Body)
+		inner.Body.Close()
+		if err != nil {
+			call.err = fmt.Errorf("reading batch response item: %w", err)
+			continue
+		}
+		if inner.StatusCode < 200 || inner.StatusCode >= 300 {
+			if apiErr := parseAPIErrorFromBody(inner.StatusCode, body); apiErr != nil {
+				call.err = apiErr
+			} else {
+				call.err = fmt.Errorf("unexpected status %d: %s", inner.StatusCode, string(body))
+			}
+			continue
+		}
+		call.raw = json.RawMessage(body)
+	}
+
+	for i, call := range calls {
+		if !answered[i] {
+			call.done, call.raw, call.err = true, nil, fmt.Errorf("batch response has no reply for %s %s", call.method, call.path)
+		}
+	}
+	return nil
+}
+
+// batchResults decodes the outcome of each call into a BatchResult.
+func batchResults[T any](calls []*BatchCall) []BatchResult[T] {
+	results := make([]BatchResult[T], len(calls))
+	for i, call := range calls {
+		results[i].Value, results[i].Err = decode[T](call.Result())
+	}
+	return results
+}
+
+// requestPath returns the URL path of a request for the API path p, which
+// the rate limiter keys its buckets by.
+func (c *Client) requestPath(p string) string {
+	u, err := url.Parse(c.buildURL(p, nil))
+	if err != nil {
+		return p
+	}
+	return u.Path
+}
+
+// batchURL returns the batch endpoint: batchPath resolved against the API
+// root.
+func (c *Client) batchURL() string {
+	return c.apiRoot() + "/" + batchPath
+}
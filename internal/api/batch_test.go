@@ -0,0 +1,138 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+	"github.com/cipher-shad0w/gogchat/internal/chattest"
+)
+
+func TestBatchDemux(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	a := srv.AddSpace(api.Space{DisplayName: "A"})
+	b := srv.AddSpace(api.Space{DisplayName: "B"})
+	names := []string{
+		"users/me/" + a.Name + "/spaceReadState",
+		"users/me/spaces/MISSING/spaceReadState",
+		"users/me/" + b.Name + "/spaceReadState",
+	}
+
+	results, err := api.NewReadStateService(c).GetSpaceReadStates(context.Background(), names)
+	if err != nil {
+		t.Fatalf("GetSpaceReadStates: %v", err)
+	}
+	if len(results) != len(names) {
+		t.Fatalf("got %d results, want %d", len(results), len(names))
+	}
+	for i, want := range []string{a.Name, "", b.Name} {
+		r := results[i]
+		if want == "" {
+			var apiErr *api.APIError
+			if !errors.As(r.Err, &apiErr) || apiErr.Code != http.StatusNotFound {
+				t.Errorf("result %d: error = %v, want a 404 APIError", i, r.Err)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("result %d: %v", i, r.Err)
+			continue
+		}
+		if wantName := chattest.DefaultCaller.Name + "/" + want + "/spaceReadState"; r.Value.Name != wantName {
+			t.Errorf("result %d: Name = %q, want %q", i, r.Value.Name, wantName)
+		}
+	}
+	if n := len(rec.matching("POST /batch")); n != 1 || len(rec.requests) != 1 {
+		t.Errorf("requests = %v, want a single batch request", rec.requests)
+	}
+}
+
+func TestBatchRetriesFailedCalls(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	a := srv.AddSpace(api.Space{DisplayName: "A"})
+	b := srv.AddSpace(api.Space{DisplayName: "B"})
+	nameA := "users/me/" + a.Name + "/spaceReadState"
+	nameB := "users/me/" + b.Name + "/spaceReadState"
+	srv.FailNext("GET", nameB, http.StatusServiceUnavailable)
+
+	results, err := api.NewReadStateService(c).GetSpaceReadStates(context.Background(), []string{nameA, nameB})
+	if err != nil {
+		t.Fatalf("GetSpaceReadStates: %v", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Errorf("result %d: %v", i, r.Err)
+		}
+	}
+	// The failed call is retried on its own, as a plain request.
+	if len(rec.requests) != 2 || len(rec.matching("POST /batch")) != 1 || len(rec.matching("GET /v1/"+nameB)) != 1 {
+		t.Errorf("requests = %v, want the batch followed by a plain GET of %s", rec.requests, nameB)
+	}
+}
+
+func TestBatchCreateMany(t *testing.T) {
+	srv, c, _ := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	m1 := srv.AddMessage(sp.Name, api.Message{Text: "one"})
+	m2 := srv.AddMessage(sp.Name, api.Message{Text: "two"})
+
+	reaction := &api.Reaction{Emoji: &api.Emoji{Unicode: "👍"}}
+	results, err := api.NewReactionsService(c).CreateMany(context.Background(), []string{m1.Name, m2.Name}, reaction)
+	if err != nil {
+		t.Fatalf("CreateMany: %v", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("result %d: %v", i, r.Err)
+		}
+		if r.Value.Emoji == nil || r.Value.Emoji.Unicode != "👍" {
+			t.Errorf("result %d: Emoji = %+v, want 👍", i, r.Value.Emoji)
+		}
+	}
+
+	list, err := api.NewReactionsService(c).List(context.Background(), m2.Name, 0, "", "")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list.Reactions) != 1 {
+		t.Errorf("%s has %d reactions, want 1", m2.Name, len(list.Reactions))
+	}
+}
+
+func TestBatchPacing(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	// A burst of 20 writes to the space, then one every 50ms.
+	c.Limiter = api.NewRateLimiter(0, 0, 20)
+
+	var names []string
+	for i := range 25 {
+		names = append(names, srv.AddMessage(sp.Name, api.Message{Text: fmt.Sprint(i)}).Name)
+	}
+	b := c.NewBatch()
+	for _, name := range names {
+		b.Delete(name, nil)
+	}
+	start := time.Now()
+	if err := b.Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	elapsed := time.Since(start)
+
+	// The calls covered by the burst go out at once; the rest follow one
+	// by one as their tokens come in, instead of all waiting for a
+	// single batch.
+	if got := len(rec.matching("POST /batch")); got != 1 {
+		t.Errorf("sent %d batch requests, want 1", got)
+	}
+	if got := len(rec.matching("DELETE ")); got != 5 {
+		t.Errorf("sent %d single deletes, want 5", got)
+	}
+	if elapsed < 200*time.Millisecond {
+		t.Errorf("took %s, want the last 5 calls paced at 50ms", elapsed)
+	}
+}
@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/telemetry"
 )
 
 // BaseURL is the default Google Chat API endpoint.
@@ -20,14 +23,48 @@ const BaseURL = "https://chat.googleapis.com/v1"
 type Client struct {
 	HTTPClient *http.Client
 	BaseURL    string
-	Verbose    bool
+	Retry      RetryPolicy
+	Limiter    *RateLimiter
+
+	// Logger receives debug records for every request attempt, response
+	// and retry. Nil disables logging.
+	Logger *slog.Logger
+
+	// QuotaProject, if set, is sent as X-Goog-User-Project with every
+	// request to bill usage and quota to that Google Cloud project.
+	QuotaProject string
+
+	// RequestTimeout abandons a single request attempt that makes no
+	// progress for this long; 0 disables it. See roundTrip.
+	RequestTimeout time.Duration
+
+	// AuthMode is how HTTPClient authenticates: AuthUser or AuthApp.
+	// Identity names the account requests are made as, e.g. a service
+	// account's address. Both only describe the credentials, which
+	// HTTPClient carries.
+	AuthMode string
+	Identity string
 }
 
-// NewClient creates a new API client with the default BaseURL.
+// Authentication modes for Client.AuthMode.
+const (
+	// AuthUser makes requests as a user, with user OAuth credentials.
+	AuthUser = "user"
+	// AuthApp makes requests as a Chat app, with a service account and the
+	// chat.bot scope.
+	AuthApp = "app"
+)
+
+// NewClient creates a new API client with the default BaseURL, retry policy,
+// rate limits and request timeout.
 func NewClient(httpClient *http.Client) *Client {
 	return &Client{
-		HTTPClient: httpClient,
-		BaseURL:    BaseURL,
+		HTTPClient:     httpClient,
+		BaseURL:        BaseURL,
+		Retry:          DefaultRetryPolicy(),
+		Limiter:        NewRateLimiter(DefaultReadRate, DefaultWriteRate, DefaultSpaceWriteRate),
+		RequestTimeout: DefaultRequestTimeout,
+		AuthMode:       AuthUser,
 	}
 }
 
@@ -118,52 +155,51 @@ func (c *Client) Delete(ctx context.Context, path string, params url.Values) (js
 }
 
 // Upload performs an HTTP POST request with arbitrary content (e.g. multipart upload)
-// and returns the raw JSON response.
+// against the media upload endpoint and returns the raw JSON response.
 func (c *Client) Upload(ctx context.Context, path string, params url.Values, body io.Reader, contentType string) (json.RawMessage, error) {
-	return c.do(ctx, http.MethodPost, path, params, body, contentType)
+	return c.doURL(ctx, http.MethodPost, c.uploadURL(path, withFields(ctx, params)), body, contentType)
 }
 
-// Download performs an HTTP GET and returns the response body as a ReadCloser,
-// the Content-Type header, and any error.
-func (c *Client) Download(ctx context.Context, path string) (io.ReadCloser, string, error) {
-	reqURL := c.buildURL(path, nil)
+// Download performs an HTTP GET for raw content (e.g. media) and returns the
+// response, whose body the caller must close. header is added to the
+// request, e.g. to send a Range. Any 2xx status is returned as-is; other
+// statuses are returned as an *APIError.
+func (c *Client) Download(ctx context.Context, path string, params url.Values, header http.Header) (*http.Response, error) {
+	reqURL := c.buildURL(path, params)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
-		return nil, "", fmt.Errorf("creating request: %w", err)
+		return nil, fmt.Errorf("creating request: %w", err)
 	}
-
-	if c.Verbose {
-		log.Printf(">> %s %s\n", req.Method, req.URL.String())
+	for k, v := range header {
+		req.Header[k] = v
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(ctx, req)
 	if err != nil {
-		return nil, "", fmt.Errorf("executing request: %w", err)
-	}
-
-	if c.Verbose {
-		log.Printf("<< %d %s\n", resp.StatusCode, resp.Status)
+		return nil, fmt.Errorf("executing request: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		defer resp.Body.Close()
 		apiErr := parseAPIError(resp)
 		if apiErr != nil {
-			return nil, "", apiErr
+			return nil, apiErr
 		}
-		return nil, "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
 	}
 
-	contentType := resp.Header.Get("Content-Type")
-	return resp.Body, contentType, nil
+	return resp, nil
 }
 
 // do is the internal helper that executes an HTTP request, checks the status code,
 // and returns the response body as raw JSON or an error.
 func (c *Client) do(ctx context.Context, method, path string, params url.Values, body io.Reader, contentType string) (json.RawMessage, error) {
-	reqURL := c.buildURL(path, params)
+	return c.doURL(ctx, method, c.buildURL(path, withFields(ctx, params)), body, contentType)
+}
 
+// doURL is do for a fully built request URL.
+func (c *Client) doURL(ctx context.Context, method, reqURL string, body io.Reader, contentType string) (json.RawMessage, error) {
 	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
@@ -173,11 +209,7 @@ func (c *Client) do(ctx context.Context, method, path string, params url.Values,
 		req.Header.Set("Content-Type", contentType)
 	}
 
-	if c.Verbose {
-		log.Printf(">> %s %s\n", req.Method, req.URL.String())
-	}
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -188,14 +220,8 @@ func (c *Client) do(ctx context.Context, method, path string, params url.Values,
 		return nil, fmt.Errorf("reading response body: %w", err)
 	}
 
-	if c.Verbose {
-		log.Printf("<< %d %s\n", resp.StatusCode, resp.Status)
-	}
-
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if c.Verbose {
-			log.Printf("<< Response body:\n%s\n", string(respBody))
-		}
+		c.log().Debug("error response body", "method", method, "url", reqURL, "status", resp.StatusCode, "body", string(respBody))
 		apiErr := parseAPIErrorFromBody(resp.StatusCode, respBody)
 		if apiErr != nil {
 			return nil, apiErr
@@ -206,6 +232,137 @@ func (c *Client) do(ctx context.Context, method, path string, params url.Values,
 	return json.RawMessage(respBody), nil
 }
 
+// send executes req, retrying transient failures according to c.Retry and
+// pacing every attempt through c.Limiter. Each attempt is bounded by
+// c.RequestTimeout and logged to c.Logger. The returned response is
+// the last one received; the caller is responsible for closing its body.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return c.sendRetrying(ctx, req, retryableRequest(req))
+}
+
+// sendRetrying is send with the retry decision made by the caller, for
+// requests such as batches whose safety cannot be judged from the method.
+// All attempts are recorded in one client span.
+func (c *Client) sendRetrying(ctx context.Context, req *http.Request, retryable bool) (*http.Response, error) {
+	span := startRequestSpan(req)
+	defer span.End()
+
+	resp, err := c.attempt(ctx, req, retryable, span)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	span.SetAttr("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= 400 {
+		span.SetError(fmt.Errorf("status %s", resp.Status))
+	}
+	return resp, nil
+}
+
+// attempt runs the attempts of sendRetrying, counting resends on span.
+func (c *Client) attempt(ctx context.Context, req *http.Request, retryable bool, span *telemetry.Span) (*http.Response, error) {
+	maxAttempts := c.Retry.attempts()
+	if !retryable {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			span.SetAttr("http.request.resend_count", attempt-1)
+		}
+		r := req
+		if attempt > 1 {
+			r = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("rewinding request body: %w", err)
+				}
+				r.Body = body
+			}
+		}
+
+		// A resend is paced even if the first attempt was admitted by the
+		// caller.
+		if attempt > 1 || !paced(ctx) {
+			if err := c.Limiter.Wait(ctx, r.Method, r.URL.Path); err != nil {
+				return nil, err
+			}
+		}
+
+		c.log().Debug("request", "method", r.Method, "url", r.URL.String(), "attempt", attempt, "maxAttempts", maxAttempts)
+		start := time.Now()
+		resp, err := c.roundTrip(r)
+		if err == nil {
+			c.log().Debug("response", "method", r.Method, "url", r.URL.String(), "status", resp.StatusCode, "duration", time.Since(start).Round(time.Millisecond))
+		}
+
+		if attempt >= maxAttempts {
+			return resp, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if !retryableError(err) {
+				return nil, err
+			}
+			delay = c.Retry.backoff(attempt)
+		case retryableStatus(resp.StatusCode):
+			var ok bool
+			if delay, ok = retryAfter(resp); !ok {
+				delay = c.Retry.backoff(attempt)
+			}
+			// Drain and close the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+		}
+		c.log().Warn("retrying request", "method", r.Method, "url", r.URL.String(), "reason", reason, "delay", delay.Round(time.Millisecond))
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// log returns c.Logger, or a logger that discards everything.
+func (c *Client) log() *slog.Logger {
+	if c.Logger == nil {
+		return discardLogger
+	}
+	return c.Logger
+}
+
+// discardLogger is used when the client has no Logger.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// decode unmarshals a raw JSON response into a new value of type T and keeps
+// raw for RawJSON. It is meant to wrap a Client call directly:
+// decode[Message](c.Get(ctx, name, nil)).
+func decode[T any](raw json.RawMessage, err error) (*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	v := new(T)
+	if len(raw) == 0 {
+		return v, nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	keepRaw(v, raw)
+	return v, nil
+}
+
 // buildURL constructs the full request URL from the base URL, path, and query parameters.
 func (c *Client) buildURL(path string, params url.Values) string {
 	u := c.BaseURL + "/" + strings.TrimLeft(path, "/")
@@ -215,6 +372,19 @@ func (c *Client) buildURL(path string, params url.Values) string {
 	return u
 }
 
+// apiRoot returns BaseURL without its "/v1" suffix: the root that the batch
+// and media upload endpoints are relative to.
+func (c *Client) apiRoot() string {
+	return strings.TrimSuffix(strings.TrimRight(c.BaseURL, "/"), "/v1")
+}
+
+// uploadURL is buildURL for the media upload endpoint, which is served under
+// "/upload" in front of the API version, e.g.
+// https://chat.googleapis.com/upload/v1/spaces/AAAA/attachments:upload.
+func (c *Client) uploadURL(path string, params url.Values) string {
+	return c.apiRoot() + "/upload" + strings.TrimPrefix(c.buildURL(path, params), c.apiRoot())
+}
+
 // parseAPIError reads the response body and attempts to parse a Google API error.
 func parseAPIError(resp *http.Response) *APIError {
 	body, err := io.ReadAll(resp.Body)
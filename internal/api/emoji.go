@@ -2,7 +2,7 @@ package api
 
 import (
 	"context"
-	"encoding/json"
+	"iter"
 	"net/url"
 )
 
@@ -18,31 +18,42 @@ func NewEmojiService(client *Client) *EmojiService {
 
 // List retrieves a paginated list of custom emojis.
 // GET /v1/customEmojis
-func (s *EmojiService) List(ctx context.Context, filter string, pageSize int, pageToken string) (json.RawMessage, error) {
+func (s *EmojiService) List(ctx context.Context, filter string, pageSize int, pageToken string) (*ListCustomEmojisResponse, error) {
 	params := url.Values{}
 	AddQueryParam(params, "filter", filter)
 	AddQueryParamInt(params, "pageSize", pageSize)
 	AddQueryParam(params, "pageToken", pageToken)
 
-	return s.client.Get(ctx, "customEmojis", params)
+	return decode[ListCustomEmojisResponse](s.client.Get(ctx, "customEmojis", params))
+}
+
+// All iterates over every custom emoji, fetching pages on demand.
+func (s *EmojiService) All(ctx context.Context, filter string, opts PageOptions) iter.Seq2[*CustomEmoji, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, pageToken string, pageSize int) ([]*CustomEmoji, string, error) {
+		resp, err := s.List(ctx, filter, pageSize, pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.CustomEmojis, resp.NextPageToken, nil
+	})
 }
 
 // Get retrieves a single custom emoji by name or ID.
 // GET /v1/{name}
-func (s *EmojiService) Get(ctx context.Context, name string) (json.RawMessage, error) {
+func (s *EmojiService) Get(ctx context.Context, name string) (*CustomEmoji, error) {
 	name = NormalizeName(name, "customEmojis/")
-	return s.client.Get(ctx, name, nil)
+	return decode[CustomEmoji](s.client.Get(ctx, name, nil))
 }
 
 // Create creates a new custom emoji.
 // POST /v1/customEmojis
-func (s *EmojiService) Create(ctx context.Context, emoji map[string]interface{}) (json.RawMessage, error) {
-	return s.client.Post(ctx, "customEmojis", nil, emoji)
+func (s *EmojiService) Create(ctx context.Context, emoji *CustomEmoji) (*CustomEmoji, error) {
+	return decode[CustomEmoji](s.client.Post(ctx, "customEmojis", nil, emoji))
 }
 
 // Delete deletes a custom emoji by name or ID.
 // DELETE /v1/{name}
-func (s *EmojiService) Delete(ctx context.Context, name string) (json.RawMessage, error) {
+func (s *EmojiService) Delete(ctx context.Context, name string) (*Empty, error) {
 	name = NormalizeName(name, "customEmojis/")
-	return s.client.Delete(ctx, name, nil)
+	return decode[Empty](s.client.Delete(ctx, name, nil))
 }
@@ -2,8 +2,8 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 )
 
@@ -21,7 +21,7 @@ func NewEventsService(client *Client) *EventsService {
 // List returns a paginated list of events from a space.
 // GET /v1/{parent}/spaceEvents
 // parent is a space name or ID (normalized with "spaces/" prefix).
-func (s *EventsService) List(ctx context.Context, parent string, filter string, pageSize int, pageToken string) (json.RawMessage, error) {
+func (s *EventsService) List(ctx context.Context, parent string, filter string, pageSize int, pageToken string) (*ListSpaceEventsResponse, error) {
 	parent = NormalizeName(parent, "spaces/")
 	path := fmt.Sprintf("%s/spaceEvents", parent)
 
@@ -30,12 +30,24 @@ func (s *EventsService) List(ctx context.Context, parent string, filter string,
 	AddQueryParamInt(params, "pageSize", pageSize)
 	AddQueryParam(params, "pageToken", pageToken)
 
-	return s.client.Get(ctx, path, params)
+	return decode[ListSpaceEventsResponse](s.client.Get(ctx, path, params))
+}
+
+// All iterates over every event in a space matching filter, fetching pages
+// on demand.
+func (s *EventsService) All(ctx context.Context, parent, filter string, opts PageOptions) iter.Seq2[*SpaceEvent, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, pageToken string, pageSize int) ([]*SpaceEvent, string, error) {
+		resp, err := s.List(ctx, parent, filter, pageSize, pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.SpaceEvents, resp.NextPageToken, nil
+	})
 }
 
 // Get retrieves a single space event by its full resource name.
 // GET /v1/{name}
 // Name format: spaces/{space}/spaceEvents/{spaceEvent}
-func (s *EventsService) Get(ctx context.Context, name string) (json.RawMessage, error) {
-	return s.client.Get(ctx, name, nil)
+func (s *EventsService) Get(ctx context.Context, name string) (*SpaceEvent, error) {
+	return decode[SpaceEvent](s.client.Get(ctx, name, nil))
 }
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// fieldsKey is the context key under which WithFields stores the selector.
+type fieldsKey struct{}
+
+// WithFields returns a context that makes every API call made with it
+// request a partial response, by sending fields as the standard "fields"
+// system parameter, e.g. "name,text,sender(displayName)" for a message or
+// "messages(name,text)" for a list. An empty fields selects the full
+// response again.
+//
+// Iterators returned by the services' All methods add "nextPageToken" to
+// the selector when it is missing, so that pagination keeps working.
+func WithFields(ctx context.Context, fields string) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, strings.TrimSpace(fields))
+}
+
+// FieldsFrom returns the field selector stored in ctx by WithFields, or "".
+func FieldsFrom(ctx context.Context) string {
+	fields, _ := ctx.Value(fieldsKey{}).(string)
+	return fields
+}
+
+// withFields returns params plus the field selector carried by ctx, if any.
+// params itself is not modified.
+func withFields(ctx context.Context, params url.Values) url.Values {
+	fields := FieldsFrom(ctx)
+	if fields == "" {
+		return params
+	}
+	out := url.Values{}
+	for k, v := range params {
+		out[k] = v
+	}
+	out.Set("fields", fields)
+	return out
+}
+
+// pageFields returns ctx with "nextPageToken" added to its field selector
+// when a selector is set but does not request the token.
+func pageFields(ctx context.Context) context.Context {
+	fields := FieldsFrom(ctx)
+	if fields == "" || fields == "*" {
+		return ctx
+	}
+	for _, f := range topLevelFields(fields) {
+		if f == "nextPageToken" {
+			return ctx
+		}
+	}
+	return WithFields(ctx, fields+",nextPageToken")
+}
+
+// topLevelFields splits a field selector into its top-level fields,
+// dropping any sub-selections: "a,b(c,d),e/f" yields a, b and e.
+func topLevelFields(fields string) []string {
+	var out []string
+	depth, start := 0, 0
+	for i := 0; i <= len(fields); i++ {
+		if i < len(fields) {
+			switch fields[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		name := strings.TrimSpace(fields[start:i])
+		if j := strings.IndexAny(name, "(/"); j >= 0 {
+			name = name[:j]
+		}
+		if name != "" {
+			out = append(out, name)
+		}
+		start = i + 1
+	}
+	return out
+}
@@ -0,0 +1,6 @@
+package api
+
+// The resource types in models.go are generated from the bundled discovery
+// document. Re-run "go generate ./internal/api" after updating api.json.
+
+//go:generate go run ../tools/genmodels -in ../../api.json -out models.go
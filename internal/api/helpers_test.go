@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+	"github.com/cipher-shad0w/gogchat/internal/chattest"
+)
+
+// transportFunc adapts a function to http.RoundTripper.
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (f transportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// recorder is a transport that notes every request it passes on to next, as
+// "METHOD /path?query".
+type recorder struct {
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	r.requests = append(r.requests, req.Method+" "+req.URL.RequestURI())
+	r.mu.Unlock()
+	return r.next.RoundTrip(req)
+}
+
+// matching returns the recorded requests that start with prefix, e.g.
+// "GET /v1/spaces".
+func (r *recorder) matching(prefix string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, req := range r.requests {
+		if strings.HasPrefix(req, prefix) {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// newTestClient starts a fake Chat server and returns it along with a client
+// for it whose requests are recorded. The client retries up to four times
+// with a negligible backoff.
+func newTestClient(t *testing.T) (*chattest.Server, *api.Client, *recorder) {
+	t.Helper()
+	srv := chattest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c := srv.APIClient()
+	rec := &recorder{next: c.HTTPClient.Transport}
+	c.HTTPClient = &http.Client{Transport: rec}
+	c.Retry = api.RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond}
+	return srv, c, rec
+}
@@ -4,13 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/telemetry"
 )
 
 // MediaService handles media upload and download operations on the Google Chat API.
@@ -23,10 +31,41 @@ func NewMediaService(client *Client) *MediaService {
 	return &MediaService{client: client}
 }
 
+// ResumableThreshold is the file size above which Upload uses the
+// resumable protocol instead of a single multipart request.
+const ResumableThreshold = 5 << 20
+
+// DefaultChunkSize is the number of bytes sent per resumable upload request.
+const DefaultChunkSize = 8 << 20
+
+// chunkGranularity is the unit resumable chunk sizes must be a multiple of.
+const chunkGranularity = 256 << 10
+
+// UploadOptions controls how MediaService.Upload sends a file.
+type UploadOptions struct {
+	// Resumable forces the resumable upload protocol. Files larger than
+	// ResumableThreshold always use it.
+	Resumable bool
+	// ChunkSize is the number of bytes sent per resumable request, rounded
+	// up to a multiple of 256 KiB. Zero means DefaultChunkSize.
+	ChunkSize int64
+	// Progress, if set, is called as the file is sent with the number of
+	// bytes sent so far and the file size. When a resumable chunk is
+	// interrupted, it rewinds to the last byte the server committed.
+	Progress func(sent, total int64)
+}
+
 // Upload uploads a file as an attachment to the specified parent space.
-// POST /v1/{parent}/attachments:upload
-func (s *MediaService) Upload(ctx context.Context, parent string, filePath string) (json.RawMessage, error) {
+// The file is streamed from disk rather than buffered in memory. Small files
+// are sent in one multipart request; large ones (or all files when
+// opts.Resumable is set) use the resumable protocol, which continues an
+// interrupted upload from the last committed byte. opts may be nil.
+// POST /upload/v1/{parent}/attachments:upload
+func (s *MediaService) Upload(ctx context.Context, parent string, filePath string, opts *UploadOptions) (result *UploadAttachmentResponse, err error) {
 	parent = NormalizeName(parent, "spaces/")
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
 
 	f, err := os.Open(filePath)
 	if err != nil {
@@ -34,6 +73,11 @@ func (s *MediaService) Upload(ctx context.Context, parent string, filePath strin
 	}
 	defer f.Close()
 
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("checking file %s: %w", filePath, err)
+	}
+
 	// Detect the content type from the file extension, falling back to
 	// application/octet-stream.
 	contentType := mime.TypeByExtension(filepath.Ext(filePath))
@@ -41,65 +85,429 @@ func (s *MediaService) Upload(ctx context.Context, parent string, filePath strin
 		contentType = "application/octet-stream"
 	}
 
-	// Build a multipart request body containing the file.
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	up := &upload{
+		client:      s.client,
+		path:        parent + "/attachments:upload",
+		file:        f,
+		filename:    filepath.Base(filePath),
+		contentType: contentType,
+		size:        info.Size(),
+		opts:        opts,
+	}
+	protocol := "multipart"
+	if info.Size() > 0 && (opts.Resumable || info.Size() > ResumableThreshold) {
+		protocol = "resumable"
+	}
+
+	ctx, up.span = telemetry.Start(ctx, "media.upload", telemetry.KindInternal,
+		telemetry.Attr{Key: "upload.size", Value: up.size},
+		telemetry.Attr{Key: "upload.protocol", Value: protocol},
+	)
+	defer func() {
+		up.span.SetError(err)
+		up.span.End()
+	}()
 
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	if protocol == "resumable" {
+		return up.resumable(ctx)
+	}
+	return up.multipart(ctx)
+}
+
+// upload is a single file upload in progress.
+type upload struct {
+	client      *Client
+	path        string
+	file        *os.File
+	filename    string
+	contentType string
+	size        int64
+	opts        *UploadOptions
+	span        *telemetry.Span
+}
+
+// progress reports that sent bytes have been sent.
+func (u *upload) progress(sent int64) {
+	if u.opts.Progress != nil {
+		u.opts.Progress(sent, u.size)
+	}
+}
+
+// multipart sends the file in a single multipart/form-data request. The
+// body is produced by a goroutine writing into an io.Pipe, so the file is
+// never held in memory.
+func (u *upload) multipart(ctx context.Context) (*UploadAttachmentResponse, error) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	writer := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(u.writeMultipart(writer))
+	}()
+
+	return decode[UploadAttachmentResponse](u.client.Upload(ctx, u.path, nil, pr, writer.FormDataContentType()))
+}
+
+// writeMultipart writes the multipart body: the file followed by the
+// filename field.
+func (u *upload) writeMultipart(writer *multipart.Writer) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "file", "filename": u.filename}))
+	header.Set("Content-Type", u.contentType)
+	part, err := writer.CreatePart(header)
 	if err != nil {
-		return nil, fmt.Errorf("creating multipart form file: %w", err)
+		return fmt.Errorf("creating multipart form file: %w", err)
 	}
 
-	if _, err := io.Copy(part, f); err != nil {
-		return nil, fmt.Errorf("copying file data: %w", err)
+	if _, err := io.Copy(part, &progressReader{r: u.file, report: u.progress}); err != nil {
+		return fmt.Errorf("copying file data: %w", err)
 	}
 
 	// Add the filename metadata field.
-	if err := writer.WriteField("filename", filepath.Base(filePath)); err != nil {
-		return nil, fmt.Errorf("writing filename field: %w", err)
+	if err := writer.WriteField("filename", u.filename); err != nil {
+		return fmt.Errorf("writing filename field: %w", err)
 	}
 
 	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("closing multipart writer: %w", err)
+		return fmt.Errorf("closing multipart writer: %w", err)
 	}
+	return nil
+}
 
-	path := parent + "/attachments:upload"
-	return s.client.Upload(ctx, path, nil, &buf, writer.FormDataContentType())
+// resumable sends the file with the resumable upload protocol: it opens an
+// upload session, then PUTs the file in chunks. When a chunk fails with a
+// network error or a retryable status, it asks the session how many bytes
+// were committed and continues from there, following the client's retry
+// policy.
+func (u *upload) resumable(ctx context.Context) (*UploadAttachmentResponse, error) {
+	session, err := u.startSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize := u.opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	chunkSize = (chunkSize + chunkGranularity - 1) / chunkGranularity * chunkGranularity
+
+	var offset int64
+	failures, chunks, resumes := 0, 0, 0
+	defer func() {
+		u.span.SetAttr("upload.chunks", chunks)
+		u.span.SetAttr("upload.resumes", resumes)
+	}()
+	for {
+		n := min(chunkSize, u.size-offset)
+		chunks++
+		result, committed, err := u.putChunk(ctx, session, offset, n)
+		if err == nil {
+			if result != nil {
+				return result, nil
+			}
+			offset, failures = committed, 0
+			continue
+		}
+
+		if !transientUploadError(err) || failures+1 >= u.client.Retry.attempts() {
+			return nil, err
+		}
+		failures++
+		resumes++
+		delay := u.client.Retry.backoff(failures)
+		u.client.log().Warn("resuming upload", "offset", offset, "error", err, "delay", delay.Round(time.Millisecond))
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+
+		result, committed, err = u.putChunk(ctx, session, -1, 0)
+		if err != nil {
+			return nil, fmt.Errorf("querying upload status: %w", err)
+		}
+		if result != nil {
+			return result, nil
+		}
+		offset = committed
+		u.progress(offset)
+	}
 }
 
-// Download downloads media content by resource name.
-// GET /v1/media/{resourceName}?alt=media
-// Returns the response body as a ReadCloser, the Content-Type header, and any error.
-func (s *MediaService) Download(ctx context.Context, resourceName string) (io.ReadCloser, string, error) {
-	path := "media/" + resourceName
-	// The Download method on Client builds the full URL. We need to append
-	// the alt=media query parameter. Since Client.Download does not accept
-	// query params, we encode them directly into the path.
-	fullPath := path + "?alt=media"
-
-	// Use a direct HTTP GET to handle the alt=media query parameter.
-	reqURL := s.client.BaseURL + "/" + fullPath
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+// startSession opens a resumable upload session and returns its URL.
+func (u *upload) startSession(ctx context.Context) (string, error) {
+	meta, err := json.Marshal(map[string]string{"filename": u.filename})
 	if err != nil {
-		return nil, "", fmt.Errorf("creating download request: %w", err)
+		return "", fmt.Errorf("marshaling upload metadata: %w", err)
 	}
 
-	resp, err := s.client.HTTPClient.Do(req)
+	params := url.Values{"uploadType": {"resumable"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.client.uploadURL(u.path, withFields(ctx, params)), bytes.NewReader(meta))
 	if err != nil {
-		return nil, "", fmt.Errorf("executing download request: %w", err)
+		return "", fmt.Errorf("creating request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Type", u.contentType)
+	req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(u.size, 10))
+
+	resp, err := u.client.send(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("starting resumable upload: %w", err)
+	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		defer resp.Body.Close()
-		body, _ := io.ReadAll(resp.Body)
-		apiErr := parseAPIErrorFromBody(resp.StatusCode, body)
-		if apiErr != nil {
-			return nil, "", apiErr
+		if apiErr := parseAPIError(resp); apiErr != nil {
+			return "", apiErr
+		}
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	session := resp.Header.Get("Location")
+	if session == "" {
+		return "", errors.New("starting resumable upload: response has no session Location")
+	}
+	return session, nil
+}
+
+// putChunk sends n bytes of the file starting at offset to the upload
+// session. With a negative offset it sends no data and only queries the
+// session's status. It returns the upload result once the server has the
+// whole file, or otherwise the number of bytes committed so far.
+//
+// Chunks go straight to the HTTP client rather than through send: the
+// session URL is already authorized for the whole upload, so chunks are
+// neither rate limited nor blindly retried. Each chunk has its own span.
+func (u *upload) putChunk(ctx context.Context, session string, offset, n int64) (result *UploadAttachmentResponse, committed int64, err error) {
+	var body io.Reader = http.NoBody
+	contentRange := fmt.Sprintf("bytes */%d", u.size)
+	if offset >= 0 {
+		body = &progressReader{r: io.NewSectionReader(u.file, offset, n), sent: offset, report: u.progress}
+		contentRange = fmt.Sprintf("bytes %d-%d/%d", offset, offset+n-1, u.size)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, session, body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.ContentLength = max(n, 0)
+	req.Header.Set("Content-Range", contentRange)
+
+	span := startRequestSpan(req)
+	span.SetAttr("upload.content_range", contentRange)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
+	log := u.client.log()
+	log.Debug("request", "method", req.Method, "url", session, "contentRange", contentRange)
+	start := time.Now()
+	resp, err := u.client.roundTrip(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+	log.Debug("response", "method", req.Method, "url", session, "status", resp.StatusCode, "duration", time.Since(start).Round(time.Millisecond))
+	span.SetAttr("http.response.status_code", resp.StatusCode)
+
+	switch {
+	case resp.StatusCode == http.StatusPermanentRedirect:
+		// "Resume Incomplete": Range reports the committed prefix.
+		var last int64 = -1
+		if r := resp.Header.Get("Range"); r != "" {
+			if _, err := fmt.Sscanf(r, "bytes=0-%d", &last); err != nil {
+				return nil, 0, fmt.Errorf("parsing upload Range %q: %w", r, err)
+			}
 		}
-		return nil, "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+		return nil, last + 1, nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		result, err := decode[UploadAttachmentResponse](io.ReadAll(resp.Body))
+		return result, u.size, err
+	}
+
+	if apiErr := parseAPIError(resp); apiErr != nil {
+		return nil, 0, apiErr
+	}
+	return nil, 0, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// transientUploadError reports whether a failed chunk may be resumed.
+func transientUploadError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.Code)
 	}
+	// A connection dropped mid-chunk surfaces as a broken pipe on our side.
+	return retryableError(err) || errors.Is(err, syscall.EPIPE)
+}
 
-	ct := resp.Header.Get("Content-Type")
-	return resp.Body, ct, nil
+// progressReader reports the running byte count of an upload as its
+// underlying reader is consumed.
+type progressReader struct {
+	r      io.Reader
+	sent   int64
+	report func(int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.report(p.sent)
+	}
+	return n, err
+}
+
+// MediaDownload is a media download in progress, as returned by
+// MediaService.Download. The caller must close Body.
+type MediaDownload struct {
+	Body        io.ReadCloser
+	ContentType string
+	// Filename is the file name from the Content-Disposition header, or
+	// empty if the server sent none. It never contains a directory.
+	Filename string
+	// Offset is the byte position Body starts at. It is zero unless a
+	// ranged request was honored by the server.
+	Offset int64
+	// Size is the total size of the media, or -1 if unknown.
+	Size int64
+	// Progress, if set, is called by WriteTo with the number of bytes of
+	// the media received so far (including Offset) and Size.
+	Progress func(received, total int64)
+
+	ctx          context.Context
+	service      *MediaService
+	resourceName string
+}
+
+// Download starts downloading media content by resource name. When offset
+// is positive, only the content from that byte onward is requested, to
+// resume a partial download; check Offset to see whether the server
+// honored the range. A 416 response to a ranged request means the partial
+// content is already complete, and yields an empty body.
+// GET /v1/media/{resourceName}?alt=media
+func (s *MediaService) Download(ctx context.Context, resourceName string, offset int64) (*MediaDownload, error) {
+	header := http.Header{}
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	params := url.Values{"alt": {"media"}}
+
+	resp, err := s.client.Download(ctx, "media/"+resourceName, params, header)
+	if err != nil {
+		var apiErr *APIError
+		if offset > 0 && errors.As(err, &apiErr) && apiErr.Code == http.StatusRequestedRangeNotSatisfiable {
+			return &MediaDownload{Body: http.NoBody, Offset: offset, Size: offset, ctx: ctx, service: s, resourceName: resourceName}, nil
+		}
+		return nil, err
+	}
+
+	d := &MediaDownload{
+		Body:         resp.Body,
+		ContentType:  resp.Header.Get("Content-Type"),
+		Size:         resp.ContentLength,
+		ctx:          ctx,
+		service:      s,
+		resourceName: resourceName,
+	}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		if name := filepath.Base(params["filename"]); name != "." && name != "/" && name != ".." {
+			d.Filename = name
+		}
+	}
+	if resp.StatusCode == http.StatusPartialContent {
+		var first, last int64
+		var total string
+		if _, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d/%s", &first, &last, &total); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("parsing Content-Range %q: %w", resp.Header.Get("Content-Range"), err)
+		}
+		d.Offset = first
+		d.Size = -1
+		if n, err := strconv.ParseInt(total, 10, 64); err == nil {
+			d.Size = n
+		}
+	}
+	return d, nil
+}
+
+// WriteTo copies the rest of the download to w and closes Body. If the
+// connection drops mid-transfer, it requests the remainder with a Range
+// header and carries on, following the client's retry policy. It returns
+// the number of bytes written to w.
+func (d *MediaDownload) WriteTo(w io.Writer) (written int64, err error) {
+	defer func() { d.Body.Close() }()
+
+	ctx, span := telemetry.Start(d.ctx, "media.download", telemetry.KindInternal,
+		telemetry.Attr{Key: "download.offset", Value: d.Offset},
+		telemetry.Attr{Key: "download.size", Value: d.Size},
+	)
+	failures := 0
+	defer func() {
+		span.SetAttr("download.bytes", written)
+		span.SetAttr("download.resumes", failures)
+		span.SetError(err)
+		span.End()
+	}()
+
+	pos := d.Offset
+	if d.Progress != nil {
+		d.Progress(pos, d.Size)
+	}
+	for {
+		body := io.Reader(d.Body)
+		if d.Progress != nil {
+			body = &progressReader{r: body, sent: pos, report: func(n int64) { d.Progress(n, d.Size) }}
+		}
+		n, err := io.Copy(destWriter{w}, body)
+		written += n
+		pos += n
+		if err == nil {
+			return written, nil
+		}
+
+		client := d.service.client
+		var werr *destError
+		if errors.As(err, &werr) {
+			return written, werr.err
+		}
+		if !retryableError(err) || failures+1 >= client.Retry.attempts() {
+			return written, err
+		}
+		failures++
+		delay := client.Retry.backoff(failures)
+		client.log().Warn("resuming download", "offset", pos, "error", err, "delay", delay.Round(time.Millisecond))
+		if err := sleepContext(ctx, delay); err != nil {
+			return written, err
+		}
+
+		next, err := d.service.Download(ctx, d.resourceName, pos)
+		if err != nil {
+			return written, err
+		}
+		d.Body.Close()
+		d.Body = next.Body
+		// A server that ignores Range resends everything; skip what we
+		// already have.
+		if next.Offset < pos {
+			if _, err := io.CopyN(io.Discard, d.Body, pos-next.Offset); err != nil {
+				return written, fmt.Errorf("skipping to byte %d: %w", pos, err)
+			}
+		}
+	}
+}
+
+// destError marks an error returned by the destination of a download, so
+// that it is not mistaken for a dropped connection.
+type destError struct{ err error }
+
+func (e *destError) Error() string { return e.err.Error() }
+
+// destWriter wraps the errors of w in destError.
+type destWriter struct{ w io.Writer }
+
+func (d destWriter) Write(p []byte) (int, error) {
+	n, err := d.w.Write(p)
+	if err != nil {
+		err = &destError{err}
+	}
+	return n, err
 }
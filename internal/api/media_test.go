@@ -0,0 +1,258 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// testData returns n bytes of non-repeating-looking content.
+func testData(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i*31 + i/251)
+	}
+	return data
+}
+
+// writeTestFile writes data to a file in a temporary directory and returns
+// its path.
+func writeTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// checkUploaded verifies that the server stored want for resp.
+func checkUploaded(t *testing.T, srv interface{ Media(string) ([]byte, bool) }, resp *api.UploadAttachmentResponse, want []byte) {
+	t.Helper()
+	if resp == nil || resp.AttachmentDataRef == nil {
+		t.Fatalf("upload response = %+v, want an attachment data reference", resp)
+	}
+	got, ok := srv.Media(resp.AttachmentDataRef.ResourceName)
+	if !ok {
+		t.Fatalf("server has no media %s", resp.AttachmentDataRef.ResourceName)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("server stored %d bytes that differ from the %d uploaded", len(got), len(want))
+	}
+}
+
+func TestUploadMultipart(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	data := testData(1000)
+
+	resp, err := api.NewMediaService(c).Upload(context.Background(), sp.Name, writeTestFile(t, "small.txt", data), nil)
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	checkUploaded(t, srv, resp, data)
+	if len(rec.requests) != 1 || strings.Contains(rec.requests[0], "uploadType=resumable") {
+		t.Errorf("requests = %v, want a single multipart upload", rec.requests)
+	}
+}
+
+func TestUploadResumable(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	data := testData(600 << 10)
+
+	var last int64
+	opts := &api.UploadOptions{
+		Resumable: true,
+		ChunkSize: 256 << 10,
+		Progress: func(sent, total int64) {
+			if sent < last || total != int64(len(data)) {
+				t.Errorf("progress went from %d to %d of %d", last, sent, total)
+			}
+			last = sent
+		},
+	}
+	resp, err := api.NewMediaService(c).Upload(context.Background(), sp.Name, writeTestFile(t, "big.bin", data), opts)
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	checkUploaded(t, srv, resp, data)
+	if last != int64(len(data)) {
+		t.Errorf("progress ended at %d, want %d", last, len(data))
+	}
+	if n := len(rec.matching("PUT /upload/")); n != 3 {
+		t.Errorf("sent %d chunks, want 3", n)
+	}
+}
+
+func TestUploadResumesAfterDrop(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	data := testData(600 << 10)
+
+	// Drop the connection partway through the second chunk, before the
+	// server has seen it.
+	var ranges []string
+	next := rec.next
+	rec.next = transportFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPut {
+			return next.RoundTrip(req)
+		}
+		cr := req.Header.Get("Content-Range")
+		ranges = append(ranges, cr)
+		if len(ranges) == 2 {
+			io.CopyN(io.Discard, req.Body, 1000)
+			req.Body.Close()
+			return nil, syscall.ECONNRESET
+		}
+		return next.RoundTrip(req)
+	})
+
+	var progress []int64
+	opts := &api.UploadOptions{
+		Resumable: true,
+		ChunkSize: 256 << 10,
+		Progress:  func(sent, total int64) { progress = append(progress, sent) },
+	}
+	resp, err := api.NewMediaService(c).Upload(context.Background(), sp.Name, writeTestFile(t, "big.bin", data), opts)
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	checkUploaded(t, srv, resp, data)
+
+	size := len(data)
+	want := []string{
+		fmt.Sprintf("bytes 0-262143/%d", size),
+		fmt.Sprintf("bytes 262144-524287/%d", size),
+		fmt.Sprintf("bytes */%d", size),
+		fmt.Sprintf("bytes 262144-524287/%d", size),
+		fmt.Sprintf("bytes 524288-%d/%d", size-1, size),
+	}
+	if fmt.Sprint(ranges) != fmt.Sprint(want) {
+		t.Errorf("Content-Ranges = %q, want %q", ranges, want)
+	}
+
+	// Progress rewinds to the committed offset after the drop.
+	rewound := false
+	for i := 1; i < len(progress); i++ {
+		if progress[i] < progress[i-1] {
+			rewound = progress[i] == 256<<10
+		}
+	}
+	if !rewound {
+		t.Errorf("progress never rewound to %d: %v", 256<<10, progress)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	srv, c, _ := newTestClient(t)
+	data := testData(100 << 10)
+	srv.AddMedia("spaces/A/attachments/B", "photo.jpg", "image/jpeg", data)
+
+	d, err := api.NewMediaService(c).Download(context.Background(), "spaces/A/attachments/B", 0)
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if d.Filename != "photo.jpg" || d.ContentType != "image/jpeg" || d.Size != int64(len(data)) {
+		t.Errorf("download = %q %q %d bytes, want photo.jpg image/jpeg %d bytes", d.Filename, d.ContentType, d.Size, len(data))
+	}
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("downloaded %d bytes that differ from the %d stored", buf.Len(), len(data))
+	}
+}
+
+func TestDownloadRange(t *testing.T) {
+	srv, c, _ := newTestClient(t)
+	data := testData(100 << 10)
+	srv.AddMedia("spaces/A/attachments/B", "photo.jpg", "image/jpeg", data)
+	svc := api.NewMediaService(c)
+
+	d, err := svc.Download(context.Background(), "spaces/A/attachments/B", 1000)
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if d.Offset != 1000 || d.Size != int64(len(data)) {
+		t.Errorf("Offset, Size = %d, %d; want 1000, %d", d.Offset, d.Size, len(data))
+	}
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data[1000:]) {
+		t.Errorf("downloaded %d bytes that differ from the last %d stored", buf.Len(), len(data)-1000)
+	}
+
+	// Resuming a download that is already complete yields nothing.
+	d, err = svc.Download(context.Background(), "spaces/A/attachments/B", int64(len(data)))
+	if err != nil {
+		t.Fatalf("Download at the end: %v", err)
+	}
+	if n, err := d.WriteTo(io.Discard); n != 0 || err != nil {
+		t.Errorf("WriteTo at the end = %d, %v; want 0, nil", n, err)
+	}
+}
+
+// truncatedBody delivers n bytes of body and then fails like a dropped
+// connection.
+type truncatedBody struct {
+	io.ReadCloser
+	n int
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.n <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if len(p) > b.n {
+		p = p[:b.n]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.n -= n
+	return n, err
+}
+
+func TestDownloadResumesAfterDrop(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	data := testData(100 << 10)
+	srv.AddMedia("spaces/A/attachments/B", "photo.jpg", "image/jpeg", data)
+
+	var ranges []string
+	next := rec.next
+	rec.next = transportFunc(func(req *http.Request) (*http.Response, error) {
+		ranges = append(ranges, req.Header.Get("Range"))
+		resp, err := next.RoundTrip(req)
+		if err == nil && len(ranges) == 1 {
+			resp.Body = &truncatedBody{ReadCloser: resp.Body, n: 30000}
+		}
+		return resp, err
+	})
+
+	d, err := api.NewMediaService(c).Download(context.Background(), "spaces/A/attachments/B", 0)
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	var buf bytes.Buffer
+	n, err := d.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if n != int64(len(data)) || !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("downloaded %d bytes that differ from the %d stored", n, len(data))
+	}
+	if want := []string{"", "bytes=30000-"}; fmt.Sprint(ranges) != fmt.Sprint(want) {
+		t.Errorf("Range headers = %q, want %q", ranges, want)
+	}
+}
@@ -2,8 +2,8 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 )
 
@@ -19,7 +19,7 @@ func NewMembersService(client *Client) *MembersService {
 
 // List retrieves members of a space.
 // parent is the space resource name (e.g. "spaces/AAAA" or just "AAAA").
-func (s *MembersService) List(ctx context.Context, parent string, pageSize int, pageToken, filter string, showInvited, showGroups, useAdminAccess bool) (json.RawMessage, error) {
+func (s *MembersService) List(ctx context.Context, parent string, pageSize int, pageToken, filter string, showInvited, showGroups, useAdminAccess bool) (*ListMembershipsResponse, error) {
 	parent = NormalizeName(parent, "spaces/")
 	path := fmt.Sprintf("%s/members", parent)
 
@@ -31,48 +31,59 @@ func (s *MembersService) List(ctx context.Context, parent string, pageSize int,
 	AddQueryParamBool(params, "showGroups", showGroups)
 	AddQueryParamBool(params, "useAdminAccess", useAdminAccess)
 
-	return s.client.Get(ctx, path, params)
+	return decode[ListMembershipsResponse](s.client.Get(ctx, path, params))
+}
+
+// All iterates over every member of a space, fetching pages on demand.
+func (s *MembersService) All(ctx context.Context, parent, filter string, showInvited, showGroups, useAdminAccess bool, opts PageOptions) iter.Seq2[*Membership, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, pageToken string, pageSize int) ([]*Membership, string, error) {
+		resp, err := s.List(ctx, parent, pageSize, pageToken, filter, showInvited, showGroups, useAdminAccess)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Memberships, resp.NextPageToken, nil
+	})
 }
 
 // Get retrieves a single membership by its resource name.
 // name is the full resource name (e.g. "spaces/AAAA/members/123456").
-func (s *MembersService) Get(ctx context.Context, name string, useAdminAccess bool) (json.RawMessage, error) {
+func (s *MembersService) Get(ctx context.Context, name string, useAdminAccess bool) (*Membership, error) {
 	params := url.Values{}
 	AddQueryParamBool(params, "useAdminAccess", useAdminAccess)
 
-	return s.client.Get(ctx, name, params)
+	return decode[Membership](s.client.Get(ctx, name, params))
 }
 
 // Create adds a new member to a space.
 // parent is the space resource name (e.g. "spaces/AAAA" or just "AAAA").
 // membership is the membership resource body to create.
-func (s *MembersService) Create(ctx context.Context, parent string, membership map[string]interface{}, useAdminAccess bool) (json.RawMessage, error) {
+func (s *MembersService) Create(ctx context.Context, parent string, membership *Membership, useAdminAccess bool) (*Membership, error) {
 	parent = NormalizeName(parent, "spaces/")
 	path := fmt.Sprintf("%s/members", parent)
 
 	params := url.Values{}
 	AddQueryParamBool(params, "useAdminAccess", useAdminAccess)
 
-	return s.client.Post(ctx, path, params, membership)
+	return decode[Membership](s.client.Post(ctx, path, params, membership))
 }
 
 // Patch updates an existing membership.
 // name is the full resource name (e.g. "spaces/AAAA/members/123456").
 // membership is the membership resource body with updated fields.
 // updateMask specifies which fields to update (comma-separated field paths).
-func (s *MembersService) Patch(ctx context.Context, name string, membership map[string]interface{}, updateMask string, useAdminAccess bool) (json.RawMessage, error) {
+func (s *MembersService) Patch(ctx context.Context, name string, membership *Membership, updateMask string, useAdminAccess bool) (*Membership, error) {
 	params := url.Values{}
 	AddQueryParam(params, "updateMask", updateMask)
 	AddQueryParamBool(params, "useAdminAccess", useAdminAccess)
 
-	return s.client.Patch(ctx, name, params, membership)
+	return decode[Membership](s.client.Patch(ctx, name, params, membership))
 }
 
-// Delete removes a membership from a space.
+// Delete removes a membership from a space and returns the deleted membership.
 // name is the full resource name (e.g. "spaces/AAAA/members/123456").
-func (s *MembersService) Delete(ctx context.Context, name string, useAdminAccess bool) (json.RawMessage, error) {
+func (s *MembersService) Delete(ctx context.Context, name string, useAdminAccess bool) (*Membership, error) {
 	params := url.Values{}
 	AddQueryParamBool(params, "useAdminAccess", useAdminAccess)
 
-	return s.client.Delete(ctx, name, params)
+	return decode[Membership](s.client.Delete(ctx, name, params))
 }
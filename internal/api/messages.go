@@ -2,8 +2,8 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 )
 
@@ -20,7 +20,7 @@ func NewMessagesService(client *Client) *MessagesService {
 
 // List retrieves messages from a space.
 // GET /v1/{parent}/messages
-func (s *MessagesService) List(ctx context.Context, parent string, pageSize int, pageToken, filter, orderBy string, showDeleted bool) (json.RawMessage, error) {
+func (s *MessagesService) List(ctx context.Context, parent string, pageSize int, pageToken, filter, orderBy string, showDeleted bool) (*ListMessagesResponse, error) {
 	parent = NormalizeName(parent, "spaces/")
 	path := fmt.Sprintf("%s/messages", parent)
 
@@ -31,19 +31,30 @@ func (s *MessagesService) List(ctx context.Context, parent string, pageSize int,
 	AddQueryParam(params, "orderBy", orderBy)
 	AddQueryParamBool(params, "showDeleted", showDeleted)
 
-	return s.client.Get(ctx, path, params)
+	return decode[ListMessagesResponse](s.client.Get(ctx, path, params))
+}
+
+// All iterates over every message in a space, fetching pages on demand.
+func (s *MessagesService) All(ctx context.Context, parent, filter, orderBy string, showDeleted bool, opts PageOptions) iter.Seq2[*Message, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, pageToken string, pageSize int) ([]*Message, string, error) {
+		resp, err := s.List(ctx, parent, pageSize, pageToken, filter, orderBy, showDeleted)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Messages, resp.NextPageToken, nil
+	})
 }
 
 // Get retrieves a single message by its full resource name.
 // GET /v1/{name}
 // Name format: spaces/{space}/messages/{message}
-func (s *MessagesService) Get(ctx context.Context, name string) (json.RawMessage, error) {
-	return s.client.Get(ctx, name, nil)
+func (s *MessagesService) Get(ctx context.Context, name string) (*Message, error) {
+	return decode[Message](s.client.Get(ctx, name, nil))
 }
 
 // Create sends a new message to a space.
 // POST /v1/{parent}/messages
-func (s *MessagesService) Create(ctx context.Context, parent string, message map[string]interface{}, threadKey, requestID, messageID, messageReplyOption string) (json.RawMessage, error) {
+func (s *MessagesService) Create(ctx context.Context, parent string, message *Message, threadKey, requestID, messageID, messageReplyOption string) (*Message, error) {
 	parent = NormalizeName(parent, "spaces/")
 	path := fmt.Sprintf("%s/messages", parent)
 
@@ -53,36 +64,36 @@ func (s *MessagesService) Create(ctx context.Context, parent string, message map
 	AddQueryParam(params, "messageId", messageID)
 	AddQueryParam(params, "messageReplyOption", messageReplyOption)
 
-	return s.client.Post(ctx, path, params, message)
+	return decode[Message](s.client.Post(ctx, path, params, message))
 }
 
 // Patch partially updates a message.
 // PATCH /v1/{name}
-func (s *MessagesService) Patch(ctx context.Context, name string, message map[string]interface{}, updateMask string, allowMissing bool) (json.RawMessage, error) {
+func (s *MessagesService) Patch(ctx context.Context, name string, message *Message, updateMask string, allowMissing bool) (*Message, error) {
 	params := url.Values{}
 	AddQueryParam(params, "updateMask", updateMask)
 	AddQueryParamBool(params, "allowMissing", allowMissing)
 
-	return s.client.Patch(ctx, name, params, message)
+	return decode[Message](s.client.Patch(ctx, name, params, message))
 }
 
 // Update fully replaces a message.
 // PUT /v1/{name}
-func (s *MessagesService) Update(ctx context.Context, name string, message map[string]interface{}, updateMask string, allowMissing bool) (json.RawMessage, error) {
+func (s *MessagesService) Update(ctx context.Context, name string, message *Message, updateMask string, allowMissing bool) (*Message, error) {
 	params := url.Values{}
 	AddQueryParam(params, "updateMask", updateMask)
 	AddQueryParamBool(params, "allowMissing", allowMissing)
 
-	return s.client.Put(ctx, name, params, message)
+	return decode[Message](s.client.Put(ctx, name, params, message))
 }
 
 // Delete removes a message.
 // DELETE /v1/{name}
 // If force is true, the force query parameter is set, which also deletes
 // threaded replies to the message.
-func (s *MessagesService) Delete(ctx context.Context, name string, force bool) (json.RawMessage, error) {
+func (s *MessagesService) Delete(ctx context.Context, name string, force bool) (*Empty, error) {
 	params := url.Values{}
 	AddQueryParamBool(params, "force", force)
 
-	return s.client.Delete(ctx, name, params)
+	return decode[Empty](s.client.Delete(ctx, name, params))
 }
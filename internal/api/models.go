@@ -0,0 +1,3156 @@
+// Code generated by genmodels from the chat v1 discovery document (revision 20260211). DO NOT EDIT.
+
+package api
+
+// AccessSettings: Represents the [access
+// setting](https://support.google.com/chat/answer/11971020) of the space.
+type AccessSettings struct {
+	rawJSON
+
+	// AccessState: Output only. Indicates the access state of the space. Possible
+	// values: ACCESS_STATE_UNSPECIFIED, PRIVATE, DISCOVERABLE.
+	AccessState string `json:"accessState,omitempty"`
+
+	// Audience: Optional. The resource name of the [target
+	// audience](https://support.google.com/a/answer/9934697) who can discover the
+	// space, join the space, and preview the messages in the space.
+	Audience string `json:"audience,omitempty"`
+}
+
+// AccessoryWidget: One or more interactive widgets that appear at the bottom of
+// a message. For details, see [Add interactive widgets at the bottom of a
+// message](https://developers.google.com/workspace/chat/create-messages#add-accessory-widgets).
+type AccessoryWidget struct {
+	rawJSON
+
+	// ButtonList: A list of buttons.
+	ButtonList *GoogleAppsCardV1ButtonList `json:"buttonList,omitempty"`
+}
+
+// ActionParameter: List of string parameters to supply when the action method
+// is invoked. For example, consider three snooze buttons: snooze now, snooze
+// one day, snooze next week. You might use `action method = snooze()`, passing
+// the snooze type and snooze time in the list of string parameters.
+type ActionParameter struct {
+	rawJSON
+
+	// Key: The name of the parameter for the action script.
+	Key string `json:"key,omitempty"`
+
+	// Value: The value of the parameter.
+	Value string `json:"value,omitempty"`
+}
+
+// ActionResponse: Parameters that a Chat app can use to configure how its
+// response is posted.
+type ActionResponse struct {
+	rawJSON
+
+	// DialogAction: Input only. A response to an interaction event related to a
+	// [dialog](https://developers.google.com/workspace/chat/dialogs).
+	DialogAction *DialogAction `json:"dialogAction,omitempty"`
+
+	// Type: Input only. The type of Chat app response. Possible values:
+	// TYPE_UNSPECIFIED, NEW_MESSAGE, UPDATE_MESSAGE, UPDATE_USER_MESSAGE_CARDS,
+	// REQUEST_CONFIG, DIALOG, UPDATE_WIDGET.
+	Type string `json:"type,omitempty"`
+
+	// UpdatedWidget: Input only. The response of the updated widget.
+	UpdatedWidget *UpdatedWidget `json:"updatedWidget,omitempty"`
+
+	// URL: Input only. URL for users to authenticate or configure.
+	URL string `json:"url,omitempty"`
+}
+
+// ActionStatus: Represents the status for a request to either invoke or submit
+// a [dialog](https://developers.google.com/workspace/chat/dialogs).
+type ActionStatus struct {
+	rawJSON
+
+	// StatusCode: The status code. Possible values: OK, CANCELLED, UNKNOWN,
+	// INVALID_ARGUMENT, DEADLINE_EXCEEDED, NOT_FOUND, ALREADY_EXISTS,
+	// PERMISSION_DENIED, UNAUTHENTICATED, RESOURCE_EXHAUSTED, FAILED_PRECONDITION,
+	// ABORTED, OUT_OF_RANGE, UNIMPLEMENTED, INTERNAL, UNAVAILABLE, DATA_LOSS.
+	StatusCode string `json:"statusCode,omitempty"`
+
+	// UserFacingMessage: The message to send users about the status of their
+	// request.
+	UserFacingMessage string `json:"userFacingMessage,omitempty"`
+}
+
+// Annotation: Output only. Annotations can be associated with the plain-text
+// body of the message or with chips that link to Google Workspace resources
+// like Google Docs or Sheets with `start_index` and `length` of 0. To add basic
+// formatting to a text message, see [Format text
+// messages](https://developers.google.com/workspace/chat/format-messages).
+// Example plain-text message body: ``` Hello @FooBot how are you!" ``` The
+// corresponding annotations metadata: ``` "annotations":[{
+// "type":"USER_MENTION", "startIndex":6, "length":7, "userMention": { "user": {
+// "name":"users/{user}", "displayName":"FooBot",
+// "avatarUrl":"https://goo.gl/aeDtrS", "type":"BOT" }, "type":"MENTION" } }]
+// ```
+type Annotation struct {
+	rawJSON
+
+	// CustomEmojiMetadata: The metadata for a custom emoji.
+	CustomEmojiMetadata *CustomEmojiMetadata `json:"customEmojiMetadata,omitempty"`
+
+	// Length: Length of the substring in the plain-text message body this
+	// annotation corresponds to.
+	Length int64 `json:"length,omitempty"`
+
+	// RichLinkMetadata: The metadata for a rich link.
+	RichLinkMetadata *RichLinkMetadata `json:"richLinkMetadata,omitempty"`
+
+	// SlashCommand: The metadata for a slash command.
+	SlashCommand *SlashCommandMetadata `json:"slashCommand,omitempty"`
+
+	// StartIndex: Start index (0-based, inclusive) in the plain-text message body
+	// this annotation corresponds to.
+	StartIndex int64 `json:"startIndex,omitempty"`
+
+	// Type: The type of this annotation. Possible values:
+	// ANNOTATION_TYPE_UNSPECIFIED, USER_MENTION, SLASH_COMMAND, RICH_LINK,
+	// CUSTOM_EMOJI.
+	Type string `json:"type,omitempty"`
+
+	// UserMention: The metadata of user mention.
+	UserMention *UserMentionMetadata `json:"userMention,omitempty"`
+}
+
+// AppCommandMetadata: Metadata about a [Chat app
+// command](https://developers.google.com/workspace/chat/commands).
+type AppCommandMetadata struct {
+	rawJSON
+
+	// AppCommandID: The ID for the command specified in the Chat API
+	// configuration.
+	AppCommandID int64 `json:"appCommandId,omitempty"`
+
+	// AppCommandType: The type of Chat app command. Possible values:
+	// APP_COMMAND_TYPE_UNSPECIFIED, SLASH_COMMAND, QUICK_COMMAND.
+	AppCommandType string `json:"appCommandType,omitempty"`
+}
+
+// AttachedGif: A GIF image that's specified by a URL.
+type AttachedGif struct {
+	rawJSON
+
+	// URI: Output only. The URL that hosts the GIF image.
+	URI string `json:"uri,omitempty"`
+}
+
+// Attachment: An attachment in Google Chat.
+type Attachment struct {
+	rawJSON
+
+	// AttachmentDataRef: Optional. A reference to the attachment data.
+	AttachmentDataRef *AttachmentDataRef `json:"attachmentDataRef,omitempty"`
+
+	// ContentName: Output only. The original file name for the content, not the
+	// full path.
+	ContentName string `json:"contentName,omitempty"`
+
+	// ContentType: Output only. The content type (MIME type) of the file.
+	ContentType string `json:"contentType,omitempty"`
+
+	// DownloadURI: Output only. The download URL which should be used to allow a
+	// human user to download the attachment.
+	DownloadURI string `json:"downloadUri,omitempty"`
+
+	// DriveDataRef: Output only. A reference to the Google Drive attachment.
+	DriveDataRef *DriveDataRef `json:"driveDataRef,omitempty"`
+
+	// Name: Identifier. Resource name of the attachment.
+	Name string `json:"name,omitempty"`
+
+	// Source: Output only. The source of the attachment. Possible values:
+	// SOURCE_UNSPECIFIED, DRIVE_FILE, UPLOADED_CONTENT.
+	Source string `json:"source,omitempty"`
+
+	// ThumbnailURI: Output only. The thumbnail URL which should be used to preview
+	// the attachment to a human user.
+	ThumbnailURI string `json:"thumbnailUri,omitempty"`
+}
+
+// AttachmentDataRef: A reference to the attachment data.
+type AttachmentDataRef struct {
+	rawJSON
+
+	// AttachmentUploadToken: Optional. Opaque token containing a reference to an
+	// uploaded attachment.
+	AttachmentUploadToken string `json:"attachmentUploadToken,omitempty"`
+
+	// ResourceName: Optional. The resource name of the attachment data.
+	ResourceName string `json:"resourceName,omitempty"`
+}
+
+// Button: A button. Can be a text button or an image button.
+type Button struct {
+	rawJSON
+
+	// ImageButton: A button with image and `onclick` action.
+	ImageButton *ImageButton `json:"imageButton,omitempty"`
+
+	// TextButton: A button with text and `onclick` action.
+	TextButton *TextButton `json:"textButton,omitempty"`
+}
+
+// CalendarEventLinkData: Data for Calendar event links.
+type CalendarEventLinkData struct {
+	rawJSON
+
+	// CalendarID: The [Calendar
+	// identifier](https://developers.google.com/workspace/calendar/api/v3/reference/calendars)
+	// of the linked Calendar.
+	CalendarID string `json:"calendarId,omitempty"`
+
+	// EventID: The [Event
+	// identifier](https://developers.google.com/workspace/calendar/api/v3/reference/events)
+	// of the linked Calendar event.
+	EventID string `json:"eventId,omitempty"`
+}
+
+// Card: A card is a UI element that can contain UI widgets such as text and
+// images.
+type Card struct {
+	rawJSON
+
+	// CardActions: The actions of this card.
+	CardActions []*CardAction `json:"cardActions,omitempty"`
+
+	// Header: The header of the card.
+	Header *CardHeader `json:"header,omitempty"`
+
+	// Name: Name of the card.
+	Name string `json:"name,omitempty"`
+
+	// Sections: Sections are separated by a line divider.
+	Sections []*Section `json:"sections,omitempty"`
+}
+
+// CardAction: A card action is the action associated with the card. For an
+// invoice card, a typical action would be: delete invoice, email invoice or
+// open the invoice in browser. Not supported by Google Chat apps.
+type CardAction struct {
+	rawJSON
+
+	// ActionLabel: The label used to be displayed in the action menu item.
+	ActionLabel string `json:"actionLabel,omitempty"`
+
+	// OnClick: The onclick action for this action item.
+	OnClick *OnClick `json:"onClick,omitempty"`
+}
+
+// CardHeader:
+type CardHeader struct {
+	rawJSON
+
+	// ImageStyle: The image's type (for example, square border or circular
+	// border). Possible values: IMAGE_STYLE_UNSPECIFIED, IMAGE, AVATAR.
+	ImageStyle string `json:"imageStyle,omitempty"`
+
+	// ImageURL: The URL of the image in the card header.
+	ImageURL string `json:"imageUrl,omitempty"`
+
+	// Subtitle: The subtitle of the card header.
+	Subtitle string `json:"subtitle,omitempty"`
+
+	// Title: The title must be specified.
+	Title string `json:"title,omitempty"`
+}
+
+// CardWithId: A
+// [card](https://developers.google.com/workspace/chat/api/reference/rest/v1/cards)
+// in a Google Chat message. Only Chat apps can create cards. If your Chat app
+// [authenticates as a
+// user](https://developers.google.com/workspace/chat/authenticate-authorize-chat-user),
+// the message can't contain cards. [Card
+// builder](https://addons.gsuite.google.com/uikit/builder)
+type CardWithId struct {
+	rawJSON
+
+	// Card: A card. Maximum size is 32 KB.
+	Card *GoogleAppsCardV1Card `json:"card,omitempty"`
+
+	// CardID: Required if the message contains multiple cards.
+	CardID string `json:"cardId,omitempty"`
+}
+
+// ChatAppLogEntry: JSON payload of error messages. If the Cloud Logging API is
+// enabled, these error messages are logged to [Google Cloud
+// Logging](https://cloud.google.com/logging/docs).
+type ChatAppLogEntry struct {
+	rawJSON
+
+	// Deployment: The deployment that caused the error.
+	Deployment string `json:"deployment,omitempty"`
+
+	// DeploymentFunction: The unencrypted `callback_method` name that was running
+	// when the error was encountered.
+	DeploymentFunction string `json:"deploymentFunction,omitempty"`
+
+	// Error: The error code and message.
+	Error *Status `json:"error,omitempty"`
+}
+
+// ChatClientDataSourceMarkup: For a `SelectionInput` widget that uses a
+// multiselect menu, a data source from Google Chat. The data source populates
+// selection items for the multiselect menu. For example, a user can select
+// Google Chat spaces that they're a member of. [Google Chat
+// apps](https://developers.google.com/workspace/chat):
+type ChatClientDataSourceMarkup struct {
+	rawJSON
+
+	// SpaceDataSource: Google Chat spaces that the user is a member of.
+	SpaceDataSource *SpaceDataSource `json:"spaceDataSource,omitempty"`
+}
+
+// ChatSpaceLinkData: Data for Chat space links.
+type ChatSpaceLinkData struct {
+	rawJSON
+
+	// Message: The message of the linked Chat space resource.
+	Message string `json:"message,omitempty"`
+
+	// Space: The space of the linked Chat space resource.
+	Space string `json:"space,omitempty"`
+
+	// Thread: The thread of the linked Chat space resource.
+	Thread string `json:"thread,omitempty"`
+}
+
+// Color: Represents a color in the RGBA color space. This representation is
+// designed for simplicity of conversion to and from color representations in
+// various languages over compactness. For example, the fields of this
+// representation can be trivially provided to the constructor of
+// `java.awt.Color` in Java; it can also be trivially provided to UIColor's
+// `+colorWithRed:green:blue:alpha` method in iOS; and, with just a little work,
+// it can be easily formatted into a CSS `rgba()` string in JavaScript. This
+// reference page doesn't have information about the absolute color space that
+// should be used to interpret the RGB value—for example, sRGB, Adobe RGB,
+// DCI-P3, and BT.2020. By default, applications should assume the sRGB color
+// space. When color equality needs to be decided, implementations, unless
+// documented otherwise, treat two colors as equal if all their red, green,
+// blue, and alpha values each differ by at most `1e-5`. Example (Java): import
+// com.google.type.Color; // ... public static java.awt.Color fromProto(Color
+// protocolor) { float alpha = protocolor.hasAlpha() ?
+// protocolor.getAlpha().getValue() : 1.0; return new java.awt.Color(
+// protocolor.getRed(), protocolor.getGreen(), protocolor.getBlue(), alpha); }
+// public static Color toProto(java.awt.Color color) { float red = (float)
+// color.getRed(); float green = (float) color.getGreen(); float blue = (float)
+// color.getBlue(); float denominator = 255.0; Color.Builder resultBuilder =
+// Color .newBuilder() .setRed(red / denominator) .setGreen(green / denominator)
+// .setBlue(blue / denominator); int alpha = color.getAlpha(); if (alpha != 255)
+// { result.setAlpha( FloatValue .newBuilder() .setValue(((float) alpha) /
+// denominator) .build()); } return resultBuilder.build(); } // ... Example (iOS
+// / Obj-C): // ... static UIColor* fromProto(Color* protocolor) { float red =
+// [protocolor red]; float green = [protocolor green]; float blue = [protocolor
+// blue]; FloatValue* alpha_wrapper = [protocolor alpha]; float alpha = 1.0; if
+// (alpha_wrapper != nil) { alpha = [alpha_wrapper value]; } return [UIColor
+// colorWithRed:red green:green blue:blue alpha:alpha]; } static Color*
+// toProto(UIColor* color) { CGFloat red, green, blue, alpha; if (![color
+// getRed:&red green:&green blue:&blue alpha:&alpha]) { return nil; } Color*
+// result = [[Color alloc] init]; [result setRed:red]; [result setGreen:green];
+// [result setBlue:blue]; if (alpha <= 0.9999) { [result
+// setAlpha:floatWrapperWithValue(alpha)]; } [result autorelease]; return
+// result; } // ... Example (JavaScript): // ... var protoToCssColor =
+// function(rgb_color) { var redFrac = rgb_color.red || 0.0; var greenFrac =
+// rgb_color.green || 0.0; var blueFrac = rgb_color.blue || 0.0; var red =
+// Math.floor(redFrac * 255); var green = Math.floor(greenFrac * 255); var blue
+// = Math.floor(blueFrac * 255); if (!('alpha' in rgb_color)) { return
+// rgbToCssColor(red, green, blue); } var alphaFrac = rgb_color.alpha.value ||
+// 0.0; var rgbParams = [red, green, blue].join(','); return ['rgba(',
+// rgbParams, ',', alphaFrac, ')'].join(”); }; var rgbToCssColor =
+// function(red, green, blue) { var rgbNumber = new Number((red << 16) | (green
+// << 8) | blue); var hexString = rgbNumber.toString(16); var missingZeros = 6 -
+// hexString.length; var resultBuilder = ['#']; for (var i = 0; i <
+// missingZeros; i++) { resultBuilder.push('0'); }
+// resultBuilder.push(hexString); return resultBuilder.join(”); }; // ...
+type Color struct {
+	rawJSON
+
+	// Alpha: The fraction of this color that should be applied to the pixel.
+	Alpha float64 `json:"alpha,omitempty"`
+
+	// Blue: The amount of blue in the color as a value in the interval [0, 1].
+	Blue float64 `json:"blue,omitempty"`
+
+	// Green: The amount of green in the color as a value in the interval [0, 1].
+	Green float64 `json:"green,omitempty"`
+
+	// Red: The amount of red in the color as a value in the interval [0, 1].
+	Red float64 `json:"red,omitempty"`
+}
+
+// CommonEventObject: The common event object is the portion of the overall
+// event object that carries general, host-independent information to the add-on
+// from the user's client. This information includes details such as the user's
+// locale, host app, and platform. In addition to homepage and contextual
+// triggers, add-ons construct and pass event objects to [action callback
+// functions](https://developers.google.com/workspace/add-ons/concepts/actions#callback_functions)
+// when the user interacts with widgets. Your add-on's callback function can
+// query the common event object to determine the contents of open widgets in
+// the user's client. For example, your add-on can locate the text a user has
+// entered into a
+// [TextInput](https://developers.google.com/apps-script/reference/card-service/text-input)
+// widget in the `eventObject.commentEventObject.formInputs` object. For Chat
+// apps, the name of the function that the user invoked when interacting with a
+// widget.
+type CommonEventObject struct {
+	rawJSON
+
+	// FormInputs: A map containing the current values of the widgets in the
+	// displayed card.
+	FormInputs map[string]*Inputs `json:"formInputs,omitempty"`
+
+	// HostApp: Indicates the host app the add-on is active in when the event
+	// object is generated. Possible values: UNSPECIFIED_HOST_APP, GMAIL, CALENDAR,
+	// DRIVE, DEMO, DOCS, MEET, SHEETS, SLIDES, DRAWINGS, CHAT.
+	HostApp string `json:"hostApp,omitempty"`
+
+	// InvokedFunction: Name of the function to invoke.
+	InvokedFunction string `json:"invokedFunction,omitempty"`
+
+	// Parameters: Any additional parameters you supply to an action using
+	// [`actionParameters`](https://developers.google.com/workspace/add-ons/reference/rpc/google.apps.card.v1#google.apps.card.v1.Action.ActionParameter)
+	// or
+	// [`Action.setParameters()`](https://developers.google.com/apps-script/reference/card-service/action#setparametersparameters).
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Platform: The platform enum which indicates the platform where the event
+	// originates (`WEB`, `IOS`, or `ANDROID`). Possible values: UNKNOWN_PLATFORM,
+	// WEB, IOS, ANDROID.
+	Platform string `json:"platform,omitempty"`
+
+	// TimeZone: **Disabled by default.** The timezone ID and offset from
+	// Coordinated Universal Time (UTC).
+	TimeZone *TimeZone `json:"timeZone,omitempty"`
+
+	// UserLocale: **Disabled by default.** The user's language and country/region
+	// identifier in the format of [ISO
+	// 639](https://wikipedia.org/wiki/ISO_639_macrolanguage) language code-[ISO
+	// 3166](https://wikipedia.org/wiki/ISO_3166) country/region code.
+	UserLocale string `json:"userLocale,omitempty"`
+}
+
+// CompleteImportSpaceRequest: Request message for completing the import process
+// for a space.
+type CompleteImportSpaceRequest struct {
+	rawJSON
+}
+
+// CompleteImportSpaceResponse: Response message for completing the import
+// process for a space.
+type CompleteImportSpaceResponse struct {
+	rawJSON
+
+	// Space: The import mode space.
+	Space *Space `json:"space,omitempty"`
+}
+
+// CustomEmoji: Represents a [custom
+// emoji](https://support.google.com/chat/answer/12800149).
+type CustomEmoji struct {
+	rawJSON
+
+	// EmojiName: Optional. Immutable. User-provided name for the custom emoji,
+	// which is unique within the organization.
+	EmojiName string `json:"emojiName,omitempty"`
+
+	// Name: Identifier. The resource name of the custom emoji, assigned by the
+	// server.
+	Name string `json:"name,omitempty"`
+
+	// Payload: Optional. Input only. Payload data. Required when the custom emoji
+	// is created.
+	Payload *CustomEmojiPayload `json:"payload,omitempty"`
+
+	// TemporaryImageURI: Output only. A temporary image URL for the custom emoji,
+	// valid for at least 10 minutes.
+	TemporaryImageURI string `json:"temporaryImageUri,omitempty"`
+
+	// UID: Output only. Unique key for the custom emoji resource.
+	UID string `json:"uid,omitempty"`
+}
+
+// CustomEmojiMetadata: Annotation metadata for custom emoji.
+type CustomEmojiMetadata struct {
+	rawJSON
+
+	// CustomEmoji: The custom emoji.
+	CustomEmoji *CustomEmoji `json:"customEmoji,omitempty"`
+}
+
+// CustomEmojiPayload: Payload data for the custom emoji.
+type CustomEmojiPayload struct {
+	rawJSON
+
+	// FileContent: Required. Input only. The image used for the custom emoji.
+	FileContent string `json:"fileContent,omitempty"`
+
+	// Filename: Required. Input only. The image file name.
+	Filename string `json:"filename,omitempty"`
+}
+
+// DateInput: Date input values.
+type DateInput struct {
+	rawJSON
+
+	// MsSinceEpoch: Time since epoch time, in milliseconds.
+	MsSinceEpoch int64 `json:"msSinceEpoch,omitempty,string"`
+}
+
+// DateTimeInput: Date and time input values.
+type DateTimeInput struct {
+	rawJSON
+
+	// HasDate: Whether the `datetime` input includes a calendar date.
+	HasDate bool `json:"hasDate,omitempty"`
+
+	// HasTime: Whether the `datetime` input includes a timestamp.
+	HasTime bool `json:"hasTime,omitempty"`
+
+	// MsSinceEpoch: Time since epoch time, in milliseconds.
+	MsSinceEpoch int64 `json:"msSinceEpoch,omitempty,string"`
+}
+
+// DeletionMetadata: Information about a deleted message. A message is deleted
+// when `delete_time` is set.
+type DeletionMetadata struct {
+	rawJSON
+
+	// DeletionType: Indicates who deleted the message. Possible values:
+	// DELETION_TYPE_UNSPECIFIED, CREATOR, SPACE_OWNER, ADMIN, APP_MESSAGE_EXPIRY,
+	// CREATOR_VIA_APP, SPACE_OWNER_VIA_APP, SPACE_MEMBER.
+	DeletionType string `json:"deletionType,omitempty"`
+}
+
+// DeprecatedEvent: A Google Chat app interaction event that represents and
+// contains data about a user's interaction with a Chat app. To configure your
+// Chat app to receive interaction events, see [Receive and respond to user
+// interactions](https://developers.google.com/workspace/chat/receive-respond-interactions).
+// In addition to receiving events from user interactions, Chat apps can receive
+// events about changes to spaces, such as when a new member is added to a
+// space. To learn about space events, see [Work with events from Google
+// Chat](https://developers.google.com/workspace/chat/events-overview). Note:
+// This event is only used for [Chat interaction
+// events](https://developers.google.com/workspace/chat/receive-respond-interactions).
+// If your Chat app is built as a [Google Workspace
+// add-on](https://developers.google.com/workspace/add-ons/chat/build), see
+// [Chat event
+// objects](https://developers.google.com/workspace/add-ons/concepts/event-objects#chat-event-object)
+// in the add-ons documentation.
+type DeprecatedEvent struct {
+	rawJSON
+
+	// Action: For `CARD_CLICKED` interaction events, the form action data
+	// associated when a user clicks a card or dialog.
+	Action *FormAction `json:"action,omitempty"`
+
+	// AppCommandMetadata: Metadata about a Chat app command.
+	AppCommandMetadata *AppCommandMetadata `json:"appCommandMetadata,omitempty"`
+
+	// Common: Represents information about the user's client, such as locale, host
+	// app, and platform.
+	Common *CommonEventObject `json:"common,omitempty"`
+
+	// ConfigCompleteRedirectURL: This URL is populated for `MESSAGE`,
+	// `ADDED_TO_SPACE`, and `APP_COMMAND` interaction events.
+	ConfigCompleteRedirectURL string `json:"configCompleteRedirectUrl,omitempty"`
+
+	// DialogEventType: The type of
+	// [dialog](https://developers.google.com/workspace/chat/dialogs) interaction
+	// event received. Possible values: TYPE_UNSPECIFIED, REQUEST_DIALOG,
+	// SUBMIT_DIALOG, CANCEL_DIALOG.
+	DialogEventType string `json:"dialogEventType,omitempty"`
+
+	// EventTime: The timestamp indicating when the interaction event occurred.
+	EventTime string `json:"eventTime,omitempty"`
+
+	// IsDialogEvent: For `CARD_CLICKED` and `MESSAGE` interaction events, whether
+	// the user is interacting with or about to interact with a
+	// [dialog](https://developers.google.com/workspace/chat/dialogs).
+	IsDialogEvent bool `json:"isDialogEvent,omitempty"`
+
+	// Message: For `ADDED_TO_SPACE`, `CARD_CLICKED`, and `MESSAGE` interaction
+	// events, the message that triggered the interaction event, if applicable.
+	Message *Message `json:"message,omitempty"`
+
+	// Space: The space in which the user interacted with the Chat app.
+	Space *Space `json:"space,omitempty"`
+
+	// Thread: The thread in which the user interacted with the Chat app.
+	Thread *Thread `json:"thread,omitempty"`
+
+	// ThreadKey: The Chat app-defined key for the thread related to the
+	// interaction event.
+	ThreadKey string `json:"threadKey,omitempty"`
+
+	// Token: A secret value that legacy Chat apps can use to verify if a request
+	// is from Google.
+	Token string `json:"token,omitempty"`
+
+	// Type: The [type](/workspace/chat/api/reference/rest/v1/EventType) of user
+	// interaction with the Chat app, such as `MESSAGE` or `ADDED_TO_SPACE`.
+	// Possible values: UNSPECIFIED, MESSAGE, ADDED_TO_SPACE, REMOVED_FROM_SPACE,
+	// CARD_CLICKED, WIDGET_UPDATED, APP_COMMAND.
+	Type string `json:"type,omitempty"`
+
+	// User: The user that interacted with the Chat app.
+	User *User `json:"user,omitempty"`
+}
+
+// Dialog: Wrapper around the card body of the dialog.
+type Dialog struct {
+	rawJSON
+
+	// Body: Input only. Body of the dialog, which is rendered in a modal.
+	Body *GoogleAppsCardV1Card `json:"body,omitempty"`
+}
+
+// DialogAction: Contains a
+// [dialog](https://developers.google.com/workspace/chat/dialogs) and request
+// status code.
+type DialogAction struct {
+	rawJSON
+
+	// ActionStatus: Input only. Status for a request to either invoke or submit a
+	// [dialog](https://developers.google.com/workspace/chat/dialogs).
+	ActionStatus *ActionStatus `json:"actionStatus,omitempty"`
+
+	// Dialog: Input only.
+	// [Dialog](https://developers.google.com/workspace/chat/dialogs) for the
+	// request.
+	Dialog *Dialog `json:"dialog,omitempty"`
+}
+
+// DriveDataRef: A reference to the data of a drive attachment.
+type DriveDataRef struct {
+	rawJSON
+
+	// DriveFileID: The ID for the drive file.
+	DriveFileID string `json:"driveFileId,omitempty"`
+}
+
+// DriveLinkData: Data for Google Drive links.
+type DriveLinkData struct {
+	rawJSON
+
+	// DriveDataRef: A
+	// [DriveDataRef](https://developers.google.com/workspace/chat/api/reference/rest/v1/spaces.messages.attachments#drivedataref)
+	// which references a Google Drive file.
+	DriveDataRef *DriveDataRef `json:"driveDataRef,omitempty"`
+
+	// MimeType: The mime type of the linked Google Drive resource.
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// Emoji: An emoji that is used as a reaction to a message.
+type Emoji struct {
+	rawJSON
+
+	// CustomEmoji: A custom emoji.
+	CustomEmoji *CustomEmoji `json:"customEmoji,omitempty"`
+
+	// Unicode: Optional. A basic emoji represented by a unicode string.
+	Unicode string `json:"unicode,omitempty"`
+}
+
+// EmojiReactionSummary: The number of people who reacted to a message with a
+// specific emoji.
+type EmojiReactionSummary struct {
+	rawJSON
+
+	// Emoji: Output only. Emoji associated with the reactions.
+	Emoji *Emoji `json:"emoji,omitempty"`
+
+	// ReactionCount: Output only. The total number of reactions using the
+	// associated emoji.
+	ReactionCount int64 `json:"reactionCount,omitempty"`
+}
+
+// Empty: A generic empty message that you can re-use to avoid defining
+// duplicated empty messages in your APIs. A typical example is to use it as the
+// request or the response type of an API method. For instance: service Foo {
+// rpc Bar(google.protobuf.Empty) returns (google.protobuf.Empty); }
+type Empty struct {
+	rawJSON
+}
+
+// FormAction: A form action describes the behavior when the form is submitted.
+// For example, you can invoke Apps Script to handle the form.
+type FormAction struct {
+	rawJSON
+
+	// ActionMethodName: The method name is used to identify which part of the form
+	// triggered the form submission.
+	ActionMethodName string `json:"actionMethodName,omitempty"`
+
+	// Parameters: List of action parameters.
+	Parameters []*ActionParameter `json:"parameters,omitempty"`
+}
+
+// ForwardedMetadata: Metadata about the source space from which a message was
+// forwarded.
+type ForwardedMetadata struct {
+	rawJSON
+
+	// Space: Output only. The resource name of the source space.
+	Space string `json:"space,omitempty"`
+
+	// SpaceDisplayName: Output only. The display name of the source space or DM at
+	// the time of forwarding.
+	SpaceDisplayName string `json:"spaceDisplayName,omitempty"`
+}
+
+// GoogleAppsCardV1Action: An action that describes the behavior when the form
+// is submitted. For example, you can invoke an Apps Script script to handle the
+// form. If the action is triggered, the form values are sent to the server.
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1Action struct {
+	rawJSON
+
+	// AllWidgetsAreRequired: Optional. If this is true, then all widgets are
+	// considered required by this action.
+	AllWidgetsAreRequired bool `json:"allWidgetsAreRequired,omitempty"`
+
+	// Function: A custom function to invoke when the containing element is clicked
+	// or otherwise activated.
+	Function string `json:"function,omitempty"`
+
+	// Interaction: Optional. Required when opening a
+	// [dialog](https://developers.google.com/workspace/chat/dialogs). Possible
+	// values: INTERACTION_UNSPECIFIED, OPEN_DIALOG.
+	Interaction string `json:"interaction,omitempty"`
+
+	// LoadIndicator: Specifies the loading indicator that the action displays
+	// while making the call to the action. Possible values: SPINNER, NONE.
+	LoadIndicator string `json:"loadIndicator,omitempty"`
+
+	// Parameters: List of action parameters.
+	Parameters []*GoogleAppsCardV1ActionParameter `json:"parameters,omitempty"`
+
+	// PersistValues: Indicates whether form values persist after the action.
+	PersistValues bool `json:"persistValues,omitempty"`
+
+	// RequiredWidgets: Optional. Fill this list with the names of widgets that
+	// this Action needs for a valid submission.
+	RequiredWidgets []string `json:"requiredWidgets,omitempty"`
+}
+
+// GoogleAppsCardV1ActionParameter: List of string parameters to supply when the
+// action method is invoked. For example, consider three snooze buttons: snooze
+// now, snooze one day, or snooze next week. You might use `action method =
+// snooze()`, passing the snooze type and snooze time in the list of string
+// parameters. To learn more, see
+// [`CommonEventObject`](https://developers.google.com/workspace/chat/api/reference/rest/v1/Event#commoneventobject).
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1ActionParameter struct {
+	rawJSON
+
+	// Key: The name of the parameter for the action script.
+	Key string `json:"key,omitempty"`
+
+	// Value: The value of the parameter.
+	Value string `json:"value,omitempty"`
+}
+
+// GoogleAppsCardV1BorderStyle: The style options for the border of a card or
+// widget, including the border type and color. [Google Workspace add-ons and
+// Chat apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1BorderStyle struct {
+	rawJSON
+
+	// CornerRadius: The corner radius for the border.
+	CornerRadius int64 `json:"cornerRadius,omitempty"`
+
+	// StrokeColor: The colors to use when the type is `BORDER_TYPE_STROKE`.
+	StrokeColor *Color `json:"strokeColor,omitempty"`
+
+	// Type: The border type. Possible values: BORDER_TYPE_UNSPECIFIED, NO_BORDER,
+	// STROKE.
+	Type string `json:"type,omitempty"`
+}
+
+// GoogleAppsCardV1Button: A text, icon, or text and icon button that users can
+// click. For an example in Google Chat apps, see [Add a
+// button](https://developers.google.com/workspace/chat/design-interactive-card-dialog#add_a_button).
+// To make an image a clickable button, specify an `Image` (not an
+// `ImageComponent`) and set an `onClick` action. [Google Workspace add-ons and
+// Chat apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1Button struct {
+	rawJSON
+
+	// AltText: The alternative text that's used for accessibility.
+	AltText string `json:"altText,omitempty"`
+
+	// Color: Optional. The color of the button.
+	Color *Color `json:"color,omitempty"`
+
+	// Disabled: If `true`, the button is displayed in an inactive state and
+	// doesn't respond to user actions.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Icon: An icon displayed inside the button.
+	Icon *GoogleAppsCardV1Icon `json:"icon,omitempty"`
+
+	// OnClick: Required. The action to perform when a user clicks the button, such
+	// as opening a hyperlink or running a custom function.
+	OnClick *GoogleAppsCardV1OnClick `json:"onClick,omitempty"`
+
+	// Text: The text displayed inside the button.
+	Text string `json:"text,omitempty"`
+
+	// Type: Optional. The type of a button. Possible values: TYPE_UNSPECIFIED,
+	// OUTLINED, FILLED, FILLED_TONAL, BORDERLESS.
+	Type string `json:"type,omitempty"`
+}
+
+// GoogleAppsCardV1ButtonList: A list of buttons layed out horizontally. For an
+// example in Google Chat apps, see [Add a
+// button](https://developers.google.com/workspace/chat/design-interactive-card-dialog#add_a_button).
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1ButtonList struct {
+	rawJSON
+
+	// Buttons: An array of buttons.
+	Buttons []*GoogleAppsCardV1Button `json:"buttons,omitempty"`
+}
+
+// GoogleAppsCardV1Card: A card interface displayed in a Google Chat message or
+// Google Workspace add-on. Cards support a defined layout, interactive UI
+// elements like buttons, and rich media like images. Use cards to present
+// detailed information, gather information from users, and guide users to take
+// a next step. [Card builder](https://addons.gsuite.google.com/uikit/builder)
+// To learn how to build cards, see the following documentation: * For Google
+// Chat apps, see [Design the components of a card or
+// dialog](https://developers.google.com/workspace/chat/design-components-card-dialog).
+// * For Google Workspace add-ons, see [Card-based
+// interfaces](https://developers.google.com/apps-script/add-ons/concepts/cards).
+// Note: You can add up to 100 widgets per card. Any widgets beyond this limit
+// are ignored. This limit applies to both card messages and dialogs in Google
+// Chat apps, and to cards in Google Workspace add-ons. **Example: Card message
+// for a Google Chat app** ![Example contact
+// card](https://developers.google.com/workspace/chat/images/card_api_reference.png)
+// To create the sample card message in Google Chat, use the following JSON: ```
+// { "cardsV2": [ { "cardId": "unique-card-id", "card": { "header": { "title":
+// "Sasha", "subtitle": "Software Engineer", "imageUrl":
+// "https://developers.google.com/workspace/chat/images/quickstart-app-avatar.png",
+// "imageType": "CIRCLE", "imageAltText": "Avatar for Sasha" }, "sections": [ {
+// "header": "Contact Info", "collapsible": true, "uncollapsibleWidgetsCount":
+// 1, "widgets": [ { "decoratedText": { "startIcon": { "knownIcon": "EMAIL" },
+// "text": "sasha@example.com" } }, { "decoratedText": { "startIcon": {
+// "knownIcon": "PERSON" }, "text": "Online" } }, { "decoratedText": {
+// "startIcon": { "knownIcon": "PHONE" }, "text": "+1 (555) 555-1234" } }, {
+// "buttonList": { "buttons": [ { "text": "Share", "onClick": { "openLink": {
+// "url": "https://example.com/share" } } }, { "text": "Edit", "onClick": {
+// "action": { "function": "goToView", "parameters": [ { "key": "viewType",
+// "value": "EDIT" } ] } } } ] } } ] } ] } } ] } ```
+type GoogleAppsCardV1Card struct {
+	rawJSON
+
+	// CardActions: The card's actions.
+	CardActions []*GoogleAppsCardV1CardAction `json:"cardActions,omitempty"`
+
+	// DisplayStyle: In Google Workspace add-ons, sets the display properties of
+	// the `peekCardHeader`. Possible values: DISPLAY_STYLE_UNSPECIFIED, PEEK,
+	// REPLACE.
+	DisplayStyle string `json:"displayStyle,omitempty"`
+
+	// ExpressionData: The expression data for the card.
+	ExpressionData []*GoogleAppsCardV1ExpressionData `json:"expressionData,omitempty"`
+
+	// FixedFooter: The fixed footer shown at the bottom of this card.
+	FixedFooter *GoogleAppsCardV1CardFixedFooter `json:"fixedFooter,omitempty"`
+
+	// Header: The header of the card.
+	Header *GoogleAppsCardV1CardHeader `json:"header,omitempty"`
+
+	// Name: Name of the card.
+	Name string `json:"name,omitempty"`
+
+	// PeekCardHeader: When displaying contextual content, the peek card header
+	// acts as a placeholder so that the user can navigate forward between the
+	// homepage cards and the contextual cards.
+	PeekCardHeader *GoogleAppsCardV1CardHeader `json:"peekCardHeader,omitempty"`
+
+	// SectionDividerStyle: The divider style between the header, sections and
+	// footer. Possible values: DIVIDER_STYLE_UNSPECIFIED, SOLID_DIVIDER,
+	// NO_DIVIDER.
+	SectionDividerStyle string `json:"sectionDividerStyle,omitempty"`
+
+	// Sections: Contains a collection of widgets.
+	Sections []*GoogleAppsCardV1Section `json:"sections,omitempty"`
+}
+
+// GoogleAppsCardV1CardAction: A card action is the action associated with the
+// card. For example, an invoice card might include actions such as delete
+// invoice, email invoice, or open the invoice in a browser. [Google Workspace
+// add-ons](https://developers.google.com/workspace/add-ons):
+type GoogleAppsCardV1CardAction struct {
+	rawJSON
+
+	// ActionLabel: The label that displays as the action menu item.
+	ActionLabel string `json:"actionLabel,omitempty"`
+
+	// OnClick: The `onClick` action for this action item.
+	OnClick *GoogleAppsCardV1OnClick `json:"onClick,omitempty"`
+}
+
+// GoogleAppsCardV1CardFixedFooter: A persistent (sticky) footer that that
+// appears at the bottom of the card. Setting `fixedFooter` without specifying a
+// `primaryButton` or a `secondaryButton` causes an error. For Chat apps, you
+// can use fixed footers in
+// [dialogs](https://developers.google.com/workspace/chat/dialogs), but not
+// [card
+// messages](https://developers.google.com/workspace/chat/create-messages#create).
+// For an example in Google Chat apps, see [Add a persistent
+// footer](https://developers.google.com/workspace/chat/design-components-card-dialog#add_a_persistent_footer).
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1CardFixedFooter struct {
+	rawJSON
+
+	// PrimaryButton: The primary button of the fixed footer.
+	PrimaryButton *GoogleAppsCardV1Button `json:"primaryButton,omitempty"`
+
+	// SecondaryButton: The secondary button of the fixed footer.
+	SecondaryButton *GoogleAppsCardV1Button `json:"secondaryButton,omitempty"`
+}
+
+// GoogleAppsCardV1CardHeader: Represents a card header. For an example in
+// Google Chat apps, see [Add a
+// header](https://developers.google.com/workspace/chat/design-components-card-dialog#add_a_header).
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1CardHeader struct {
+	rawJSON
+
+	// ImageAltText: The alternative text of this image that's used for
+	// accessibility.
+	ImageAltText string `json:"imageAltText,omitempty"`
+
+	// ImageType: The shape used to crop the image. Possible values: SQUARE,
+	// CIRCLE.
+	ImageType string `json:"imageType,omitempty"`
+
+	// ImageURL: The HTTPS URL of the image in the card header.
+	ImageURL string `json:"imageUrl,omitempty"`
+
+	// Subtitle: The subtitle of the card header.
+	Subtitle string `json:"subtitle,omitempty"`
+
+	// Title: Required. The title of the card header.
+	Title string `json:"title,omitempty"`
+}
+
+// GoogleAppsCardV1Carousel: A carousel, also known as a slider, rotates and
+// displays a list of widgets in a slideshow format, with buttons navigating to
+// the previous or next widget. For example, this is a JSON representation of a
+// carousel that contains three text paragraph widgets. ``` { "carouselCards": [
+// { "widgets": [ { "textParagraph": { "text": "First text paragraph in
+// carousel", } } ] }, { "widgets": [ { "textParagraph": { "text": "Second text
+// paragraph in carousel", } } ] }, { "widgets": [ { "textParagraph": { "text":
+// "Third text paragraph in carousel", } } ] } ] } ``` [Google Chat
+// apps](https://developers.google.com/workspace/chat):
+type GoogleAppsCardV1Carousel struct {
+	rawJSON
+
+	// CarouselCards: A list of cards included in the carousel.
+	CarouselCards []*GoogleAppsCardV1CarouselCard `json:"carouselCards,omitempty"`
+}
+
+// GoogleAppsCardV1CarouselCard: A card that can be displayed as a carousel
+// item. [Google Chat apps](https://developers.google.com/workspace/chat):
+type GoogleAppsCardV1CarouselCard struct {
+	rawJSON
+
+	// FooterWidgets: A list of widgets displayed at the bottom of the carousel
+	// card.
+	FooterWidgets []*GoogleAppsCardV1NestedWidget `json:"footerWidgets,omitempty"`
+
+	// Widgets: A list of widgets displayed in the carousel card.
+	Widgets []*GoogleAppsCardV1NestedWidget `json:"widgets,omitempty"`
+}
+
+// GoogleAppsCardV1Chip: A text, icon, or text and icon chip that users can
+// click. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1Chip struct {
+	rawJSON
+
+	// AltText: The alternative text that's used for accessibility.
+	AltText string `json:"altText,omitempty"`
+
+	// Disabled: Whether the chip is in an inactive state and ignores user actions.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Enabled: Whether the chip is in an active state and responds to user
+	// actions.
+	//
+	// Deprecated: see the Google Chat API reference.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Icon: The icon image.
+	Icon *GoogleAppsCardV1Icon `json:"icon,omitempty"`
+
+	// Label: The text displayed inside the chip.
+	Label string `json:"label,omitempty"`
+
+	// OnClick: Optional. The action to perform when a user clicks the chip, such
+	// as opening a hyperlink or running a custom function.
+	OnClick *GoogleAppsCardV1OnClick `json:"onClick,omitempty"`
+}
+
+// GoogleAppsCardV1ChipList: A list of chips layed out horizontally, which can
+// either scroll horizontally or wrap to the next line. [Google Workspace
+// add-ons and Chat apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1ChipList struct {
+	rawJSON
+
+	// Chips: An array of chips.
+	Chips []*GoogleAppsCardV1Chip `json:"chips,omitempty"`
+
+	// Layout: Specified chip list layout. Possible values: LAYOUT_UNSPECIFIED,
+	// WRAPPED, HORIZONTAL_SCROLLABLE.
+	Layout string `json:"layout,omitempty"`
+}
+
+// GoogleAppsCardV1CollapseControl: Represent an expand and collapse control.
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1CollapseControl struct {
+	rawJSON
+
+	// CollapseButton: Optional. Define a customizable button to collapse the
+	// section.
+	CollapseButton *GoogleAppsCardV1Button `json:"collapseButton,omitempty"`
+
+	// ExpandButton: Optional. Define a customizable button to expand the section.
+	ExpandButton *GoogleAppsCardV1Button `json:"expandButton,omitempty"`
+
+	// HorizontalAlignment: The horizontal alignment of the expand and collapse
+	// button. Possible values: HORIZONTAL_ALIGNMENT_UNSPECIFIED, START, CENTER,
+	// END.
+	HorizontalAlignment string `json:"horizontalAlignment,omitempty"`
+}
+
+// GoogleAppsCardV1Column: A column. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend)
+type GoogleAppsCardV1Column struct {
+	rawJSON
+
+	// HorizontalAlignment: Specifies whether widgets align to the left, right, or
+	// center of a column. Possible values: HORIZONTAL_ALIGNMENT_UNSPECIFIED,
+	// START, CENTER, END.
+	HorizontalAlignment string `json:"horizontalAlignment,omitempty"`
+
+	// HorizontalSizeStyle: Specifies how a column fills the width of the card.
+	// Possible values: HORIZONTAL_SIZE_STYLE_UNSPECIFIED, FILL_AVAILABLE_SPACE,
+	// FILL_MINIMUM_SPACE.
+	HorizontalSizeStyle string `json:"horizontalSizeStyle,omitempty"`
+
+	// VerticalAlignment: Specifies whether widgets align to the top, bottom, or
+	// center of a column. Possible values: VERTICAL_ALIGNMENT_UNSPECIFIED, CENTER,
+	// TOP, BOTTOM.
+	VerticalAlignment string `json:"verticalAlignment,omitempty"`
+
+	// Widgets: An array of widgets included in a column.
+	Widgets []*GoogleAppsCardV1Widgets `json:"widgets,omitempty"`
+}
+
+// GoogleAppsCardV1Columns: The `Columns` widget displays up to 2 columns in a
+// card or dialog. You can add widgets to each column; the widgets appear in the
+// order that they are specified. For an example in Google Chat apps, see
+// [Display cards and dialogs in
+// columns](https://developers.google.com/workspace/chat/format-structure-card-dialog#display_cards_and_dialogs_in_columns).
+// The height of each column is determined by the taller column. For example, if
+// the first column is taller than the second column, both columns have the
+// height of the first column. Because each column can contain a different
+// number of widgets, you can't define rows or align widgets between the
+// columns. Columns are displayed side-by-side. You can customize the width of
+// each column using the `HorizontalSizeStyle` field. If the user's screen width
+// is too narrow, the second column wraps below the first: * On web, the second
+// column wraps if the screen width is less than or equal to 480 pixels. * On
+// iOS devices, the second column wraps if the screen width is less than or
+// equal to 300 pt. * On Android devices, the second column wraps if the screen
+// width is less than or equal to 320 dp. To include more than two columns, or
+// to use rows, use the `Grid` widget. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend): The add-on UIs that
+// support columns include: * The dialog displayed when users open the add-on
+// from an email draft. * The dialog displayed when users open the add-on from
+// the **Add attachment** menu in a Google Calendar event.
+type GoogleAppsCardV1Columns struct {
+	rawJSON
+
+	// ColumnItems: An array of columns.
+	ColumnItems []*GoogleAppsCardV1Column `json:"columnItems,omitempty"`
+}
+
+// GoogleAppsCardV1CommonWidgetAction: Represents an action that is not specific
+// to a widget. Available for Google Workspace add-ons that extend Google
+// Workspace Studio. Unavailable for Google Chat apps.
+type GoogleAppsCardV1CommonWidgetAction struct {
+	rawJSON
+
+	// UpdateVisibilityAction: The action to update the visibility of a widget.
+	UpdateVisibilityAction *GoogleAppsCardV1UpdateVisibilityAction `json:"updateVisibilityAction,omitempty"`
+}
+
+// GoogleAppsCardV1Condition: Represents a condition that can be used to trigger
+// an action. Available for Google Workspace add-ons that extend Google
+// Workspace Studio. Unavailable for Google Chat apps.
+type GoogleAppsCardV1Condition struct {
+	rawJSON
+
+	// ActionRuleID: The unique identifier of the ActionRule.
+	ActionRuleID string `json:"actionRuleId,omitempty"`
+
+	// ExpressionDataCondition: The condition that is determined by the expression
+	// data.
+	ExpressionDataCondition *GoogleAppsCardV1ExpressionDataCondition `json:"expressionDataCondition,omitempty"`
+}
+
+// GoogleAppsCardV1DataSourceConfig: A configuration object that helps configure
+// the data sources for a widget. Available for Google Chat apps and Google
+// Workspace add-ons that extend Google Workspace Studio.
+type GoogleAppsCardV1DataSourceConfig struct {
+	rawJSON
+
+	// MinCharactersTrigger: The minimum number of characters the user must enter
+	// before this data provider is triggered (i.e., before it starts returning
+	// results).
+	MinCharactersTrigger int64 `json:"minCharactersTrigger,omitempty"`
+
+	// PlatformDataSource: The data is from a Google Workspace application.
+	PlatformDataSource *GoogleAppsCardV1PlatformDataSource `json:"platformDataSource,omitempty"`
+
+	// RemoteDataSource: The data is from a remote data provider.
+	RemoteDataSource *GoogleAppsCardV1Action `json:"remoteDataSource,omitempty"`
+}
+
+// GoogleAppsCardV1DateTimePicker: Lets users input a date, a time, or both a
+// date and a time. Supports form submission validation. When
+// `Action.all_widgets_are_required` is set to `true` or this widget is
+// specified in `Action.required_widgets`, the submission action is blocked
+// unless a value is selected. For an example in Google Chat apps, see [Let a
+// user pick a date and
+// time](https://developers.google.com/workspace/chat/design-interactive-card-dialog#let_a_user_pick_a_date_and_time).
+// Users can input text or use the picker to select dates and times. If users
+// input an invalid date or time, the picker shows an error that prompts users
+// to input the information correctly. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1DateTimePicker struct {
+	rawJSON
+
+	// HostAppDataSource: A data source that's unique to a Google Workspace host
+	// application, such as Gmail emails, Google Calendar events, or Google Chat
+	// messages.
+	HostAppDataSource *HostAppDataSourceMarkup `json:"hostAppDataSource,omitempty"`
+
+	// Label: The text that prompts users to input a date, a time, or a date and
+	// time.
+	Label string `json:"label,omitempty"`
+
+	// Name: The name by which the `DateTimePicker` is identified in a form input
+	// event.
+	Name string `json:"name,omitempty"`
+
+	// OnChangeAction: Triggered when the user clicks **Save** or **Clear** from
+	// the `DateTimePicker` interface.
+	OnChangeAction *GoogleAppsCardV1Action `json:"onChangeAction,omitempty"`
+
+	// TimezoneOffsetDate: The number representing the time zone offset from UTC,
+	// in minutes.
+	TimezoneOffsetDate int64 `json:"timezoneOffsetDate,omitempty"`
+
+	// Type: Whether the widget supports inputting a date, a time, or the date and
+	// time. Possible values: DATE_AND_TIME, DATE_ONLY, TIME_ONLY.
+	Type string `json:"type,omitempty"`
+
+	// ValueMsEpoch: The default value displayed in the widget, in milliseconds
+	// since [Unix epoch time](https://en.wikipedia.org/wiki/Unix_time).
+	ValueMsEpoch int64 `json:"valueMsEpoch,omitempty,string"`
+}
+
+// GoogleAppsCardV1DecoratedText: A widget that displays text with optional
+// decorations such as a label above or below the text, an icon in front of the
+// text, a selection widget, or a button after the text. For an example in
+// Google Chat apps, see [Display text with decorative
+// text](https://developers.google.com/workspace/chat/add-text-image-card-dialog#display_text_with_decorative_elements).
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1DecoratedText struct {
+	rawJSON
+
+	// BottomLabel: The text that appears below `text`.
+	BottomLabel string `json:"bottomLabel,omitempty"`
+
+	// BottomLabelText: `TextParagraph` equivalent of `bottom_label`.
+	BottomLabelText *GoogleAppsCardV1TextParagraph `json:"bottomLabelText,omitempty"`
+
+	// Button: A button that a user can click to trigger an action.
+	Button *GoogleAppsCardV1Button `json:"button,omitempty"`
+
+	// ContentText: `TextParagraph` equivalent of `text`.
+	ContentText *GoogleAppsCardV1TextParagraph `json:"contentText,omitempty"`
+
+	// EndIcon: An icon displayed after the text.
+	EndIcon *GoogleAppsCardV1Icon `json:"endIcon,omitempty"`
+
+	// Icon: Deprecated in favor of `startIcon`.
+	Icon *GoogleAppsCardV1Icon `json:"icon,omitempty"`
+
+	// OnClick: This action is triggered when users click `topLabel` or
+	// `bottomLabel`.
+	OnClick *GoogleAppsCardV1OnClick `json:"onClick,omitempty"`
+
+	// StartIcon: The icon displayed in front of the text.
+	StartIcon *GoogleAppsCardV1Icon `json:"startIcon,omitempty"`
+
+	// StartIconVerticalAlignment: Optional. Vertical alignment of the start icon.
+	// Possible values: VERTICAL_ALIGNMENT_UNSPECIFIED, TOP, MIDDLE, BOTTOM.
+	StartIconVerticalAlignment string `json:"startIconVerticalAlignment,omitempty"`
+
+	// SwitchControl: A switch widget that a user can click to change its state and
+	// trigger an action.
+	SwitchControl *GoogleAppsCardV1SwitchControl `json:"switchControl,omitempty"`
+
+	// Text: Required. The primary text.
+	Text string `json:"text,omitempty"`
+
+	// TopLabel: The text that appears above `text`.
+	TopLabel string `json:"topLabel,omitempty"`
+
+	// TopLabelText: `TextParagraph` equivalent of `top_label`.
+	TopLabelText *GoogleAppsCardV1TextParagraph `json:"topLabelText,omitempty"`
+
+	// WrapText: The wrap text setting.
+	WrapText bool `json:"wrapText,omitempty"`
+}
+
+// GoogleAppsCardV1Divider: Displays a divider between widgets as a horizontal
+// line. For an example in Google Chat apps, see [Add a horizontal divider
+// between
+// widgets](https://developers.google.com/workspace/chat/format-structure-card-dialog#add_a_horizontal_divider_between_widgets).
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend): For example, the
+// following JSON creates a divider: ``` "divider": {} ```
+type GoogleAppsCardV1Divider struct {
+	rawJSON
+}
+
+// GoogleAppsCardV1EventAction: Represents an actionthat can be performed on an
+// ui element. Available for Google Workspace add-ons that extend Google
+// Workspace Studio. Unavailable for Google Chat apps.
+type GoogleAppsCardV1EventAction struct {
+	rawJSON
+
+	// ActionRuleID: The unique identifier of the ActionRule.
+	ActionRuleID string `json:"actionRuleId,omitempty"`
+
+	// CommonWidgetAction: Common widget action.
+	CommonWidgetAction *GoogleAppsCardV1CommonWidgetAction `json:"commonWidgetAction,omitempty"`
+
+	// PostEventTriggers: The list of triggers that will be triggered after the
+	// EventAction is executed.
+	PostEventTriggers []*GoogleAppsCardV1Trigger `json:"postEventTriggers,omitempty"`
+}
+
+// GoogleAppsCardV1ExpressionData: Represents the data that is used to evaluate
+// an expression. Available for Google Workspace add-ons that extend Google
+// Workspace Studio. Unavailable for Google Chat apps.
+type GoogleAppsCardV1ExpressionData struct {
+	rawJSON
+
+	// Conditions: The list of conditions that are determined by the expression
+	// evaluation result.
+	Conditions []*GoogleAppsCardV1Condition `json:"conditions,omitempty"`
+
+	// EventActions: The list of actions that the ExpressionData can be used.
+	EventActions []*GoogleAppsCardV1EventAction `json:"eventActions,omitempty"`
+
+	// Expression: The uncompiled expression.
+	Expression string `json:"expression,omitempty"`
+
+	// ID: The unique identifier of the ExpressionData.
+	ID string `json:"id,omitempty"`
+}
+
+// GoogleAppsCardV1ExpressionDataCondition: Represents a condition that is
+// evaluated using CEL. Available for Google Workspace add-ons that extend
+// Google Workspace Studio. Unavailable for Google Chat apps.
+type GoogleAppsCardV1ExpressionDataCondition struct {
+	rawJSON
+
+	// ConditionType: The type of the condition. Possible values:
+	// CONDITION_TYPE_UNSPECIFIED, EXPRESSION_EVALUATION_SUCCESS,
+	// EXPRESSION_EVALUATION_FAILURE.
+	ConditionType string `json:"conditionType,omitempty"`
+}
+
+// GoogleAppsCardV1Grid: Displays a grid with a collection of items. Items can
+// only include text or images. For responsive columns, or to include more than
+// text or images, use `Columns`. For an example in Google Chat apps, see
+// [Display a Grid with a collection of
+// items](https://developers.google.com/workspace/chat/format-structure-card-dialog#display_a_grid_with_a_collection_of_items).
+// A grid supports any number of columns and items. The number of rows is
+// determined by items divided by columns. A grid with 10 items and 2 columns
+// has 5 rows. A grid with 11 items and 2 columns has 6 rows. [Google Workspace
+// add-ons and Chat apps](https://developers.google.com/workspace/extend): For
+// example, the following JSON creates a 2 column grid with a single item: ```
+// "grid": { "title": "A fine collection of items", "columnCount": 2,
+// "borderStyle": { "type": "STROKE", "cornerRadius": 4 }, "items": [ { "image":
+// { "imageUri": "https://www.example.com/image.png", "cropStyle": { "type":
+// "SQUARE" }, "borderStyle": { "type": "STROKE" } }, "title": "An item",
+// "textAlignment": "CENTER" } ], "onClick": { "openLink": { "url":
+// "https://www.example.com" } } } ```
+type GoogleAppsCardV1Grid struct {
+	rawJSON
+
+	// BorderStyle: The border style to apply to each grid item.
+	BorderStyle *GoogleAppsCardV1BorderStyle `json:"borderStyle,omitempty"`
+
+	// ColumnCount: The number of columns to display in the grid.
+	ColumnCount int64 `json:"columnCount,omitempty"`
+
+	// Items: The items to display in the grid.
+	Items []*GoogleAppsCardV1GridItem `json:"items,omitempty"`
+
+	// OnClick: This callback is reused by each individual grid item, but with the
+	// item's identifier and index in the items list added to the callback's
+	// parameters.
+	OnClick *GoogleAppsCardV1OnClick `json:"onClick,omitempty"`
+
+	// Title: The text that displays in the grid header.
+	Title string `json:"title,omitempty"`
+}
+
+// GoogleAppsCardV1GridItem: Represents an item in a grid layout. Items can
+// contain text, an image, or both text and an image. [Google Workspace add-ons
+// and Chat apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1GridItem struct {
+	rawJSON
+
+	// ID: A user-specified identifier for this grid item.
+	ID string `json:"id,omitempty"`
+
+	// Image: The image that displays in the grid item.
+	Image *GoogleAppsCardV1ImageComponent `json:"image,omitempty"`
+
+	// Layout: The layout to use for the grid item. Possible values:
+	// GRID_ITEM_LAYOUT_UNSPECIFIED, TEXT_BELOW, TEXT_ABOVE.
+	Layout string `json:"layout,omitempty"`
+
+	// Subtitle: The grid item's subtitle.
+	Subtitle string `json:"subtitle,omitempty"`
+
+	// Title: The grid item's title.
+	Title string `json:"title,omitempty"`
+}
+
+// GoogleAppsCardV1Icon: An icon displayed in a widget on a card. For an example
+// in Google Chat apps, see [Add an
+// icon](https://developers.google.com/workspace/chat/add-text-image-card-dialog#add_an_icon).
+// Supports
+// [built-in](https://developers.google.com/workspace/chat/format-messages#builtinicons)
+// and
+// [custom](https://developers.google.com/workspace/chat/format-messages#customicons)
+// icons. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1Icon struct {
+	rawJSON
+
+	// AltText: Optional. A description of the icon used for accessibility.
+	AltText string `json:"altText,omitempty"`
+
+	// IconURL: Display a custom icon hosted at an HTTPS URL.
+	IconURL string `json:"iconUrl,omitempty"`
+
+	// ImageType: The crop style applied to the image. Possible values: SQUARE,
+	// CIRCLE.
+	ImageType string `json:"imageType,omitempty"`
+
+	// KnownIcon: Display one of the built-in icons provided by Google Workspace.
+	KnownIcon string `json:"knownIcon,omitempty"`
+
+	// MaterialIcon: Display one of the [Google Material
+	// Icons](https://fonts.google.com/icons).
+	MaterialIcon *GoogleAppsCardV1MaterialIcon `json:"materialIcon,omitempty"`
+}
+
+// GoogleAppsCardV1Image: An image that is specified by a URL and can have an
+// `onClick` action. For an example, see [Add an
+// image](https://developers.google.com/workspace/chat/add-text-image-card-dialog#add_an_image).
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1Image struct {
+	rawJSON
+
+	// AltText: The alternative text of this image that's used for accessibility.
+	AltText string `json:"altText,omitempty"`
+
+	// ImageURL: The HTTPS URL that hosts the image.
+	ImageURL string `json:"imageUrl,omitempty"`
+
+	// OnClick: When a user clicks the image, the click triggers this action.
+	OnClick *GoogleAppsCardV1OnClick `json:"onClick,omitempty"`
+}
+
+// GoogleAppsCardV1ImageComponent: Represents an image. [Google Workspace
+// add-ons and Chat apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1ImageComponent struct {
+	rawJSON
+
+	// AltText: The accessibility label for the image.
+	AltText string `json:"altText,omitempty"`
+
+	// BorderStyle: The border style to apply to the image.
+	BorderStyle *GoogleAppsCardV1BorderStyle `json:"borderStyle,omitempty"`
+
+	// CropStyle: The crop style to apply to the image.
+	CropStyle *GoogleAppsCardV1ImageCropStyle `json:"cropStyle,omitempty"`
+
+	// ImageURI: The image URL.
+	ImageURI string `json:"imageUri,omitempty"`
+}
+
+// GoogleAppsCardV1ImageCropStyle: Represents the crop style applied to an
+// image. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend): For example, here's
+// how to apply a 16:9 aspect ratio: ``` cropStyle { "type": "RECTANGLE_CUSTOM",
+// "aspectRatio": 16/9 } ```
+type GoogleAppsCardV1ImageCropStyle struct {
+	rawJSON
+
+	// AspectRatio: The aspect ratio to use if the crop type is `RECTANGLE_CUSTOM`.
+	AspectRatio float64 `json:"aspectRatio,omitempty"`
+
+	// Type: The crop type. Possible values: IMAGE_CROP_TYPE_UNSPECIFIED, SQUARE,
+	// CIRCLE, RECTANGLE_CUSTOM, RECTANGLE_4_3.
+	Type string `json:"type,omitempty"`
+}
+
+// GoogleAppsCardV1MaterialIcon: A [Google Material
+// Icon](https://fonts.google.com/icons), which includes over 2500+ options. For
+// example, to display a [checkbox
+// icon](https://fonts.google.com/icons?selected=Material%20Symbols%20Outlined%3Acheck_box%3AFILL%400%3Bwght%40400%3BGRAD%400%3Bopsz%4048)
+// with customized weight and grade, write the following: ``` { "name":
+// "check_box", "fill": true, "weight": 300, "grade": -25 } ``` [Google Chat
+// apps](https://developers.google.com/workspace/chat):
+type GoogleAppsCardV1MaterialIcon struct {
+	rawJSON
+
+	// Fill: Whether the icon renders as filled.
+	Fill bool `json:"fill,omitempty"`
+
+	// Grade: Weight and grade affect a symbol’s thickness.
+	Grade int64 `json:"grade,omitempty"`
+
+	// Name: The icon name defined in the [Google Material
+	// Icon](https://fonts.google.com/icons), for example, `check_box`.
+	Name string `json:"name,omitempty"`
+
+	// Weight: The stroke weight of the icon.
+	Weight int64 `json:"weight,omitempty"`
+}
+
+// GoogleAppsCardV1NestedWidget: A list of widgets that can be displayed in a
+// containing layout, such as a `CarouselCard`. [Google Chat
+// apps](https://developers.google.com/workspace/chat):
+type GoogleAppsCardV1NestedWidget struct {
+	rawJSON
+
+	// ButtonList: A button list widget.
+	ButtonList *GoogleAppsCardV1ButtonList `json:"buttonList,omitempty"`
+
+	// Image: An image widget.
+	Image *GoogleAppsCardV1Image `json:"image,omitempty"`
+
+	// TextParagraph: A text paragraph widget.
+	TextParagraph *GoogleAppsCardV1TextParagraph `json:"textParagraph,omitempty"`
+}
+
+// GoogleAppsCardV1OnClick: Represents how to respond when users click an
+// interactive element on a card, such as a button. [Google Workspace add-ons
+// and Chat apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1OnClick struct {
+	rawJSON
+
+	// Action: If specified, an action is triggered by this `onClick`.
+	Action *GoogleAppsCardV1Action `json:"action,omitempty"`
+
+	// Card: A new card is pushed to the card stack after clicking if specified.
+	Card *GoogleAppsCardV1Card `json:"card,omitempty"`
+
+	// OpenDynamicLinkAction: An add-on triggers this action when the action needs
+	// to open a link.
+	OpenDynamicLinkAction *GoogleAppsCardV1Action `json:"openDynamicLinkAction,omitempty"`
+
+	// OpenLink: If specified, this `onClick` triggers an open link action.
+	OpenLink *GoogleAppsCardV1OpenLink `json:"openLink,omitempty"`
+
+	// OverflowMenu: If specified, this `onClick` opens an overflow menu.
+	OverflowMenu *GoogleAppsCardV1OverflowMenu `json:"overflowMenu,omitempty"`
+}
+
+// GoogleAppsCardV1OpenLink: Represents an `onClick` event that opens a
+// hyperlink. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1OpenLink struct {
+	rawJSON
+
+	// OnClose: Whether the client forgets about a link after opening it, or
+	// observes it until the window closes. Possible values: NOTHING, RELOAD.
+	OnClose string `json:"onClose,omitempty"`
+
+	// OpenAs: How to open a link. Possible values: FULL_SIZE, OVERLAY.
+	OpenAs string `json:"openAs,omitempty"`
+
+	// URL: The URL to open.
+	URL string `json:"url,omitempty"`
+}
+
+// GoogleAppsCardV1OverflowMenu: A widget that presents a pop-up menu with one
+// or more actions that users can invoke. For example, showing non-primary
+// actions in a card. You can use this widget when actions don't fit in the
+// available space. To use, specify this widget in the `OnClick` action of
+// widgets that support it. For example, in a `Button`. [Google Workspace
+// add-ons and Chat apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1OverflowMenu struct {
+	rawJSON
+
+	// Items: Required. The list of menu options.
+	Items []*GoogleAppsCardV1OverflowMenuItem `json:"items,omitempty"`
+}
+
+// GoogleAppsCardV1OverflowMenuItem: An option that users can invoke in an
+// overflow menu. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1OverflowMenuItem struct {
+	rawJSON
+
+	// Disabled: Whether the menu option is disabled.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// OnClick: Required. The action invoked when a menu option is selected.
+	OnClick *GoogleAppsCardV1OnClick `json:"onClick,omitempty"`
+
+	// StartIcon: The icon displayed in front of the text.
+	StartIcon *GoogleAppsCardV1Icon `json:"startIcon,omitempty"`
+
+	// Text: Required. The text that identifies or describes the item to users.
+	Text string `json:"text,omitempty"`
+}
+
+// GoogleAppsCardV1PlatformDataSource: For a `SelectionInput` widget that uses a
+// multiselect menu, a data source from Google Workspace. Used to populate items
+// in a multiselect menu. [Google Chat
+// apps](https://developers.google.com/workspace/chat):
+type GoogleAppsCardV1PlatformDataSource struct {
+	rawJSON
+
+	// CommonDataSource: A data source shared by all Google Workspace applications,
+	// such as users in a Google Workspace organization. Possible values: UNKNOWN,
+	// USER.
+	CommonDataSource string `json:"commonDataSource,omitempty"`
+
+	// HostAppDataSource: A data source that's unique to a Google Workspace host
+	// application, such spaces in Google Chat.
+	HostAppDataSource *HostAppDataSourceMarkup `json:"hostAppDataSource,omitempty"`
+}
+
+// GoogleAppsCardV1Section: A section contains a collection of widgets that are
+// rendered vertically in the order that they're specified. [Google Workspace
+// add-ons and Chat apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1Section struct {
+	rawJSON
+
+	// CollapseControl: Optional. Define the expand and collapse button of the
+	// section.
+	CollapseControl *GoogleAppsCardV1CollapseControl `json:"collapseControl,omitempty"`
+
+	// Collapsible: Indicates whether this section is collapsible.
+	Collapsible bool `json:"collapsible,omitempty"`
+
+	// Header: Text that appears at the top of a section.
+	Header string `json:"header,omitempty"`
+
+	// ID: A unique ID assigned to the section that's used to identify the section
+	// to be mutated.
+	ID string `json:"id,omitempty"`
+
+	// UncollapsibleWidgetsCount: The number of uncollapsible widgets which remain
+	// visible even when a section is collapsed.
+	UncollapsibleWidgetsCount int64 `json:"uncollapsibleWidgetsCount,omitempty"`
+
+	// Widgets: All the widgets in the section.
+	Widgets []*GoogleAppsCardV1Widget `json:"widgets,omitempty"`
+}
+
+// GoogleAppsCardV1SelectionInput: A widget that creates one or more UI items
+// that users can select. Supports form submission validation for `dropdown` and
+// `multiselect` menus only. When `Action.all_widgets_are_required` is set to
+// `true` or this widget is specified in `Action.required_widgets`, the
+// submission action is blocked unless a value is selected. For example, a
+// dropdown menu or checkboxes. You can use this widget to collect data that can
+// be predicted or enumerated. For an example in Google Chat apps, see [Add
+// selectable UI
+// elements](/workspace/chat/design-interactive-card-dialog#add_selectable_ui_elements).
+// Chat apps can process the value of items that users select or input. For
+// details about working with form inputs, see [Receive form
+// data](https://developers.google.com/workspace/chat/read-form-data). To
+// collect undefined or abstract data from users, use the TextInput widget.
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1SelectionInput struct {
+	rawJSON
+
+	// DataSourceConfigs: Optional. The data source configs for the selection
+	// control.
+	DataSourceConfigs []*GoogleAppsCardV1DataSourceConfig `json:"dataSourceConfigs,omitempty"`
+
+	// ExternalDataSource: An external data source, such as a relational database.
+	ExternalDataSource *GoogleAppsCardV1Action `json:"externalDataSource,omitempty"`
+
+	// HintText: Optional. Text that appears below the selection input field meant
+	// to assist users by prompting them to enter a certain value.
+	HintText string `json:"hintText,omitempty"`
+
+	// Items: An array of selectable items.
+	Items []*GoogleAppsCardV1SelectionItem `json:"items,omitempty"`
+
+	// Label: The text that appears above the selection input field in the user
+	// interface.
+	Label string `json:"label,omitempty"`
+
+	// MultiSelectMaxSelectedItems: For multiselect menus, the maximum number of
+	// items that a user can select.
+	MultiSelectMaxSelectedItems int64 `json:"multiSelectMaxSelectedItems,omitempty"`
+
+	// MultiSelectMinQueryLength: For multiselect menus, the number of text
+	// characters that a user inputs before the menu returns suggested selection
+	// items.
+	MultiSelectMinQueryLength int64 `json:"multiSelectMinQueryLength,omitempty"`
+
+	// Name: Required. The name that identifies the selection input in a form input
+	// event.
+	Name string `json:"name,omitempty"`
+
+	// OnChangeAction: If specified, the form is submitted when the selection
+	// changes.
+	OnChangeAction *GoogleAppsCardV1Action `json:"onChangeAction,omitempty"`
+
+	// PlatformDataSource: A data source from Google Workspace.
+	PlatformDataSource *GoogleAppsCardV1PlatformDataSource `json:"platformDataSource,omitempty"`
+
+	// Type: The type of items that are displayed to users in a `SelectionInput`
+	// widget. Possible values: CHECK_BOX, RADIO_BUTTON, SWITCH, DROPDOWN,
+	// MULTI_SELECT.
+	Type string `json:"type,omitempty"`
+}
+
+// GoogleAppsCardV1SelectionItem: An item that users can select in a selection
+// input, such as a checkbox or switch. Supports up to 100 items. [Google
+// Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1SelectionItem struct {
+	rawJSON
+
+	// BottomText: For multiselect menus, a text description or label that's
+	// displayed below the item's `text` field.
+	BottomText string `json:"bottomText,omitempty"`
+
+	// Selected: Whether the item is selected by default.
+	Selected bool `json:"selected,omitempty"`
+
+	// StartIconURI:
+	StartIconURI string `json:"startIconUri,omitempty"`
+
+	// Text: The text that identifies or describes the item to users.
+	Text string `json:"text,omitempty"`
+
+	// Value: The value associated with this item.
+	Value string `json:"value,omitempty"`
+}
+
+// GoogleAppsCardV1SuggestionItem: One suggested value that users can enter in a
+// text input field. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1SuggestionItem struct {
+	rawJSON
+
+	// Text: The value of a suggested input to a text input field.
+	Text string `json:"text,omitempty"`
+}
+
+// GoogleAppsCardV1Suggestions: Suggested values that users can enter. These
+// values appear when users click inside the text input field. As users type,
+// the suggested values dynamically filter to match what the users have typed.
+// For example, a text input field for programming language might suggest Java,
+// JavaScript, Python, and C++. When users start typing `Jav`, the list of
+// suggestions filters to show `Java` and `JavaScript`. Suggested values help
+// guide users to enter values that your app can make sense of. When referring
+// to JavaScript, some users might enter `javascript` and others `java script`.
+// Suggesting `JavaScript` can standardize how users interact with your app.
+// When specified, `TextInput.type` is always `SINGLE_LINE`, even if it's set to
+// `MULTIPLE_LINE`. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1Suggestions struct {
+	rawJSON
+
+	// Items: A list of suggestions used for autocomplete recommendations in text
+	// input fields.
+	Items []*GoogleAppsCardV1SuggestionItem `json:"items,omitempty"`
+}
+
+// GoogleAppsCardV1SwitchControl: Either a toggle-style switch or a checkbox
+// inside a `decoratedText` widget. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend): Only supported in the
+// `decoratedText` widget.
+type GoogleAppsCardV1SwitchControl struct {
+	rawJSON
+
+	// ControlType: How the switch appears in the user interface. Possible values:
+	// SWITCH, CHECKBOX, CHECK_BOX.
+	ControlType string `json:"controlType,omitempty"`
+
+	// Name: The name by which the switch widget is identified in a form input
+	// event.
+	Name string `json:"name,omitempty"`
+
+	// OnChangeAction: The action to perform when the switch state is changed, such
+	// as what function to run.
+	OnChangeAction *GoogleAppsCardV1Action `json:"onChangeAction,omitempty"`
+
+	// Selected: When `true`, the switch is selected.
+	Selected bool `json:"selected,omitempty"`
+
+	// Value: The value entered by a user, returned as part of a form input event.
+	Value string `json:"value,omitempty"`
+}
+
+// GoogleAppsCardV1TextInput: A field in which users can enter text. Supports
+// suggestions and on-change actions. Supports form submission validation. When
+// `Action.all_widgets_are_required` is set to `true` or this widget is
+// specified in `Action.required_widgets`, the submission action is blocked
+// unless a value is entered. For an example in Google Chat apps, see [Add a
+// field in which a user can enter
+// text](https://developers.google.com/workspace/chat/design-interactive-card-dialog#add_a_field_in_which_a_user_can_enter_text).
+// Chat apps receive and can process the value of entered text during form input
+// events. For details about working with form inputs, see [Receive form
+// data](https://developers.google.com/workspace/chat/read-form-data). When you
+// need to collect undefined or abstract data from users, use a text input. To
+// collect defined or enumerated data from users, use the SelectionInput widget.
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1TextInput struct {
+	rawJSON
+
+	// AutoCompleteAction: Optional. Specify what action to take when the text
+	// input field provides suggestions to users who interact with it.
+	AutoCompleteAction *GoogleAppsCardV1Action `json:"autoCompleteAction,omitempty"`
+
+	// HintText: Text that appears below the text input field meant to assist users
+	// by prompting them to enter a certain value.
+	HintText string `json:"hintText,omitempty"`
+
+	// HostAppDataSource: A data source that's unique to a Google Workspace host
+	// application, such as Gmail emails, Google Calendar events, or Google Chat
+	// messages.
+	HostAppDataSource *HostAppDataSourceMarkup `json:"hostAppDataSource,omitempty"`
+
+	// InitialSuggestions: Suggested values that users can enter.
+	InitialSuggestions *GoogleAppsCardV1Suggestions `json:"initialSuggestions,omitempty"`
+
+	// Label: The text that appears above the text input field in the user
+	// interface.
+	Label string `json:"label,omitempty"`
+
+	// Name: The name by which the text input is identified in a form input event.
+	Name string `json:"name,omitempty"`
+
+	// OnChangeAction: What to do when a change occurs in the text input field.
+	OnChangeAction *GoogleAppsCardV1Action `json:"onChangeAction,omitempty"`
+
+	// PlaceholderText: Text that appears in the text input field when the field is
+	// empty.
+	PlaceholderText string `json:"placeholderText,omitempty"`
+
+	// Type: How a text input field appears in the user interface. Possible values:
+	// SINGLE_LINE, MULTIPLE_LINE.
+	Type string `json:"type,omitempty"`
+
+	// Validation: Specify the input format validation necessary for this text
+	// field.
+	Validation *GoogleAppsCardV1Validation `json:"validation,omitempty"`
+
+	// Value: The value entered by a user, returned as part of a form input event.
+	Value string `json:"value,omitempty"`
+}
+
+// GoogleAppsCardV1TextParagraph: A paragraph of text that supports formatting.
+// For an example in Google Chat apps, see [Add a paragraph of formatted
+// text](https://developers.google.com/workspace/chat/add-text-image-card-dialog#add_a_paragraph_of_formatted_text).
+// For more information about formatting text, see [Formatting text in Google
+// Chat
+// apps](https://developers.google.com/workspace/chat/format-messages#card-formatting)
+// and [Formatting text in Google Workspace
+// add-ons](https://developers.google.com/apps-script/add-ons/concepts/widgets#text_formatting).
+// [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1TextParagraph struct {
+	rawJSON
+
+	// MaxLines: The maximum number of lines of text that are displayed in the
+	// widget.
+	MaxLines int64 `json:"maxLines,omitempty"`
+
+	// Text: The text that's shown in the widget.
+	Text string `json:"text,omitempty"`
+
+	// TextSyntax: The syntax of the text. Possible values:
+	// TEXT_SYNTAX_UNSPECIFIED, HTML, MARKDOWN.
+	TextSyntax string `json:"textSyntax,omitempty"`
+}
+
+// GoogleAppsCardV1Trigger: Represents a trigger. Available for Google Workspace
+// add-ons that extend Google Workspace Studio. Unavailable for Google Chat
+// apps.
+type GoogleAppsCardV1Trigger struct {
+	rawJSON
+
+	// ActionRuleID: The unique identifier of the ActionRule.
+	ActionRuleID string `json:"actionRuleId,omitempty"`
+}
+
+// GoogleAppsCardV1UpdateVisibilityAction: Represents an action that updates the
+// visibility of a widget. Available for Google Workspace add-ons that extend
+// Google Workspace Studio. Unavailable for Google Chat apps.
+type GoogleAppsCardV1UpdateVisibilityAction struct {
+	rawJSON
+
+	// Visibility: The new visibility. Possible values: VISIBILITY_UNSPECIFIED,
+	// VISIBLE, HIDDEN.
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// GoogleAppsCardV1Validation: Represents the necessary data for validating the
+// widget it's attached to. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend):
+type GoogleAppsCardV1Validation struct {
+	rawJSON
+
+	// CharacterLimit: Specify the character limit for text input widgets.
+	CharacterLimit int64 `json:"characterLimit,omitempty"`
+
+	// InputType: Specify the type of the input widgets. Possible values:
+	// INPUT_TYPE_UNSPECIFIED, TEXT, INTEGER, FLOAT, EMAIL, EMOJI_PICKER.
+	InputType string `json:"inputType,omitempty"`
+}
+
+// GoogleAppsCardV1Widget: Each card is made up of widgets. A widget is a
+// composite object that can represent one of text, images, buttons, and other
+// object types.
+type GoogleAppsCardV1Widget struct {
+	rawJSON
+
+	// ButtonList: A list of buttons.
+	ButtonList *GoogleAppsCardV1ButtonList `json:"buttonList,omitempty"`
+
+	// Carousel: A carousel contains a collection of nested widgets.
+	Carousel *GoogleAppsCardV1Carousel `json:"carousel,omitempty"`
+
+	// ChipList: A list of chips.
+	ChipList *GoogleAppsCardV1ChipList `json:"chipList,omitempty"`
+
+	// Columns: Displays up to 2 columns.
+	Columns *GoogleAppsCardV1Columns `json:"columns,omitempty"`
+
+	// DateTimePicker: Displays a widget that lets users input a date, time, or
+	// date and time.
+	DateTimePicker *GoogleAppsCardV1DateTimePicker `json:"dateTimePicker,omitempty"`
+
+	// DecoratedText: Displays a decorated text item.
+	DecoratedText *GoogleAppsCardV1DecoratedText `json:"decoratedText,omitempty"`
+
+	// Divider: Displays a horizontal line divider between widgets.
+	Divider *GoogleAppsCardV1Divider `json:"divider,omitempty"`
+
+	// EventActions: Specifies the event actions that can be performed on the
+	// widget.
+	EventActions []*GoogleAppsCardV1EventAction `json:"eventActions,omitempty"`
+
+	// Grid: Displays a grid with a collection of items.
+	Grid *GoogleAppsCardV1Grid `json:"grid,omitempty"`
+
+	// HorizontalAlignment: Specifies whether widgets align to the left, right, or
+	// center of a column. Possible values: HORIZONTAL_ALIGNMENT_UNSPECIFIED,
+	// START, CENTER, END.
+	HorizontalAlignment string `json:"horizontalAlignment,omitempty"`
+
+	// ID: A unique ID assigned to the widget that's used to identify the widget to
+	// be mutated.
+	ID string `json:"id,omitempty"`
+
+	// Image: Displays an image.
+	Image *GoogleAppsCardV1Image `json:"image,omitempty"`
+
+	// SelectionInput: Displays a selection control that lets users select items.
+	SelectionInput *GoogleAppsCardV1SelectionInput `json:"selectionInput,omitempty"`
+
+	// TextInput: Displays a text box that users can type into.
+	TextInput *GoogleAppsCardV1TextInput `json:"textInput,omitempty"`
+
+	// TextParagraph: Displays a text paragraph.
+	TextParagraph *GoogleAppsCardV1TextParagraph `json:"textParagraph,omitempty"`
+
+	// Visibility: Specifies whether the widget is visible or hidden. Possible
+	// values: VISIBILITY_UNSPECIFIED, VISIBLE, HIDDEN.
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// GoogleAppsCardV1Widgets: The supported widgets that you can include in a
+// column. [Google Workspace add-ons and Chat
+// apps](https://developers.google.com/workspace/extend)
+type GoogleAppsCardV1Widgets struct {
+	rawJSON
+
+	// ButtonList: ButtonList widget.
+	ButtonList *GoogleAppsCardV1ButtonList `json:"buttonList,omitempty"`
+
+	// ChipList: ChipList widget.
+	ChipList *GoogleAppsCardV1ChipList `json:"chipList,omitempty"`
+
+	// DateTimePicker: DateTimePicker widget.
+	DateTimePicker *GoogleAppsCardV1DateTimePicker `json:"dateTimePicker,omitempty"`
+
+	// DecoratedText: DecoratedText widget.
+	DecoratedText *GoogleAppsCardV1DecoratedText `json:"decoratedText,omitempty"`
+
+	// Image: Image widget.
+	Image *GoogleAppsCardV1Image `json:"image,omitempty"`
+
+	// SelectionInput: SelectionInput widget.
+	SelectionInput *GoogleAppsCardV1SelectionInput `json:"selectionInput,omitempty"`
+
+	// TextInput: TextInput widget.
+	TextInput *GoogleAppsCardV1TextInput `json:"textInput,omitempty"`
+
+	// TextParagraph: TextParagraph widget.
+	TextParagraph *GoogleAppsCardV1TextParagraph `json:"textParagraph,omitempty"`
+}
+
+// Group: A Google Group in Google Chat.
+type Group struct {
+	rawJSON
+
+	// Name: Resource name for a Google Group.
+	Name string `json:"name,omitempty"`
+}
+
+// HostAppDataSourceMarkup: A data source from a Google Workspace application.
+// The data source populates available items for a widget.
+type HostAppDataSourceMarkup struct {
+	rawJSON
+
+	// ChatDataSource: A data source from Google Chat.
+	ChatDataSource *ChatClientDataSourceMarkup `json:"chatDataSource,omitempty"`
+
+	// WorkflowDataSource: A data source from Google Workflow.
+	WorkflowDataSource *WorkflowDataSourceMarkup `json:"workflowDataSource,omitempty"`
+}
+
+// Image: An image that's specified by a URL and can have an `onclick` action.
+type Image struct {
+	rawJSON
+
+	// AspectRatio: The aspect ratio of this image (width and height).
+	AspectRatio float64 `json:"aspectRatio,omitempty"`
+
+	// ImageURL: The URL of the image.
+	ImageURL string `json:"imageUrl,omitempty"`
+
+	// OnClick: The `onclick` action.
+	OnClick *OnClick `json:"onClick,omitempty"`
+}
+
+// ImageButton: An image button with an `onclick` action.
+type ImageButton struct {
+	rawJSON
+
+	// Icon: The icon specified by an `enum` that indices to an icon provided by
+	// Chat API. Possible values: ICON_UNSPECIFIED, AIRPLANE, BOOKMARK, BUS, CAR,
+	// CLOCK, CONFIRMATION_NUMBER_ICON, DOLLAR, DESCRIPTION, EMAIL,
+	// EVENT_PERFORMER, EVENT_SEAT, FLIGHT_ARRIVAL, FLIGHT_DEPARTURE, HOTEL,
+	// HOTEL_ROOM_TYPE, INVITE, MAP_PIN, MEMBERSHIP, MULTIPLE_PEOPLE, OFFER,
+	// PERSON, PHONE, RESTAURANT_ICON, SHOPPING_CART, STAR, STORE, TICKET, TRAIN,
+	// VIDEO_CAMERA, VIDEO_PLAY.
+	Icon string `json:"icon,omitempty"`
+
+	// IconURL: The icon specified by a URL.
+	IconURL string `json:"iconUrl,omitempty"`
+
+	// Name: The name of this `image_button` that's used for accessibility.
+	Name string `json:"name,omitempty"`
+
+	// OnClick: The `onclick` action.
+	OnClick *OnClick `json:"onClick,omitempty"`
+}
+
+// Inputs: Types of data that users can [input on cards or
+// dialogs](https://developers.google.com/chat/ui/read-form-data). The input
+// type depends on the type of values that the widget accepts.
+type Inputs struct {
+	rawJSON
+
+	// DateInput: Date input values from a
+	// [`DateTimePicker`](https://developers.google.com/chat/api/reference/rest/v1/cards#DateTimePicker)
+	// widget that only accepts date values.
+	DateInput *DateInput `json:"dateInput,omitempty"`
+
+	// DateTimeInput: Date and time input values from a
+	// [`DateTimePicker`](https://developers.google.com/chat/api/reference/rest/v1/cards#DateTimePicker)
+	// widget that accepts both a date and time.
+	DateTimeInput *DateTimeInput `json:"dateTimeInput,omitempty"`
+
+	// StringInputs: A list of strings that represent the values that the user
+	// inputs in a widget.
+	StringInputs *StringInputs `json:"stringInputs,omitempty"`
+
+	// TimeInput: Time input values from a
+	// [`DateTimePicker`](https://developers.google.com/chat/api/reference/rest/v1/cards#DateTimePicker)
+	// widget that only accepts time values.
+	TimeInput *TimeInput `json:"timeInput,omitempty"`
+}
+
+// KeyValue: A UI element contains a key (label) and a value (content). This
+// element can also contain some actions such as `onclick` button.
+type KeyValue struct {
+	rawJSON
+
+	// BottomLabel: The text of the bottom label.
+	BottomLabel string `json:"bottomLabel,omitempty"`
+
+	// Button: A button that can be clicked to trigger an action.
+	Button *Button `json:"button,omitempty"`
+
+	// Content: The text of the content.
+	Content string `json:"content,omitempty"`
+
+	// ContentMultiline: If the content should be multiline.
+	ContentMultiline bool `json:"contentMultiline,omitempty"`
+
+	// Icon: An enum value that's replaced by the Chat API with the corresponding
+	// icon image. Possible values: ICON_UNSPECIFIED, AIRPLANE, BOOKMARK, BUS, CAR,
+	// CLOCK, CONFIRMATION_NUMBER_ICON, DOLLAR, DESCRIPTION, EMAIL,
+	// EVENT_PERFORMER, EVENT_SEAT, FLIGHT_ARRIVAL, FLIGHT_DEPARTURE, HOTEL,
+	// HOTEL_ROOM_TYPE, INVITE, MAP_PIN, MEMBERSHIP, MULTIPLE_PEOPLE, OFFER,
+	// PERSON, PHONE, RESTAURANT_ICON, SHOPPING_CART, STAR, STORE, TICKET, TRAIN,
+	// VIDEO_CAMERA, VIDEO_PLAY.
+	Icon string `json:"icon,omitempty"`
+
+	// IconURL: The icon specified by a URL.
+	IconURL string `json:"iconUrl,omitempty"`
+
+	// OnClick: The `onclick` action.
+	OnClick *OnClick `json:"onClick,omitempty"`
+
+	// TopLabel: The text of the top label.
+	TopLabel string `json:"topLabel,omitempty"`
+}
+
+// ListCustomEmojisResponse: A response to list custom emojis.
+type ListCustomEmojisResponse struct {
+	rawJSON
+
+	// CustomEmojis: Unordered list. List of custom emojis.
+	CustomEmojis []*CustomEmoji `json:"customEmojis,omitempty"`
+
+	// NextPageToken: A token that you can send as `pageToken` to retrieve the next
+	// page of results.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+// ListMembershipsResponse: Response to list memberships of the space.
+type ListMembershipsResponse struct {
+	rawJSON
+
+	// Memberships: Unordered list. List of memberships in the requested (or first)
+	// page.
+	Memberships []*Membership `json:"memberships,omitempty"`
+
+	// NextPageToken: A token that you can send as `pageToken` to retrieve the next
+	// page of results.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+// ListMessagesResponse: Response message for listing messages.
+type ListMessagesResponse struct {
+	rawJSON
+
+	// Messages: List of messages.
+	Messages []*Message `json:"messages,omitempty"`
+
+	// NextPageToken: You can send a token as `pageToken` to retrieve the next page
+	// of results.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+// ListReactionsResponse: Response to a list reactions request.
+type ListReactionsResponse struct {
+	rawJSON
+
+	// NextPageToken: Continuation token to retrieve the next page of results.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+
+	// Reactions: List of reactions in the requested (or first) page.
+	Reactions []*Reaction `json:"reactions,omitempty"`
+}
+
+// ListSpaceEventsResponse: Response message for listing space events.
+type ListSpaceEventsResponse struct {
+	rawJSON
+
+	// NextPageToken: Continuation token used to fetch more events.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+
+	// SpaceEvents: Results are returned in chronological order (oldest event
+	// first).
+	SpaceEvents []*SpaceEvent `json:"spaceEvents,omitempty"`
+}
+
+// ListSpacesResponse: The response for a list spaces request.
+type ListSpacesResponse struct {
+	rawJSON
+
+	// NextPageToken: You can send a token as `pageToken` to retrieve the next page
+	// of results.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+
+	// Spaces: List of spaces in the requested (or first) page.
+	Spaces []*Space `json:"spaces,omitempty"`
+}
+
+// MatchedUrl: A matched URL in a Chat message. Chat apps can preview matched
+// URLs. For more information, see [Preview
+// links](https://developers.google.com/chat/how-tos/preview-links).
+type MatchedUrl struct {
+	rawJSON
+
+	// URL: Output only. The URL that was matched.
+	URL string `json:"url,omitempty"`
+}
+
+// Media: Media resource.
+type Media struct {
+	rawJSON
+
+	// ResourceName: Name of the media resource.
+	ResourceName string `json:"resourceName,omitempty"`
+}
+
+// MeetSpaceLinkData: Data for Meet space links.
+type MeetSpaceLinkData struct {
+	rawJSON
+
+	// HuddleStatus: Optional. Output only. If the Meet is a Huddle, indicates the
+	// status of the huddle. Possible values: HUDDLE_STATUS_UNSPECIFIED, STARTED,
+	// ENDED, MISSED.
+	HuddleStatus string `json:"huddleStatus,omitempty"`
+
+	// MeetingCode: Meeting code of the linked Meet space.
+	MeetingCode string `json:"meetingCode,omitempty"`
+
+	// Type: Indicates the type of the Meet space. Possible values:
+	// TYPE_UNSPECIFIED, MEETING, HUDDLE.
+	Type string `json:"type,omitempty"`
+}
+
+// Membership: Represents a membership relation in Google Chat, such as whether
+// a user or Chat app is invited to, part of, or absent from a space.
+type Membership struct {
+	rawJSON
+
+	// CreateTime: Optional. Immutable. The creation time of the membership, such
+	// as when a member joined or was invited to join a space.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// DeleteTime: Optional. Immutable. The deletion time of the membership, such
+	// as when a member left or was removed from a space.
+	DeleteTime string `json:"deleteTime,omitempty"`
+
+	// GroupMember: Optional. The Google Group the membership corresponds to.
+	GroupMember *Group `json:"groupMember,omitempty"`
+
+	// Member: Optional. The Google Chat user or app the membership corresponds to.
+	Member *User `json:"member,omitempty"`
+
+	// Name: Identifier. Resource name of the membership, assigned by the server.
+	Name string `json:"name,omitempty"`
+
+	// Role: Optional. User's role within a Chat space, which determines their
+	// permitted actions in the space. Possible values:
+	// MEMBERSHIP_ROLE_UNSPECIFIED, ROLE_MEMBER, ROLE_MANAGER,
+	// ROLE_ASSISTANT_MANAGER.
+	Role string `json:"role,omitempty"`
+
+	// State: Output only. State of the membership. Possible values:
+	// MEMBERSHIP_STATE_UNSPECIFIED, JOINED, INVITED, NOT_A_MEMBER.
+	State string `json:"state,omitempty"`
+}
+
+// MembershipBatchCreatedEventData: Event payload for multiple new memberships.
+// Event type: `google.workspace.chat.membership.v1.batchCreated`
+type MembershipBatchCreatedEventData struct {
+	rawJSON
+
+	// Memberships: A list of new memberships.
+	Memberships []*MembershipCreatedEventData `json:"memberships,omitempty"`
+}
+
+// MembershipBatchDeletedEventData: Event payload for multiple deleted
+// memberships. Event type: `google.workspace.chat.membership.v1.batchDeleted`
+type MembershipBatchDeletedEventData struct {
+	rawJSON
+
+	// Memberships: A list of deleted memberships.
+	Memberships []*MembershipDeletedEventData `json:"memberships,omitempty"`
+}
+
+// MembershipBatchUpdatedEventData: Event payload for multiple updated
+// memberships. Event type: `google.workspace.chat.membership.v1.batchUpdated`
+type MembershipBatchUpdatedEventData struct {
+	rawJSON
+
+	// Memberships: A list of updated memberships.
+	Memberships []*MembershipUpdatedEventData `json:"memberships,omitempty"`
+}
+
+// MembershipCount: Represents the count of memberships of a space, grouped into
+// categories.
+type MembershipCount struct {
+	rawJSON
+
+	// JoinedDirectHumanUserCount: Output only. Count of human users that have
+	// directly joined the space, not counting users joined by having membership in
+	// a joined group.
+	JoinedDirectHumanUserCount int64 `json:"joinedDirectHumanUserCount,omitempty"`
+
+	// JoinedGroupCount: Output only. Count of all groups that have directly joined
+	// the space.
+	JoinedGroupCount int64 `json:"joinedGroupCount,omitempty"`
+}
+
+// MembershipCreatedEventData: Event payload for a new membership. Event type:
+// `google.workspace.chat.membership.v1.created`.
+type MembershipCreatedEventData struct {
+	rawJSON
+
+	// Membership: The new membership.
+	Membership *Membership `json:"membership,omitempty"`
+}
+
+// MembershipDeletedEventData: Event payload for a deleted membership. Event
+// type: `google.workspace.chat.membership.v1.deleted`
+type MembershipDeletedEventData struct {
+	rawJSON
+
+	// Membership: The deleted membership.
+	Membership *Membership `json:"membership,omitempty"`
+}
+
+// MembershipUpdatedEventData: Event payload for an updated membership. Event
+// type: `google.workspace.chat.membership.v1.updated`
+type MembershipUpdatedEventData struct {
+	rawJSON
+
+	// Membership: The updated membership.
+	Membership *Membership `json:"membership,omitempty"`
+}
+
+// Message: A message in a Google Chat space.
+type Message struct {
+	rawJSON
+
+	// AccessoryWidgets: Optional. One or more interactive widgets that appear at
+	// the bottom of a message.
+	AccessoryWidgets []*AccessoryWidget `json:"accessoryWidgets,omitempty"`
+
+	// ActionResponse: Input only. Parameters that a Chat app can use to configure
+	// how its response is posted.
+	ActionResponse *ActionResponse `json:"actionResponse,omitempty"`
+
+	// Annotations: Output only. Annotations can be associated with the plain-text
+	// body of the message or with chips that link to Google Workspace resources
+	// like Google Docs or Sheets with `start_index` and `length` of 0.
+	Annotations []*Annotation `json:"annotations,omitempty"`
+
+	// ArgumentText: Output only. Plain-text body of the message with all Chat app
+	// mentions stripped out.
+	ArgumentText string `json:"argumentText,omitempty"`
+
+	// AttachedGifs: Output only. GIF images that are attached to the message.
+	AttachedGifs []*AttachedGif `json:"attachedGifs,omitempty"`
+
+	// Attachment: Optional. User-uploaded attachment.
+	Attachment []*Attachment `json:"attachment,omitempty"`
+
+	// Cards: Deprecated: Use `cards_v2` instead.
+	Cards []*Card `json:"cards,omitempty"`
+
+	// CardsV2: Optional. An array of
+	// [cards](https://developers.google.com/workspace/chat/api/reference/rest/v1/cards).
+	CardsV2 []*CardWithId `json:"cardsV2,omitempty"`
+
+	// ClientAssignedMessageID: Optional. A custom ID for the message.
+	ClientAssignedMessageID string `json:"clientAssignedMessageId,omitempty"`
+
+	// CreateTime: Optional. Immutable. For spaces created in Chat, the time at
+	// which the message was created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// DeleteTime: Output only. The time at which the message was deleted in Google
+	// Chat.
+	DeleteTime string `json:"deleteTime,omitempty"`
+
+	// DeletionMetadata: Output only. Information about a deleted message.
+	DeletionMetadata *DeletionMetadata `json:"deletionMetadata,omitempty"`
+
+	// EmojiReactionSummaries: Output only. The list of emoji reaction summaries on
+	// the message.
+	EmojiReactionSummaries []*EmojiReactionSummary `json:"emojiReactionSummaries,omitempty"`
+
+	// FallbackText: Optional. A plain-text description of the message's cards,
+	// used when the actual cards can't be displayed—for example, mobile
+	// notifications.
+	FallbackText string `json:"fallbackText,omitempty"`
+
+	// FormattedText: Output only. Contains the message `text` with markups added
+	// to communicate formatting.
+	FormattedText string `json:"formattedText,omitempty"`
+
+	// LastUpdateTime: Output only. The time at which the message was last edited
+	// by a user.
+	LastUpdateTime string `json:"lastUpdateTime,omitempty"`
+
+	// MatchedURL: Output only. A URL in `spaces.messages.text` that matches a link
+	// preview pattern.
+	MatchedURL *MatchedUrl `json:"matchedUrl,omitempty"`
+
+	// Name: Identifier. Resource name of the message.
+	Name string `json:"name,omitempty"`
+
+	// PrivateMessageViewer: Optional. Immutable. Input for creating a message,
+	// otherwise output only.
+	PrivateMessageViewer *User `json:"privateMessageViewer,omitempty"`
+
+	// QuotedMessageMetadata: Optional. Information about a message that another
+	// message quotes.
+	QuotedMessageMetadata *QuotedMessageMetadata `json:"quotedMessageMetadata,omitempty"`
+
+	// Sender: Output only. The user who created the message.
+	Sender *User `json:"sender,omitempty"`
+
+	// SlashCommand: Output only. Slash command information, if applicable.
+	SlashCommand *SlashCommand `json:"slashCommand,omitempty"`
+
+	// Space: Output only. If your Chat app [authenticates as a
+	// user](https://developers.google.com/workspace/chat/authenticate-authorize-chat-user),
+	// the output only populates the
+	// [space](https://developers.google.com/workspace/chat/api/reference/rest/v1/spaces)
+	// `name`.
+	Space *Space `json:"space,omitempty"`
+
+	// Text: Optional. Plain-text body of the message.
+	Text string `json:"text,omitempty"`
+
+	// Thread: The thread the message belongs to.
+	Thread *Thread `json:"thread,omitempty"`
+
+	// ThreadReply: Output only. When `true`, the message is a response in a reply
+	// thread.
+	ThreadReply bool `json:"threadReply,omitempty"`
+}
+
+// MessageBatchCreatedEventData: Event payload for multiple new messages. Event
+// type: `google.workspace.chat.message.v1.batchCreated`
+type MessageBatchCreatedEventData struct {
+	rawJSON
+
+	// Messages: A list of new messages.
+	Messages []*MessageCreatedEventData `json:"messages,omitempty"`
+}
+
+// MessageBatchDeletedEventData: Event payload for multiple deleted messages.
+// Event type: `google.workspace.chat.message.v1.batchDeleted`
+type MessageBatchDeletedEventData struct {
+	rawJSON
+
+	// Messages: A list of deleted messages.
+	Messages []*MessageDeletedEventData `json:"messages,omitempty"`
+}
+
+// MessageBatchUpdatedEventData: Event payload for multiple updated messages.
+// Event type: `google.workspace.chat.message.v1.batchUpdated`
+type MessageBatchUpdatedEventData struct {
+	rawJSON
+
+	// Messages: A list of updated messages.
+	Messages []*MessageUpdatedEventData `json:"messages,omitempty"`
+}
+
+// MessageCreatedEventData: Event payload for a new message. Event type:
+// `google.workspace.chat.message.v1.created`
+type MessageCreatedEventData struct {
+	rawJSON
+
+	// Message: The new message.
+	Message *Message `json:"message,omitempty"`
+}
+
+// MessageDeletedEventData: Event payload for a deleted message. Event type:
+// `google.workspace.chat.message.v1.deleted`
+type MessageDeletedEventData struct {
+	rawJSON
+
+	// Message: The deleted message.
+	Message *Message `json:"message,omitempty"`
+}
+
+// MessageUpdatedEventData: Event payload for an updated message. Event type:
+// `google.workspace.chat.message.v1.updated`
+type MessageUpdatedEventData struct {
+	rawJSON
+
+	// Message: The updated message.
+	Message *Message `json:"message,omitempty"`
+}
+
+// OnClick: An `onclick` action (for example, open a link).
+type OnClick struct {
+	rawJSON
+
+	// Action: A form action is triggered by this `onclick` action if specified.
+	Action *FormAction `json:"action,omitempty"`
+
+	// OpenLink: This `onclick` action triggers an open link action if specified.
+	OpenLink *OpenLink `json:"openLink,omitempty"`
+}
+
+// OpenLink: A link that opens a new window.
+type OpenLink struct {
+	rawJSON
+
+	// URL: The URL to open.
+	URL string `json:"url,omitempty"`
+}
+
+// PermissionSetting: Represents a space permission setting.
+type PermissionSetting struct {
+	rawJSON
+
+	// AssistantManagersAllowed: Optional. Whether space managers
+	// `ROLE_ASSISTANT_MANAGER`) have this permission.
+	AssistantManagersAllowed bool `json:"assistantManagersAllowed,omitempty"`
+
+	// ManagersAllowed: Optional. Whether space owners (`ROLE_MANAGER`) have this
+	// permission.
+	ManagersAllowed bool `json:"managersAllowed,omitempty"`
+
+	// MembersAllowed: Optional. Whether basic space members (`ROLE_MEMBER`) have
+	// this permission.
+	MembersAllowed bool `json:"membersAllowed,omitempty"`
+}
+
+// PermissionSettings: [Permission
+// settings](https://support.google.com/chat/answer/13340792) that you can
+// specify when updating an existing named space. To set permission settings
+// when creating a space, specify the `PredefinedPermissionSettings` field in
+// your request.
+type PermissionSettings struct {
+	rawJSON
+
+	// ManageApps: Optional. Setting for managing apps in a space.
+	ManageApps *PermissionSetting `json:"manageApps,omitempty"`
+
+	// ManageMembersAndGroups: Optional. Setting for managing members and groups in
+	// a space.
+	ManageMembersAndGroups *PermissionSetting `json:"manageMembersAndGroups,omitempty"`
+
+	// ManageWebhooks: Optional. Setting for managing webhooks in a space.
+	ManageWebhooks *PermissionSetting `json:"manageWebhooks,omitempty"`
+
+	// ModifySpaceDetails: Optional. Setting for updating space name, avatar,
+	// description and guidelines.
+	ModifySpaceDetails *PermissionSetting `json:"modifySpaceDetails,omitempty"`
+
+	// PostMessages: Output only. Setting for posting messages in a space.
+	PostMessages *PermissionSetting `json:"postMessages,omitempty"`
+
+	// ReplyMessages: Optional. Setting for replying to messages in a space.
+	ReplyMessages *PermissionSetting `json:"replyMessages,omitempty"`
+
+	// ToggleHistory: Optional. Setting for toggling space history on and off.
+	ToggleHistory *PermissionSetting `json:"toggleHistory,omitempty"`
+
+	// UseAtMentionAll: Optional. Setting for using @all in a space.
+	UseAtMentionAll *PermissionSetting `json:"useAtMentionAll,omitempty"`
+}
+
+// QuotedMessageMetadata: Information about a message that another message
+// quotes. When you create a message, you can quote messages within the same
+// thread, or quote a root message to create a new root message. However, you
+// can't quote a message reply from a different thread. When you update a
+// message, you can't add or replace the `quotedMessageMetadata` field, but you
+// can remove it. For example usage, see [Quote another
+// message](https://developers.google.com/workspace/chat/create-messages#quote-a-message).
+type QuotedMessageMetadata struct {
+	rawJSON
+
+	// ForwardedMetadata: Output only. Metadata about the source space of the
+	// quoted message.
+	ForwardedMetadata *ForwardedMetadata `json:"forwardedMetadata,omitempty"`
+
+	// LastUpdateTime: Required. The timestamp when the quoted message was created
+	// or when the quoted message was last updated.
+	LastUpdateTime string `json:"lastUpdateTime,omitempty"`
+
+	// Name: Required. Resource name of the message that is quoted.
+	Name string `json:"name,omitempty"`
+
+	// QuoteType: Optional. Specifies the quote type. Possible values:
+	// QUOTE_TYPE_UNSPECIFIED, REPLY.
+	QuoteType string `json:"quoteType,omitempty"`
+
+	// QuotedMessageSnapshot: Output only. A snapshot of the quoted message's
+	// content.
+	QuotedMessageSnapshot *QuotedMessageSnapshot `json:"quotedMessageSnapshot,omitempty"`
+}
+
+// QuotedMessageSnapshot: Provides a snapshot of the content of the quoted
+// message at the time of quoting or forwarding
+type QuotedMessageSnapshot struct {
+	rawJSON
+
+	// Annotations: Output only. Annotations parsed from the text body of the
+	// quoted message.
+	Annotations []*Annotation `json:"annotations,omitempty"`
+
+	// Attachments: Output only. Attachments that were part of the quoted message.
+	Attachments []*Attachment `json:"attachments,omitempty"`
+
+	// FormattedText: Output only. Contains the quoted message `text` with markups
+	// added to support rich formatting like hyperlinks,custom emojis, markup, etc.
+	FormattedText string `json:"formattedText,omitempty"`
+
+	// Sender: Output only. The quoted message's author name.
+	Sender string `json:"sender,omitempty"`
+
+	// Text: Output only. Snapshot of the quoted message's text content.
+	Text string `json:"text,omitempty"`
+}
+
+// Reaction: A reaction to a message.
+type Reaction struct {
+	rawJSON
+
+	// Emoji: Required. The emoji used in the reaction.
+	Emoji *Emoji `json:"emoji,omitempty"`
+
+	// Name: Identifier. The resource name of the reaction.
+	Name string `json:"name,omitempty"`
+
+	// User: Output only. The user who created the reaction.
+	User *User `json:"user,omitempty"`
+}
+
+// ReactionBatchCreatedEventData: Event payload for multiple new reactions.
+// Event type: `google.workspace.chat.reaction.v1.batchCreated`
+type ReactionBatchCreatedEventData struct {
+	rawJSON
+
+	// Reactions: A list of new reactions.
+	Reactions []*ReactionCreatedEventData `json:"reactions,omitempty"`
+}
+
+// ReactionBatchDeletedEventData: Event payload for multiple deleted reactions.
+// Event type: `google.workspace.chat.reaction.v1.batchDeleted`
+type ReactionBatchDeletedEventData struct {
+	rawJSON
+
+	// Reactions: A list of deleted reactions.
+	Reactions []*ReactionDeletedEventData `json:"reactions,omitempty"`
+}
+
+// ReactionCreatedEventData: Event payload for a new reaction. Event type:
+// `google.workspace.chat.reaction.v1.created`
+type ReactionCreatedEventData struct {
+	rawJSON
+
+	// Reaction: The new reaction.
+	Reaction *Reaction `json:"reaction,omitempty"`
+}
+
+// ReactionDeletedEventData: Event payload for a deleted reaction. Type:
+// `google.workspace.chat.reaction.v1.deleted`
+type ReactionDeletedEventData struct {
+	rawJSON
+
+	// Reaction: The deleted reaction.
+	Reaction *Reaction `json:"reaction,omitempty"`
+}
+
+// RichLinkMetadata: A rich link to a resource. Rich links can be associated
+// with the plain-text body of the message or represent chips that link to
+// Google Workspace resources like Google Docs or Sheets with `start_index` and
+// `length` of 0.
+type RichLinkMetadata struct {
+	rawJSON
+
+	// CalendarEventLinkData: Data for a Calendar event link.
+	CalendarEventLinkData *CalendarEventLinkData `json:"calendarEventLinkData,omitempty"`
+
+	// ChatSpaceLinkData: Data for a chat space link.
+	ChatSpaceLinkData *ChatSpaceLinkData `json:"chatSpaceLinkData,omitempty"`
+
+	// DriveLinkData: Data for a drive link.
+	DriveLinkData *DriveLinkData `json:"driveLinkData,omitempty"`
+
+	// MeetSpaceLinkData: Data for a Meet space link.
+	MeetSpaceLinkData *MeetSpaceLinkData `json:"meetSpaceLinkData,omitempty"`
+
+	// RichLinkType: The rich link type. Possible values:
+	// RICH_LINK_TYPE_UNSPECIFIED, DRIVE_FILE, CHAT_SPACE, GMAIL_MESSAGE,
+	// MEET_SPACE, CALENDAR_EVENT.
+	RichLinkType string `json:"richLinkType,omitempty"`
+
+	// URI: The URI of this link.
+	URI string `json:"uri,omitempty"`
+}
+
+// SearchSpacesResponse: Response with a list of spaces corresponding to the
+// search spaces request.
+type SearchSpacesResponse struct {
+	rawJSON
+
+	// NextPageToken: A token that can be used to retrieve the next page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+
+	// Spaces: A page of the requested spaces.
+	Spaces []*Space `json:"spaces,omitempty"`
+
+	// TotalSize: The total number of spaces that match the query, across all
+	// pages.
+	TotalSize int64 `json:"totalSize,omitempty"`
+}
+
+// Section: A section contains a collection of widgets that are rendered
+// (vertically) in the order that they are specified. Across all platforms,
+// cards have a narrow fixed width, so there's currently no need for layout
+// properties (for example, float).
+type Section struct {
+	rawJSON
+
+	// Header: The header of the section.
+	Header string `json:"header,omitempty"`
+
+	// Widgets: A section must contain at least one widget.
+	Widgets []*WidgetMarkup `json:"widgets,omitempty"`
+}
+
+// SelectionItems: List of widget autocomplete results.
+type SelectionItems struct {
+	rawJSON
+
+	// Items: An array of the SelectionItem objects.
+	Items []*GoogleAppsCardV1SelectionItem `json:"items,omitempty"`
+}
+
+// SetUpSpaceRequest: Request to create a space and add specified users to it.
+type SetUpSpaceRequest struct {
+	rawJSON
+
+	// Memberships: Optional. The Google Chat users or groups to invite to join the
+	// space.
+	Memberships []*Membership `json:"memberships,omitempty"`
+
+	// RequestID: Optional. A unique identifier for this request.
+	RequestID string `json:"requestId,omitempty"`
+
+	// Space: Required. The `Space.spaceType` field is required.
+	Space *Space `json:"space,omitempty"`
+}
+
+// SlashCommand: Metadata about a [slash
+// command](https://developers.google.com/workspace/chat/commands) in Google
+// Chat.
+type SlashCommand struct {
+	rawJSON
+
+	// CommandID: The ID of the slash command.
+	CommandID int64 `json:"commandId,omitempty,string"`
+}
+
+// SlashCommandMetadata: Annotation metadata for slash commands (/).
+type SlashCommandMetadata struct {
+	rawJSON
+
+	// Bot: The Chat app whose command was invoked.
+	Bot *User `json:"bot,omitempty"`
+
+	// CommandID: The command ID of the invoked slash command.
+	CommandID int64 `json:"commandId,omitempty,string"`
+
+	// CommandName: The name of the invoked slash command.
+	CommandName string `json:"commandName,omitempty"`
+
+	// TriggersDialog: Indicates whether the slash command is for a dialog.
+	TriggersDialog bool `json:"triggersDialog,omitempty"`
+
+	// Type: The type of slash command. Possible values: TYPE_UNSPECIFIED, ADD,
+	// INVOKE.
+	Type string `json:"type,omitempty"`
+}
+
+// Space: A space in Google Chat. Spaces are conversations between two or more
+// users or 1:1 messages between a user and a Chat app.
+type Space struct {
+	rawJSON
+
+	// AccessSettings: Optional. Specifies the [access
+	// setting](https://support.google.com/chat/answer/11971020) of the space.
+	AccessSettings *AccessSettings `json:"accessSettings,omitempty"`
+
+	// AdminInstalled: Output only. For direct message (DM) spaces with a Chat app,
+	// whether the space was created by a Google Workspace administrator.
+	AdminInstalled bool `json:"adminInstalled,omitempty"`
+
+	// CreateTime: Optional. Immutable. For spaces created in Chat, the time the
+	// space was created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// Customer: Optional. Immutable. The customer id of the domain of the space.
+	Customer string `json:"customer,omitempty"`
+
+	// DisplayName: Optional. The space's display name.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// ExternalUserAllowed: Optional. Immutable. Whether this space permits any
+	// Google Chat user as a member.
+	ExternalUserAllowed bool `json:"externalUserAllowed,omitempty"`
+
+	// ImportMode: Optional. Whether this space is created in `Import Mode` as part
+	// of a data migration into Google Workspace.
+	ImportMode bool `json:"importMode,omitempty"`
+
+	// ImportModeExpireTime: Output only. The time when the space will be
+	// automatically deleted by the system if it remains in import mode.
+	ImportModeExpireTime string `json:"importModeExpireTime,omitempty"`
+
+	// LastActiveTime: Output only. Timestamp of the last message in the space.
+	LastActiveTime string `json:"lastActiveTime,omitempty"`
+
+	// MembershipCount: Output only. The count of joined memberships grouped by
+	// member type.
+	MembershipCount *MembershipCount `json:"membershipCount,omitempty"`
+
+	// Name: Identifier. Resource name of the space.
+	Name string `json:"name,omitempty"`
+
+	// PermissionSettings: Optional. Space permission settings for existing spaces.
+	PermissionSettings *PermissionSettings `json:"permissionSettings,omitempty"`
+
+	// PredefinedPermissionSettings: Optional. Input only. Predefined space
+	// permission settings, input only when creating a space. Possible values:
+	// PREDEFINED_PERMISSION_SETTINGS_UNSPECIFIED, COLLABORATION_SPACE,
+	// ANNOUNCEMENT_SPACE.
+	PredefinedPermissionSettings string `json:"predefinedPermissionSettings,omitempty"`
+
+	// SingleUserBotDm: Optional. Whether the space is a DM between a Chat app and
+	// a single human.
+	SingleUserBotDm bool `json:"singleUserBotDm,omitempty"`
+
+	// SpaceDetails: Optional. Details about the space including description and
+	// rules.
+	SpaceDetails *SpaceDetails `json:"spaceDetails,omitempty"`
+
+	// SpaceHistoryState: Optional. The message history state for messages and
+	// threads in this space. Possible values: HISTORY_STATE_UNSPECIFIED,
+	// HISTORY_OFF, HISTORY_ON.
+	SpaceHistoryState string `json:"spaceHistoryState,omitempty"`
+
+	// SpaceThreadingState: Output only. The threading state in the Chat space.
+	// Possible values: SPACE_THREADING_STATE_UNSPECIFIED, THREADED_MESSAGES,
+	// GROUPED_MESSAGES, UNTHREADED_MESSAGES.
+	SpaceThreadingState string `json:"spaceThreadingState,omitempty"`
+
+	// SpaceType: Optional. The type of space. Possible values:
+	// SPACE_TYPE_UNSPECIFIED, SPACE, GROUP_CHAT, DIRECT_MESSAGE.
+	SpaceType string `json:"spaceType,omitempty"`
+
+	// SpaceURI: Output only. The URI for a user to access the space.
+	SpaceURI string `json:"spaceUri,omitempty"`
+
+	// Threaded: Output only. Deprecated: Use `spaceThreadingState` instead.
+	//
+	// Deprecated: see the Google Chat API reference.
+	Threaded bool `json:"threaded,omitempty"`
+
+	// Type: Output only. Deprecated: Use `space_type` instead. Possible values:
+	// TYPE_UNSPECIFIED, ROOM, DM.
+	//
+	// Deprecated: see the Google Chat API reference.
+	Type string `json:"type,omitempty"`
+}
+
+// SpaceBatchUpdatedEventData: Event payload for multiple updates to a space.
+// Event type: `google.workspace.chat.space.v1.batchUpdated`
+type SpaceBatchUpdatedEventData struct {
+	rawJSON
+
+	// Spaces: A list of updated spaces.
+	Spaces []*SpaceUpdatedEventData `json:"spaces,omitempty"`
+}
+
+// SpaceDataSource: A data source that populates Google Chat spaces as selection
+// items for a multiselect menu. Only populates spaces that the user is a member
+// of. [Google Chat apps](https://developers.google.com/workspace/chat):
+type SpaceDataSource struct {
+	rawJSON
+
+	// DefaultToCurrentSpace: If set to `true`, the multiselect menu selects the
+	// current Google Chat space as an item by default.
+	DefaultToCurrentSpace bool `json:"defaultToCurrentSpace,omitempty"`
+}
+
+// SpaceDetails: Details about the space including description and rules.
+type SpaceDetails struct {
+	rawJSON
+
+	// Description: Optional. A description of the space.
+	Description string `json:"description,omitempty"`
+
+	// Guidelines: Optional. The space's rules, expectations, and etiquette.
+	Guidelines string `json:"guidelines,omitempty"`
+}
+
+// SpaceEvent: An event that represents a change or activity in a Google Chat
+// space. To learn more, see [Work with events from Google
+// Chat](https://developers.google.com/workspace/chat/events-overview).
+type SpaceEvent struct {
+	rawJSON
+
+	// EventTime: Time when the event occurred.
+	EventTime string `json:"eventTime,omitempty"`
+
+	// EventType: Type of space event.
+	EventType string `json:"eventType,omitempty"`
+
+	// MembershipBatchCreatedEventData: Event payload for multiple new memberships.
+	MembershipBatchCreatedEventData *MembershipBatchCreatedEventData `json:"membershipBatchCreatedEventData,omitempty"`
+
+	// MembershipBatchDeletedEventData: Event payload for multiple deleted
+	// memberships.
+	MembershipBatchDeletedEventData *MembershipBatchDeletedEventData `json:"membershipBatchDeletedEventData,omitempty"`
+
+	// MembershipBatchUpdatedEventData: Event payload for multiple updated
+	// memberships.
+	MembershipBatchUpdatedEventData *MembershipBatchUpdatedEventData `json:"membershipBatchUpdatedEventData,omitempty"`
+
+	// MembershipCreatedEventData: Event payload for a new membership.
+	MembershipCreatedEventData *MembershipCreatedEventData `json:"membershipCreatedEventData,omitempty"`
+
+	// MembershipDeletedEventData: Event payload for a deleted membership.
+	MembershipDeletedEventData *MembershipDeletedEventData `json:"membershipDeletedEventData,omitempty"`
+
+	// MembershipUpdatedEventData: Event payload for an updated membership.
+	MembershipUpdatedEventData *MembershipUpdatedEventData `json:"membershipUpdatedEventData,omitempty"`
+
+	// MessageBatchCreatedEventData: Event payload for multiple new messages.
+	MessageBatchCreatedEventData *MessageBatchCreatedEventData `json:"messageBatchCreatedEventData,omitempty"`
+
+	// MessageBatchDeletedEventData: Event payload for multiple deleted messages.
+	MessageBatchDeletedEventData *MessageBatchDeletedEventData `json:"messageBatchDeletedEventData,omitempty"`
+
+	// MessageBatchUpdatedEventData: Event payload for multiple updated messages.
+	MessageBatchUpdatedEventData *MessageBatchUpdatedEventData `json:"messageBatchUpdatedEventData,omitempty"`
+
+	// MessageCreatedEventData: Event payload for a new message.
+	MessageCreatedEventData *MessageCreatedEventData `json:"messageCreatedEventData,omitempty"`
+
+	// MessageDeletedEventData: Event payload for a deleted message.
+	MessageDeletedEventData *MessageDeletedEventData `json:"messageDeletedEventData,omitempty"`
+
+	// MessageUpdatedEventData: Event payload for an updated message.
+	MessageUpdatedEventData *MessageUpdatedEventData `json:"messageUpdatedEventData,omitempty"`
+
+	// Name: Resource name of the space event.
+	Name string `json:"name,omitempty"`
+
+	// ReactionBatchCreatedEventData: Event payload for multiple new reactions.
+	ReactionBatchCreatedEventData *ReactionBatchCreatedEventData `json:"reactionBatchCreatedEventData,omitempty"`
+
+	// ReactionBatchDeletedEventData: Event payload for multiple deleted reactions.
+	ReactionBatchDeletedEventData *ReactionBatchDeletedEventData `json:"reactionBatchDeletedEventData,omitempty"`
+
+	// ReactionCreatedEventData: Event payload for a new reaction.
+	ReactionCreatedEventData *ReactionCreatedEventData `json:"reactionCreatedEventData,omitempty"`
+
+	// ReactionDeletedEventData: Event payload for a deleted reaction.
+	ReactionDeletedEventData *ReactionDeletedEventData `json:"reactionDeletedEventData,omitempty"`
+
+	// SpaceBatchUpdatedEventData: Event payload for multiple updates to a space.
+	SpaceBatchUpdatedEventData *SpaceBatchUpdatedEventData `json:"spaceBatchUpdatedEventData,omitempty"`
+
+	// SpaceUpdatedEventData: Event payload for a space update.
+	SpaceUpdatedEventData *SpaceUpdatedEventData `json:"spaceUpdatedEventData,omitempty"`
+}
+
+// SpaceNotificationSetting: The notification setting of a user in a space.
+type SpaceNotificationSetting struct {
+	rawJSON
+
+	// MuteSetting: The space notification mute setting. Possible values:
+	// MUTE_SETTING_UNSPECIFIED, UNMUTED, MUTED.
+	MuteSetting string `json:"muteSetting,omitempty"`
+
+	// Name: Identifier. The resource name of the space notification setting.
+	Name string `json:"name,omitempty"`
+
+	// NotificationSetting: The notification setting. Possible values:
+	// NOTIFICATION_SETTING_UNSPECIFIED, ALL, MAIN_CONVERSATIONS, FOR_YOU, OFF.
+	NotificationSetting string `json:"notificationSetting,omitempty"`
+}
+
+// SpaceReadState: A user's read state within a space, used to identify read and
+// unread messages.
+type SpaceReadState struct {
+	rawJSON
+
+	// LastReadTime: Optional. The time when the user's space read state was
+	// updated.
+	LastReadTime string `json:"lastReadTime,omitempty"`
+
+	// Name: Resource name of the space read state.
+	Name string `json:"name,omitempty"`
+}
+
+// SpaceUpdatedEventData: Event payload for an updated space. Event type:
+// `google.workspace.chat.space.v1.updated`
+type SpaceUpdatedEventData struct {
+	rawJSON
+
+	// Space: The updated space.
+	Space *Space `json:"space,omitempty"`
+}
+
+// Status: The `Status` type defines a logical error model that is suitable for
+// different programming environments, including REST APIs and RPC APIs. It is
+// used by [gRPC](https://github.com/grpc). Each `Status` message contains three
+// pieces of data: error code, error message, and error details. You can find
+// out more about this error model and how to work with it in the [API Design
+// Guide](https://cloud.google.com/apis/design/errors).
+type Status struct {
+	rawJSON
+
+	// Code: The status code, which should be an enum value of google.rpc.Code.
+	Code int64 `json:"code,omitempty"`
+
+	// Details: A list of messages that carry the error details.
+	Details []map[string]interface{} `json:"details,omitempty"`
+
+	// Message: A developer-facing error message, which should be in English.
+	Message string `json:"message,omitempty"`
+}
+
+// StringInputs: Input parameter for regular widgets. For single-valued widgets,
+// it is a single value list. For multi-valued widgets, such as checkbox, all
+// the values are presented.
+type StringInputs struct {
+	rawJSON
+
+	// Value: An list of strings entered by the user.
+	Value []string `json:"value,omitempty"`
+}
+
+// TextButton: A button with text and `onclick` action.
+type TextButton struct {
+	rawJSON
+
+	// OnClick: The `onclick` action of the button.
+	OnClick *OnClick `json:"onClick,omitempty"`
+
+	// Text: The text of the button.
+	Text string `json:"text,omitempty"`
+}
+
+// TextParagraph: A paragraph of text. Formatted text supported. For more
+// information about formatting text, see [Formatting text in Google Chat
+// apps](https://developers.google.com/workspace/chat/format-messages#card-formatting)
+// and [Formatting text in Google Workspace
+// Add-ons](https://developers.google.com/apps-script/add-ons/concepts/widgets#text_formatting).
+type TextParagraph struct {
+	rawJSON
+
+	// Text:
+	Text string `json:"text,omitempty"`
+}
+
+// Thread: A thread in a Google Chat space. For example usage, see [Start or
+// reply to a message
+// thread](https://developers.google.com/workspace/chat/create-messages#create-message-thread).
+// If you specify a thread when creating a message, you can set the
+// [`messageReplyOption`](https://developers.google.com/workspace/chat/api/reference/rest/v1/spaces.messages/create#messagereplyoption)
+// field to determine what happens if no matching thread is found.
+type Thread struct {
+	rawJSON
+
+	// Name: Identifier. Resource name of the thread.
+	Name string `json:"name,omitempty"`
+
+	// ThreadKey: Optional. Input for creating or updating a thread.
+	ThreadKey string `json:"threadKey,omitempty"`
+}
+
+// ThreadReadState: A user's read state within a thread, used to identify read
+// and unread messages.
+type ThreadReadState struct {
+	rawJSON
+
+	// LastReadTime: The time when the user's thread read state was updated.
+	LastReadTime string `json:"lastReadTime,omitempty"`
+
+	// Name: Resource name of the thread read state.
+	Name string `json:"name,omitempty"`
+}
+
+// TimeInput: Time input values.
+type TimeInput struct {
+	rawJSON
+
+	// Hours: The hour on a 24-hour clock.
+	Hours int64 `json:"hours,omitempty"`
+
+	// Minutes: The number of minutes past the hour.
+	Minutes int64 `json:"minutes,omitempty"`
+}
+
+// TimeZone: The timezone ID and offset from Coordinated Universal Time (UTC).
+// Only supported for the event types
+// [`CARD_CLICKED`](https://developers.google.com/chat/api/reference/rest/v1/EventType#ENUM_VALUES.CARD_CLICKED)
+// and
+// [`SUBMIT_DIALOG`](https://developers.google.com/chat/api/reference/rest/v1/DialogEventType#ENUM_VALUES.SUBMIT_DIALOG).
+type TimeZone struct {
+	rawJSON
+
+	// ID: The [IANA TZ](https://www.iana.org/time-zones) time zone database code,
+	// such as "America/Toronto".
+	ID string `json:"id,omitempty"`
+
+	// Offset: The user timezone offset, in milliseconds, from Coordinated
+	// Universal Time (UTC).
+	Offset int64 `json:"offset,omitempty"`
+}
+
+// UpdatedWidget: For `selectionInput` widgets, returns autocomplete suggestions
+// for a multiselect menu.
+type UpdatedWidget struct {
+	rawJSON
+
+	// Suggestions: List of widget autocomplete results
+	Suggestions *SelectionItems `json:"suggestions,omitempty"`
+
+	// Widget: The ID of the updated widget.
+	Widget string `json:"widget,omitempty"`
+}
+
+// UploadAttachmentRequest: Request to upload an attachment.
+type UploadAttachmentRequest struct {
+	rawJSON
+
+	// Filename: Required. The filename of the attachment, including the file
+	// extension.
+	Filename string `json:"filename,omitempty"`
+}
+
+// UploadAttachmentResponse: Response of uploading an attachment.
+type UploadAttachmentResponse struct {
+	rawJSON
+
+	// AttachmentDataRef: Reference to the uploaded attachment.
+	AttachmentDataRef *AttachmentDataRef `json:"attachmentDataRef,omitempty"`
+}
+
+// User: A user in Google Chat. When returned as an output from a request, if
+// your Chat app [authenticates as a
+// user](https://developers.google.com/workspace/chat/authenticate-authorize-chat-user),
+// the output for a `User` resource only populates the user's `name` and `type`.
+type User struct {
+	rawJSON
+
+	// DisplayName: Output only. The user's display name.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// DomainID: Unique identifier of the user's Google Workspace domain.
+	DomainID string `json:"domainId,omitempty"`
+
+	// IsAnonymous: Output only. When `true`, the user is deleted or their profile
+	// is not visible.
+	IsAnonymous bool `json:"isAnonymous,omitempty"`
+
+	// Name: Resource name for a Google Chat user.
+	Name string `json:"name,omitempty"`
+
+	// Type: User type. Possible values: TYPE_UNSPECIFIED, HUMAN, BOT.
+	Type string `json:"type,omitempty"`
+}
+
+// UserMentionMetadata: Annotation metadata for user mentions (@).
+type UserMentionMetadata struct {
+	rawJSON
+
+	// Type: The type of user mention. Possible values: TYPE_UNSPECIFIED, ADD,
+	// MENTION.
+	Type string `json:"type,omitempty"`
+
+	// User: The user mentioned.
+	User *User `json:"user,omitempty"`
+}
+
+// WidgetMarkup: A widget is a UI element that presents text and images.
+type WidgetMarkup struct {
+	rawJSON
+
+	// Buttons: A list of buttons.
+	Buttons []*Button `json:"buttons,omitempty"`
+
+	// Image: Display an image in this widget.
+	Image *Image `json:"image,omitempty"`
+
+	// KeyValue: Display a key value item in this widget.
+	KeyValue *KeyValue `json:"keyValue,omitempty"`
+
+	// TextParagraph: Display a text paragraph in this widget.
+	TextParagraph *TextParagraph `json:"textParagraph,omitempty"`
+}
+
+// WorkflowDataSourceMarkup: * Only supported by Google Workspace Workflow, but
+// not Google Chat apps or Google Workspace add-ons. In a `TextInput` or
+// `SelectionInput` widget with MULTI_SELECT type or a `DateTimePicker`, provide
+// data source from Google.
+type WorkflowDataSourceMarkup struct {
+	rawJSON
+
+	// IncludeVariables: Whether to include variables from the previous step in the
+	// data source.
+	IncludeVariables bool `json:"includeVariables,omitempty"`
+
+	// Type: The type of data source. Possible values: UNKNOWN, USER, SPACE,
+	// USER_WITH_FREE_FORM.
+	Type string `json:"type,omitempty"`
+}
@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"net/url"
 )
 
@@ -20,16 +19,16 @@ func NewNotificationsService(client *Client) *NotificationsService {
 // Get returns the notification setting for a space.
 // GET /v1/{name}
 // Name format: users/{user}/spaces/{space}/spaceNotificationSetting
-func (s *NotificationsService) Get(ctx context.Context, name string) (json.RawMessage, error) {
-	return s.client.Get(ctx, name, nil)
+func (s *NotificationsService) Get(ctx context.Context, name string) (*SpaceNotificationSetting, error) {
+	return decode[SpaceNotificationSetting](s.client.Get(ctx, name, nil))
 }
 
 // Patch updates the notification setting for a space.
 // PATCH /v1/{name}
 // Name format: users/{user}/spaces/{space}/spaceNotificationSetting
-func (s *NotificationsService) Patch(ctx context.Context, name string, setting map[string]interface{}, updateMask string) (json.RawMessage, error) {
+func (s *NotificationsService) Patch(ctx context.Context, name string, setting *SpaceNotificationSetting, updateMask string) (*SpaceNotificationSetting, error) {
 	params := url.Values{}
 	AddQueryParam(params, "updateMask", updateMask)
 
-	return s.client.Patch(ctx, name, params, setting)
+	return decode[SpaceNotificationSetting](s.client.Patch(ctx, name, params, setting))
 }
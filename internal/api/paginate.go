@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"iter"
+
+	"github.com/cipher-shad0w/gogchat/internal/telemetry"
+)
+
+// PageOptions controls iteration over a paginated List endpoint.
+type PageOptions struct {
+	// PageSize is the page-size hint sent with every request. Zero lets the
+	// server pick its default.
+	PageSize int
+	// PageToken is the token of the first page to fetch. Empty starts at the
+	// beginning of the collection.
+	PageToken string
+	// MaxItems stops the iteration after this many items. Zero means no limit.
+	MaxItems int
+}
+
+// PageFunc fetches a single page. It receives the page token and page size to
+// request and returns the page's items and the token of the next page ("" on
+// the last page).
+type PageFunc[T any] func(ctx context.Context, pageToken string, pageSize int) ([]T, string, error)
+
+// Paginate returns an iterator over every item of a paginated collection.
+// Pages are fetched lazily as the caller consumes items, so large listings
+// stream instead of being held in memory. Iteration stops at the last page,
+// after opts.MaxItems items, when the caller breaks out of the loop, or when
+// ctx is cancelled. Errors are yielded once as the final element.
+//
+// A field selector set with WithFields is extended with nextPageToken, so
+// that partial responses can still be paged through. Every page is fetched
+// in a "list page" span.
+func Paginate[T any](ctx context.Context, opts PageOptions, fetch PageFunc[T]) iter.Seq2[T, error] {
+	ctx = pageFields(ctx)
+	return func(yield func(T, error) bool) {
+		var zero T
+		pageToken := opts.PageToken
+		seen := 0
+
+		for page := 1; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			// Don't ask for more than we are going to hand out.
+			pageSize := opts.PageSize
+			if opts.MaxItems > 0 {
+				remaining := opts.MaxItems - seen
+				if pageSize <= 0 || remaining < pageSize {
+					pageSize = remaining
+				}
+			}
+
+			pageCtx, span := telemetry.Start(ctx, "list page", telemetry.KindInternal, telemetry.Attr{Key: "page", Value: page})
+			items, next, err := fetch(pageCtx, pageToken, pageSize)
+			span.SetAttr("items", len(items))
+			span.SetError(err)
+			span.End()
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				seen++
+				if opts.MaxItems > 0 && seen >= opts.MaxItems {
+					return
+				}
+			}
+
+			if next == "" {
+				return
+			}
+			pageToken = next
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+	"github.com/cipher-shad0w/gogchat/internal/chattest"
+)
+
+// addSpaces seeds n spaces and returns their names in listing order.
+func addSpaces(t *testing.T, srv *chattest.Server, n int) []string {
+	t.Helper()
+	var names []string
+	for i := range n {
+		names = append(names, srv.AddSpace(api.Space{DisplayName: fmt.Sprintf("Space %d", i)}).Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestPaginateAll(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	want := addSpaces(t, srv, 7)
+
+	var got []string
+	for sp, err := range api.NewSpacesService(c).All(context.Background(), "", api.PageOptions{PageSize: 3}) {
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		got = append(got, sp.Name)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("All = %v, want %v", got, want)
+	}
+	if n := len(rec.matching("GET /v1/spaces?")); n != 3 {
+		t.Errorf("fetched %d pages, want 3", n)
+	}
+}
+
+func TestPaginateMaxItems(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	want := addSpaces(t, srv, 7)[:4]
+
+	var got []string
+	for sp, err := range api.NewSpacesService(c).All(context.Background(), "", api.PageOptions{PageSize: 3, MaxItems: 4}) {
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		got = append(got, sp.Name)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("All = %v, want %v", got, want)
+	}
+
+	// The second page only asks for the one item still needed.
+	pages := rec.matching("GET /v1/spaces?")
+	if len(pages) != 2 || !strings.Contains(pages[1], "pageSize=1") {
+		t.Errorf("requests = %v, want two pages, the second with pageSize=1", pages)
+	}
+}
+
+func TestPaginateIsLazy(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	addSpaces(t, srv, 7)
+
+	n := 0
+	for _, err := range api.NewSpacesService(c).All(context.Background(), "", api.PageOptions{PageSize: 3}) {
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		if n++; n == 2 {
+			break
+		}
+	}
+	if pages := len(rec.matching("GET /v1/spaces?")); pages != 1 {
+		t.Errorf("fetched %d pages after breaking on the first one, want 1", pages)
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	srv, c, _ := newTestClient(t)
+	c.Retry.MaxAttempts = 1
+	want := addSpaces(t, srv, 5)[:3]
+
+	var got []string
+	var iterErr error
+	for sp, err := range api.NewSpacesService(c).All(context.Background(), "", api.PageOptions{PageSize: 3}) {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		got = append(got, sp.Name)
+		if len(got) == 1 {
+			// Pages are fetched as items are consumed, so this fails the
+			// second page.
+			srv.FailNext("GET", "spaces", http.StatusInternalServerError)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("items before the error = %v, want %v", got, want)
+	}
+	var apiErr *api.APIError
+	if !errors.As(iterErr, &apiErr) || apiErr.Code != http.StatusInternalServerError {
+		t.Errorf("error = %v, want a 500 APIError", iterErr)
+	}
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default client-side rate limits, in requests per second. They stay below
+// the Google Chat per-project read/write quotas and the per-space write
+// limit of roughly one write per second.
+const (
+	DefaultReadRate       = 10.0
+	DefaultWriteRate      = 5.0
+	DefaultSpaceWriteRate = 1.0
+)
+
+// RateLimiter is a client-side token-bucket limiter with separate buckets for
+// read and write requests. Writes are additionally paced per space, keyed by
+// the "spaces/{id}" segment of the request path, so that concurrent commands
+// sharing a client never exceed the per-space write quota.
+//
+// A rate of zero or less disables the corresponding bucket.
+type RateLimiter struct {
+	ReadRate       float64
+	WriteRate      float64
+	SpaceWriteRate float64
+
+	mu     sync.Mutex
+	read   *bucket
+	write  *bucket
+	spaces map[string]*bucket
+}
+
+// NewRateLimiter creates a RateLimiter with the given per-second rates.
+func NewRateLimiter(readRate, writeRate, spaceWriteRate float64) *RateLimiter {
+	return &RateLimiter{
+		ReadRate:       readRate,
+		WriteRate:      writeRate,
+		SpaceWriteRate: spaceWriteRate,
+	}
+}
+
+// Wait blocks until a request with the given method and URL path may be sent,
+// or until ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, method, path string) error {
+	if l == nil {
+		return nil
+	}
+	for _, b := range l.buckets(method, path) {
+		if err := b.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TryTake takes the tokens for a request with the given method and URL path
+// if all of them are available right away, and reports whether it did.
+// Unlike Wait, it never blocks and never runs a bucket into debt.
+func (l *RateLimiter) TryTake(method, path string) bool {
+	if l == nil {
+		return true
+	}
+	buckets := l.buckets(method, path)
+	// buckets always returns the shared bucket before the space bucket, so
+	// concurrent callers lock them in the same order.
+	for _, b := range buckets {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+	now := time.Now()
+	for _, b := range buckets {
+		b.refill(now)
+		if b.rate > 0 && b.tokens < 1 {
+			return false
+		}
+	}
+	for _, b := range buckets {
+		if b.rate > 0 {
+			b.tokens--
+		}
+	}
+	return true
+}
+
+// buckets returns the buckets a request must take a token from, creating
+// them lazily.
+func (l *RateLimiter) buckets(method, path string) []*bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !isWriteMethod(method) {
+		if l.read == nil {
+			l.read = newBucket(l.ReadRate)
+		}
+		return []*bucket{l.read}
+	}
+
+	if l.write == nil {
+		l.write = newBucket(l.WriteRate)
+	}
+	buckets := []*bucket{l.write}
+
+	if space := spaceKey(path); space != "" {
+		if l.spaces == nil {
+			l.spaces = make(map[string]*bucket)
+		}
+		b, ok := l.spaces[space]
+		if !ok {
+			b = newBucket(l.SpaceWriteRate)
+			l.spaces[space] = b
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets
+}
+
+// isWriteMethod reports whether the HTTP method counts against write quotas.
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// spaceKey extracts the "spaces/{id}" prefix from a request path such as
+// "/v1/spaces/AAAA/messages". It returns "" when the path does not address a
+// specific space (e.g. "spaces:setup").
+func spaceKey(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] != "spaces" {
+			continue
+		}
+		id, _, _ := strings.Cut(parts[i+1], ":")
+		if id != "" {
+			return "spaces/" + id
+		}
+	}
+	return ""
+}
+
+// bucket is a token bucket holding at most one second's worth of tokens
+// (and at least one).
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64) *bucket {
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{rate: rate, burst: burst, tokens: burst}
+}
+
+// wait takes a token from the bucket, sleeping until one is available.
+func (b *bucket) wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	b.refill(time.Now())
+	// Reserve the token now so that concurrent callers queue up behind us.
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// Give the reservation back; we never sent the request.
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// refill adds the tokens earned since the last call. b.mu must be held.
+func (b *bucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+// pacedKey marks a context whose requests have already taken their rate
+// limiter tokens, such as the calls of a batch, which are admitted one by
+// one before it is sent.
+type pacedKey struct{}
+
+// withPaced returns ctx marked by pacedKey.
+func withPaced(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pacedKey{}, true)
+}
+
+// paced reports whether the requests of ctx have taken their tokens.
+func paced(ctx context.Context) bool {
+	return ctx.Value(pacedKey{}) != nil
+}
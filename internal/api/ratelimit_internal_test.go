@@ -0,0 +1,21 @@
+package api
+
+import "testing"
+
+func TestSpaceKey(t *testing.T) {
+	tests := []struct{ path, want string }{
+		{"/v1/spaces/AAAA/messages", "spaces/AAAA"},
+		{"/v1/spaces/AAAA/messages/BBBB.CCCC", "spaces/AAAA"},
+		{"/v1/spaces/AAAA:completeImport", "spaces/AAAA"},
+		{"/upload/v1/spaces/AAAA/attachments:upload", "spaces/AAAA"},
+		{"/v1/spaces:setup", ""},
+		{"/v1/spaces", ""},
+		{"/v1/users/me/spaces/AAAA/spaceReadState", "spaces/AAAA"},
+		{"/batch", ""},
+	}
+	for _, tt := range tests {
+		if got := spaceKey(tt.path); got != tt.want {
+			t.Errorf("spaceKey(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+func TestRateLimiterSpaceBuckets(t *testing.T) {
+	l := api.NewRateLimiter(0, 0, 1)
+	if !l.TryTake(http.MethodPost, "/v1/spaces/A/messages") {
+		t.Fatal("first write to spaces/A was refused")
+	}
+	if l.TryTake(http.MethodPatch, "/v1/spaces/A/messages/M") {
+		t.Error("second write to spaces/A within a second was admitted")
+	}
+	if !l.TryTake(http.MethodPost, "/v1/spaces/B/messages") {
+		t.Error("write to spaces/B waited for spaces/A")
+	}
+	if !l.TryTake(http.MethodGet, "/v1/spaces/A/messages") {
+		t.Error("read from spaces/A waited for its writes")
+	}
+	if !l.TryTake(http.MethodPost, "/v1/spaces:setup") {
+		t.Error("write without a space waited for a space")
+	}
+}
+
+func TestRateLimiterSharedWriteBucket(t *testing.T) {
+	// The project-wide write bucket caps writes across spaces.
+	l := api.NewRateLimiter(0, 1, 0)
+	if !l.TryTake(http.MethodPost, "/v1/spaces/A/messages") {
+		t.Fatal("first write was refused")
+	}
+	if l.TryTake(http.MethodPost, "/v1/spaces/B/messages") {
+		t.Error("second write within a second was admitted")
+	}
+	if !l.TryTake(http.MethodGet, "/v1/spaces/B/messages") {
+		t.Error("read waited for the write bucket")
+	}
+}
+
+func TestRateLimiterWaitPacesSpace(t *testing.T) {
+	l := api.NewRateLimiter(0, 0, 20)
+	ctx := context.Background()
+	start := time.Now()
+	// The bucket starts full with 20 tokens; the rest are paced at 20/s.
+	for range 25 {
+		if err := l.Wait(ctx, http.MethodPost, "/v1/spaces/A/messages"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("25 writes to one space took %v, want at least 200ms", elapsed)
+	}
+
+	start = time.Now()
+	if err := l.Wait(ctx, http.MethodPost, "/v1/spaces/B/messages"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("write to another space waited %v", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	l := api.NewRateLimiter(0, 0, 1)
+	path := "/v1/spaces/A/messages"
+	if err := l.Wait(context.Background(), http.MethodPost, path); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, http.MethodPost, path); err == nil {
+		t.Fatal("Wait succeeded past its deadline")
+	}
+	// The cancelled wait gave its reservation back, so a token is
+	// available again after one second rather than two.
+	time.Sleep(time.Second)
+	if !l.TryTake(http.MethodPost, path) {
+		t.Error("cancelled wait kept its reservation")
+	}
+}
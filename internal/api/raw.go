@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// rawJSON is embedded in every generated resource type. For a value
+// returned by a service call, it keeps the JSON that the value was decoded
+// from, so that --json can print the server's response as is, including
+// fields that models.go does not know about.
+type rawJSON struct {
+	raw json.RawMessage
+}
+
+// RawJSON returns the JSON the value was decoded from, or nil if it was
+// built in code or is nested in another value.
+func (r *rawJSON) RawJSON() json.RawMessage {
+	return r.raw
+}
+
+func (r *rawJSON) setRaw(raw json.RawMessage) {
+	r.raw = raw
+}
+
+// rawSetter is implemented by pointers to the generated types.
+type rawSetter interface {
+	setRaw(json.RawMessage)
+}
+
+var rawSetterType = reflect.TypeFor[rawSetter]()
+
+// keepRaw records raw as the JSON that v, a decoded response, came from. The
+// items of a list response are printed one by one, so every element of a
+// top-level list field also gets its own part of raw. Other nested values
+// keep nothing.
+func keepRaw(v any, raw json.RawMessage) {
+	r, ok := v.(rawSetter)
+	if !ok {
+		return
+	}
+	r.setRaw(raw)
+
+	rv := reflect.ValueOf(v).Elem()
+	var fields map[string]json.RawMessage
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Type().Field(i)
+		list := rv.Field(i)
+		if f.Type.Kind() != reflect.Slice || !f.Type.Elem().Implements(rawSetterType) || list.Len() == 0 {
+			continue
+		}
+		if fields == nil {
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				return
+			}
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		var items []json.RawMessage
+		if err := json.Unmarshal(fields[name], &items); err != nil || len(items) != list.Len() {
+			continue
+		}
+		for j, item := range items {
+			if elem := list.Index(j); !elem.IsNil() {
+				elem.Interface().(rawSetter).setRaw(item)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+	"github.com/cipher-shad0w/gogchat/internal/output"
+)
+
+func TestRawJSON(t *testing.T) {
+	srv, c, _ := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	srv.AddMessage(sp.Name, api.Message{Text: "one", Thread: &api.Thread{ThreadKey: "t"}})
+	srv.AddMessage(sp.Name, api.Message{Text: "two"})
+
+	resp, err := api.NewMessagesService(c).List(context.Background(), sp.Name, 0, "", "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.RawJSON()) == 0 {
+		t.Error("list response kept no JSON")
+	}
+	for _, msg := range resp.Messages {
+		var got api.Message
+		if err := json.Unmarshal(msg.RawJSON(), &got); err != nil || got.Name != msg.Name {
+			t.Errorf("item %s kept %s", msg.Name, msg.RawJSON())
+		}
+		if msg.Thread != nil && msg.Thread.RawJSON() != nil {
+			t.Errorf("nested thread of %s kept %s", msg.Name, msg.Thread.RawJSON())
+		}
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	srv, c, _ := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+
+	got, err := api.NewSpacesService(c).Get(context.Background(), sp.Name, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw, ok := output.JSONValue(got).(json.RawMessage); !ok || !strings.Contains(string(raw), `"spaceUri"`) {
+		t.Errorf("JSONValue = %v, want the response as received", output.JSONValue(got))
+	}
+
+	// A changed value is printed as it is now.
+	got.DisplayName = "B"
+	out, err := json.Marshal(output.JSONValue(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"displayName":"B"`) {
+		t.Errorf("JSONValue of a changed value = %s, want displayName B", out)
+	}
+}
+
+func TestServerRejectsUnknownFields(t *testing.T) {
+	srv, c, _ := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+
+	_, err := c.Post(context.Background(), sp.Name+"/messages", nil, map[string]any{"text": "hi", "bogus": true})
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("posting an unknown field: %v, want a 400 error", err)
+	}
+}
@@ -2,8 +2,8 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 )
 
@@ -20,7 +20,7 @@ func NewReactionsService(client *Client) *ReactionsService {
 
 // List returns reactions on a message.
 // parent is the message resource name, e.g. "spaces/{space}/messages/{message}".
-func (s *ReactionsService) List(ctx context.Context, parent string, pageSize int, pageToken, filter string) (json.RawMessage, error) {
+func (s *ReactionsService) List(ctx context.Context, parent string, pageSize int, pageToken, filter string) (*ListReactionsResponse, error) {
 	parent = NormalizeName(parent, "spaces/")
 	path := fmt.Sprintf("%s/reactions", parent)
 
@@ -29,23 +29,51 @@ func (s *ReactionsService) List(ctx context.Context, parent string, pageSize int
 	AddQueryParam(params, "pageToken", pageToken)
 	AddQueryParam(params, "filter", filter)
 
-	return s.client.Get(ctx, path, params)
+	return decode[ListReactionsResponse](s.client.Get(ctx, path, params))
+}
+
+// All iterates over every reaction on a message, fetching pages on demand.
+func (s *ReactionsService) All(ctx context.Context, parent, filter string, opts PageOptions) iter.Seq2[*Reaction, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, pageToken string, pageSize int) ([]*Reaction, string, error) {
+		resp, err := s.List(ctx, parent, pageSize, pageToken, filter)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Reactions, resp.NextPageToken, nil
+	})
 }
 
 // Create adds a reaction to a message.
 // parent is the message resource name, e.g. "spaces/{space}/messages/{message}".
 // reaction is the request body describing the reaction to create.
-func (s *ReactionsService) Create(ctx context.Context, parent string, reaction map[string]interface{}) (json.RawMessage, error) {
+func (s *ReactionsService) Create(ctx context.Context, parent string, reaction *Reaction) (*Reaction, error) {
 	parent = NormalizeName(parent, "spaces/")
 	path := fmt.Sprintf("%s/reactions", parent)
 
-	return s.client.Post(ctx, path, nil, reaction)
+	return decode[Reaction](s.client.Post(ctx, path, nil, reaction))
+}
+
+// CreateMany adds the same reaction to several messages using batch
+// requests. Results are in the order of parents; a failure for one message
+// does not affect the others.
+// POST /batch
+func (s *ReactionsService) CreateMany(ctx context.Context, parents []string, reaction *Reaction) ([]BatchResult[Reaction], error) {
+	batch := s.client.NewBatch()
+	calls := make([]*BatchCall, len(parents))
+	for i, parent := range parents {
+		parent = NormalizeName(parent, "spaces/")
+		calls[i] = batch.Post(fmt.Sprintf("%s/reactions", parent), nil, reaction)
+	}
+	if err := batch.Do(ctx); err != nil {
+		return nil, err
+	}
+	return batchResults[Reaction](calls), nil
 }
 
 // Delete removes a reaction.
 // name is the full reaction resource name,
 // e.g. "spaces/{space}/messages/{message}/reactions/{reaction}".
-func (s *ReactionsService) Delete(ctx context.Context, name string) (json.RawMessage, error) {
+func (s *ReactionsService) Delete(ctx context.Context, name string) (*Empty, error) {
 	name = NormalizeName(name, "spaces/")
-	return s.client.Delete(ctx, name, nil)
+	return decode[Empty](s.client.Delete(ctx, name, nil))
 }
@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"net/url"
 )
 
@@ -21,23 +20,38 @@ func NewReadStateService(client *Client) *ReadStateService {
 // GetSpaceReadState returns the read state of a space for the calling user.
 // GET /v1/{name}
 // Name format: users/{user}/spaces/{space}/spaceReadState
-func (s *ReadStateService) GetSpaceReadState(ctx context.Context, name string) (json.RawMessage, error) {
-	return s.client.Get(ctx, name, nil)
+func (s *ReadStateService) GetSpaceReadState(ctx context.Context, name string) (*SpaceReadState, error) {
+	return decode[SpaceReadState](s.client.Get(ctx, name, nil))
+}
+
+// GetSpaceReadStates returns the read states of several spaces using batch
+// requests. Results are in the order of names.
+// POST /batch
+func (s *ReadStateService) GetSpaceReadStates(ctx context.Context, names []string) ([]BatchResult[SpaceReadState], error) {
+	batch := s.client.NewBatch()
+	calls := make([]*BatchCall, len(names))
+	for i, name := range names {
+		calls[i] = batch.Get(name, nil)
+	}
+	if err := batch.Do(ctx); err != nil {
+		return nil, err
+	}
+	return batchResults[SpaceReadState](calls), nil
 }
 
 // UpdateSpaceReadState updates the read state of a space for the calling user.
 // PATCH /v1/{name}
 // Name format: users/{user}/spaces/{space}/spaceReadState
-func (s *ReadStateService) UpdateSpaceReadState(ctx context.Context, name string, state map[string]interface{}, updateMask string) (json.RawMessage, error) {
+func (s *ReadStateService) UpdateSpaceReadState(ctx context.Context, name string, state *SpaceReadState, updateMask string) (*SpaceReadState, error) {
 	params := url.Values{}
 	AddQueryParam(params, "updateMask", updateMask)
 
-	return s.client.Patch(ctx, name, params, state)
+	return decode[SpaceReadState](s.client.Patch(ctx, name, params, state))
 }
 
 // GetThreadReadState returns the read state of a thread for the calling user.
 // GET /v1/{name}
 // Name format: users/{user}/spaces/{space}/threads/{thread}/threadReadState
-func (s *ReadStateService) GetThreadReadState(ctx context.Context, name string) (json.RawMessage, error) {
-	return s.client.Get(ctx, name, nil)
+func (s *ReadStateService) GetThreadReadState(ctx context.Context, name string) (*ThreadReadState, error) {
+	return decode[ThreadReadState](s.client.Get(ctx, name, nil))
 }
@@ -0,0 +1,87 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+func TestRetryTransientStatus(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "Team"})
+	srv.FailNext("GET", sp.Name, http.StatusServiceUnavailable)
+	srv.FailNext("GET", sp.Name, http.StatusTooManyRequests)
+
+	got, err := api.NewSpacesService(c).Get(context.Background(), sp.Name, false)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.DisplayName != "Team" {
+		t.Errorf("DisplayName = %q, want Team", got.DisplayName)
+	}
+	if n := len(rec.matching("GET /v1/" + sp.Name)); n != 3 {
+		t.Errorf("sent %d requests, want 3", n)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	c.Retry.MaxAttempts = 2
+	sp := srv.AddSpace(api.Space{DisplayName: "Team"})
+	for range 3 {
+		srv.FailNext("GET", sp.Name, http.StatusInternalServerError)
+	}
+
+	_, err := api.NewSpacesService(c).Get(context.Background(), sp.Name, false)
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusInternalServerError {
+		t.Fatalf("Get error = %v, want a 500 APIError", err)
+	}
+	if n := len(rec.matching("GET /v1/" + sp.Name)); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+}
+
+func TestRetrySkipsPermanentErrors(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "Team"})
+	srv.FailNext("GET", sp.Name, http.StatusForbidden)
+
+	_, err := api.NewSpacesService(c).Get(context.Background(), sp.Name, false)
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusForbidden {
+		t.Fatalf("Get error = %v, want a 403 APIError", err)
+	}
+	if n := len(rec.matching("GET /v1/" + sp.Name)); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
+
+func TestRetryPostNeedsRequestID(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "Team"})
+	path := sp.Name + "/messages"
+	msg := &api.Message{Text: "hello"}
+
+	// Without a requestId the server cannot deduplicate a resent POST, so
+	// it is not retried.
+	srv.FailNext("POST", path, http.StatusServiceUnavailable)
+	if _, err := c.Post(context.Background(), path, nil, msg); err == nil {
+		t.Fatal("Post without requestId succeeded, want the injected 503")
+	}
+	if n := len(rec.matching("POST /v1/" + path)); n != 1 {
+		t.Errorf("sent %d requests without requestId, want 1", n)
+	}
+
+	srv.FailNext("POST", path, http.StatusServiceUnavailable)
+	if _, err := c.Post(context.Background(), path, url.Values{"requestId": {"r1"}}, msg); err != nil {
+		t.Fatalf("Post with requestId: %v", err)
+	}
+	if n := len(rec.matching("POST /v1/" + path)); n != 3 {
+		t.Errorf("sent %d requests in total, want 3", n)
+	}
+}
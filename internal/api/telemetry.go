@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cipher-shad0w/gogchat/internal/telemetry"
+)
+
+// startRequestSpan starts the client span covering every attempt of req.
+// Spans are named after the request's path template rather than its path,
+// so that requests for different resources group together.
+func startRequestSpan(req *http.Request) *telemetry.Span {
+	template := pathTemplate(req.URL.Path)
+	_, span := telemetry.Start(req.Context(), req.Method+" "+template, telemetry.KindClient,
+		telemetry.Attr{Key: "http.request.method", Value: req.Method},
+		telemetry.Attr{Key: "url.template", Value: template},
+		telemetry.Attr{Key: "server.address", Value: req.URL.Hostname()},
+	)
+	return span
+}
+
+// pathTemplate replaces the resource IDs in an API path with placeholders
+// named after their collection, e.g. /v1/spaces/AAA/messages/BBB becomes
+// /v1/spaces/{space}/messages/{message}. Custom methods (":search") are
+// kept. Paths outside the versioned API are returned unchanged.
+func pathTemplate(path string) string {
+	i := strings.Index(path, "v1/")
+	if i < 0 {
+		return path
+	}
+	prefix, rest := path[:i+len("v1/")], path[i+len("v1/"):]
+	if strings.HasPrefix(rest, "media/") {
+		return prefix + "media/{resourceName}"
+	}
+
+	segs := strings.Split(rest, "/")
+	for i := 1; i < len(segs); i += 2 {
+		verb := ""
+		if j := strings.Index(segs[i], ":"); j >= 0 {
+			verb = segs[i][j:]
+		}
+		segs[i] = "{" + strings.TrimSuffix(segs[i-1], "s") + "}" + verb
+	}
+	return prefix + strings.Join(segs, "/")
+}
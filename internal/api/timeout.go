@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultRequestTimeout is the per-request timeout used by NewClient.
+const DefaultRequestTimeout = 60 * time.Second
+
+// ErrRequestTimeout is returned when a single HTTP request makes no progress
+// for Client.RequestTimeout. It is treated as a transient failure, so the
+// request is retried according to the client's retry policy.
+var ErrRequestTimeout = errors.New("request timed out")
+
+// roundTrip sends a single attempt of req through the HTTP client, guarded
+// by c.RequestTimeout and tagged with c.QuotaProject.
+//
+// The timeout is an idle timeout rather than a deadline: it restarts every
+// time the request body is read or the response body delivers data, so
+// large uploads and downloads are not cut off while they are progressing,
+// but a hung connection is abandoned. The guard stays armed until the
+// response body is closed.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if c.QuotaProject != "" {
+		req.Header.Set("X-Goog-User-Project", c.QuotaProject)
+	}
+	if c.RequestTimeout <= 0 {
+		return c.HTTPClient.Do(req)
+	}
+
+	ctx, cancel := context.WithCancelCause(req.Context())
+	w := &watchdog{ctx: ctx, timeout: c.RequestTimeout}
+	w.timer = time.AfterFunc(c.RequestTimeout, func() {
+		cancel(ErrRequestTimeout)
+	})
+	w.stop = func() {
+		w.timer.Stop()
+		cancel(context.Canceled)
+	}
+
+	r := req.WithContext(ctx)
+	if req.Body != nil && req.Body != http.NoBody {
+		r.Body = &watchedBody{ReadCloser: req.Body, w: w}
+	}
+
+	resp, err := c.HTTPClient.Do(r)
+	if err != nil {
+		err = w.translate(err)
+		w.stop()
+		return nil, err
+	}
+	resp.Body = &watchedBody{ReadCloser: resp.Body, w: w, closes: true}
+	return resp, nil
+}
+
+// watchdog cancels a request that has been idle for too long.
+type watchdog struct {
+	ctx     context.Context
+	timeout time.Duration
+	timer   *time.Timer
+	stop    func()
+}
+
+// kick restarts the idle timer after progress was made.
+func (w *watchdog) kick() {
+	w.timer.Reset(w.timeout)
+}
+
+// translate replaces the cancellation error caused by the watchdog firing
+// with ErrRequestTimeout. Other errors, including the caller's own
+// cancellation, are returned unchanged.
+func (w *watchdog) translate(err error) error {
+	if err != nil && context.Cause(w.ctx) == ErrRequestTimeout {
+		return fmt.Errorf("%w: no response for %s", ErrRequestTimeout, w.timeout)
+	}
+	return err
+}
+
+// watchedBody is a request or response body that keeps its watchdog alive
+// while data flows. A response body stops the watchdog when closed.
+type watchedBody struct {
+	io.ReadCloser
+	w      *watchdog
+	closes bool
+	once   sync.Once
+}
+
+func (b *watchedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.w.kick()
+	}
+	if err != nil && err != io.EOF {
+		err = b.w.translate(err)
+	}
+	return n, err
+}
+
+func (b *watchedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.closes {
+		b.once.Do(b.w.stop)
+	}
+	return err
+}
@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// stall blocks until req is abandoned.
+func stall(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestRequestTimeout(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	c.Retry.MaxAttempts = 1
+	c.RequestTimeout = 20 * time.Millisecond
+	rec.next = transportFunc(stall)
+
+	_, err := api.NewSpacesService(c).Get(context.Background(), sp.Name, false)
+	if !errors.Is(err, api.ErrRequestTimeout) {
+		t.Fatalf("Get error = %v, want ErrRequestTimeout", err)
+	}
+}
+
+func TestRequestTimeoutIsRetried(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	c.RequestTimeout = 20 * time.Millisecond
+	next := rec.next
+	first := true
+	rec.next = transportFunc(func(req *http.Request) (*http.Response, error) {
+		if first {
+			first = false
+			return stall(req)
+		}
+		return next.RoundTrip(req)
+	})
+
+	got, err := api.NewSpacesService(c).Get(context.Background(), sp.Name, false)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.DisplayName != "A" {
+		t.Errorf("DisplayName = %q, want A", got.DisplayName)
+	}
+	if n := len(rec.requests); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+}
+
+// slowBody delivers its content a few bytes at a time with a pause before
+// each read.
+type slowBody struct {
+	io.ReadCloser
+	pause time.Duration
+}
+
+func (b *slowBody) Read(p []byte) (int, error) {
+	time.Sleep(b.pause)
+	if len(p) > 8 {
+		p = p[:8]
+	}
+	return b.ReadCloser.Read(p)
+}
+
+func TestRequestTimeoutIsIdle(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	c.Retry.MaxAttempts = 1
+	c.RequestTimeout = 50 * time.Millisecond
+	next := rec.next
+	rec.next = transportFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err == nil {
+			resp.Body = &slowBody{ReadCloser: resp.Body, pause: 10 * time.Millisecond}
+		}
+		return resp, err
+	})
+
+	start := time.Now()
+	got, err := api.NewSpacesService(c).Get(context.Background(), sp.Name, false)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Name != sp.Name {
+		t.Errorf("Name = %q, want %q", got.Name, sp.Name)
+	}
+	if elapsed := time.Since(start); elapsed < c.RequestTimeout {
+		t.Errorf("response took %s, want longer than the %s timeout for this test to be meaningful", elapsed, c.RequestTimeout)
+	}
+}
+
+// stalledBody delivers nothing until its request is abandoned.
+type stalledBody struct {
+	io.ReadCloser
+	ctx context.Context
+}
+
+func (b *stalledBody) Read(p []byte) (int, error) {
+	<-b.ctx.Done()
+	return 0, b.ctx.Err()
+}
+
+func TestRequestTimeoutStalledBody(t *testing.T) {
+	srv, c, rec := newTestClient(t)
+	sp := srv.AddSpace(api.Space{DisplayName: "A"})
+	c.Retry.MaxAttempts = 1
+	c.RequestTimeout = 20 * time.Millisecond
+	next := rec.next
+	rec.next = transportFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err == nil {
+			resp.Body = &stalledBody{ReadCloser: resp.Body, ctx: req.Context()}
+		}
+		return resp, err
+	})
+
+	_, err := api.NewSpacesService(c).Get(context.Background(), sp.Name, false)
+	if !errors.Is(err, api.ErrRequestTimeout) {
+		t.Fatalf("Get error = %v, want ErrRequestTimeout", err)
+	}
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// CommandTokenSource returns a token source whose access tokens are
+// printed by command, run through the platform shell, e.g.
+// "gcloud auth print-access-token". The command prints either the bare
+// token or a JSON object with "access_token" and optionally "expires_in"
+// (seconds) or "expiry" (RFC 3339). The command is run again once a token
+// with a known expiry runs out; a bare token is used for the rest of the
+// process.
+func CommandTokenSource(command string) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, commandSource(command))
+}
+
+type commandSource string
+
+func (s commandSource) Token() (*oauth2.Token, error) {
+	command := string(s)
+	out, err := runShell(command, nil, os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("getting access token: %w", err)
+	}
+	token, err := parseCommandToken(strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, fmt.Errorf("getting access token from %q: %w", command, err)
+	}
+	return token, nil
+}
+
+// parseCommandToken parses the output of a token command.
+func parseCommandToken(out string) (*oauth2.Token, error) {
+	if out == "" {
+		return nil, errors.New("the command printed no token")
+	}
+	if !strings.HasPrefix(out, "{") {
+		if strings.ContainsAny(out, " \t\r\n") {
+			return nil, errors.New("the command printed more than a token")
+		}
+		return &oauth2.Token{AccessToken: out, TokenType: "Bearer"}, nil
+	}
+
+	var v struct {
+		AccessToken string    `json:"access_token"`
+		ExpiresIn   int64     `json:"expires_in"`
+		Expiry      time.Time `json:"expiry"`
+	}
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		return nil, fmt.Errorf("parsing token: %w", err)
+	}
+	if v.AccessToken == "" {
+		return nil, errors.New("parsing token: no access_token in the output")
+	}
+	token := &oauth2.Token{AccessToken: v.AccessToken, TokenType: "Bearer", Expiry: v.Expiry}
+	if v.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(v.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
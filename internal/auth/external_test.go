@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCommandToken(t *testing.T) {
+	expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		out    string
+		want   string
+		expiry bool
+		ok     bool
+	}{
+		{"ya29.abc", "ya29.abc", false, true},
+		{`{"access_token":"ya29.abc"}`, "ya29.abc", false, true},
+		{`{"access_token":"ya29.abc","expires_in":3599}`, "ya29.abc", true, true},
+		{`{"access_token":"ya29.abc","expiry":"2030-01-02T03:04:05Z"}`, "ya29.abc", true, true},
+		{"", "", false, false},
+		{"Bearer ya29.abc", "", false, false},
+		{"ya29.abc\nya29.def", "", false, false},
+		{`{"token":"ya29.abc"}`, "", false, false},
+		{`{"access_token":`, "", false, false},
+	}
+	for _, tt := range tests {
+		token, err := parseCommandToken(tt.out)
+		if (err == nil) != tt.ok {
+			t.Errorf("parseCommandToken(%q) err = %v, want ok %v", tt.out, err, tt.ok)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if token.AccessToken != tt.want || token.TokenType != "Bearer" {
+			t.Errorf("parseCommandToken(%q) = %q (%s), want %q (Bearer)", tt.out, token.AccessToken, token.TokenType, tt.want)
+		}
+		if token.Expiry.IsZero() == tt.expiry {
+			t.Errorf("parseCommandToken(%q) expiry = %v, want set %v", tt.out, token.Expiry, tt.expiry)
+		}
+	}
+
+	token, err := parseCommandToken(`{"access_token":"ya29.abc","expiry":"2030-01-02T03:04:05Z"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !token.Expiry.Equal(expiry) {
+		t.Errorf("expiry = %v, want %v", token.Expiry, expiry)
+	}
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// manualRedirectURI is the redirect URI of LoginManual. Nothing listens
+// there: the browser fails to load it, and the user copies the URL, which
+// carries the authorization code, from the address bar.
+const manualRedirectURI = "http://127.0.0.1/"
+
+// LoginManual performs the authorization-code flow without a local
+// callback server, for machines whose browser cannot reach this one, e.g.
+// over SSH or in a container. It prints the consent URL, then reads the
+// redirected URL (or just the code) with readInput, and exchanges the code
+// for a token. Like Login, it uses a random state and PKCE; a pasted URL
+// must carry the expected state. Scopes are requested as by Login.
+func LoginManual(ctx context.Context, clientID, clientSecret string, scopes []string, readInput func() (string, error)) (*oauth2.Token, error) {
+	cfg := scopedConfig(clientID, clientSecret, scopes)
+	cfg.RedirectURL = manualRedirectURI
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+	authURL := cfg.AuthCodeURL(state, oauth2.AccessTypeOffline, includeGrantedScopes, oauth2.S256ChallengeOption(verifier))
+
+	fmt.Println("Open this URL in a browser on any machine and grant access:")
+	fmt.Printf("\n%s\n\n", authURL)
+	fmt.Printf("The browser is then sent to %s, which will fail to load.\n", manualRedirectURI)
+	fmt.Print("Paste the full URL from its address bar (or just the code) here: ")
+
+	input, err := readInput()
+	if err != nil {
+		return nil, fmt.Errorf("reading authorization response: %w", err)
+	}
+	code, err := parseAuthResponse(strings.TrimSpace(input), state)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchanging authorization code: %w", err)
+	}
+	return token, nil
+}
+
+// parseAuthResponse extracts the authorization code from what the user
+// pasted: the redirect URL, its query string, or the bare code. A URL or
+// query string must carry state; only a bare code skips the check.
+func parseAuthResponse(input, state string) (string, error) {
+	if input == "" {
+		return "", errors.New("no authorization code entered")
+	}
+	if !strings.Contains(input, "code=") && !strings.Contains(input, "error=") {
+		return input, nil
+	}
+
+	raw := input
+	if i := strings.Index(raw, "?"); i >= 0 {
+		raw = raw[i+1:]
+	}
+	query, err := url.ParseQuery(raw)
+	if err != nil {
+		return "", fmt.Errorf("parsing pasted URL: %w", err)
+	}
+	if errMsg := query.Get("error"); errMsg != "" {
+		return "", fmt.Errorf("OAuth callback error: %s", errMsg)
+	}
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+		return "", errors.New("OAuth callback error: state mismatch; the URL does not come from this login attempt")
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", errors.New("no authorization code in the pasted URL")
+	}
+	return code, nil
+}
+
+// LoginDevice performs the OAuth2 device authorization flow: it prints a
+// verification URL and a user code to enter there from any device, then
+// polls until access is granted or denied, ctx is done, or the code
+// expires.
+//
+// Google only offers the flow to OAuth clients of the "TVs and Limited
+// Input devices" type, and only for some scopes; other clients get an
+// error suggesting LoginManual. It requests scopes, or Scopes if it is
+// empty; the device flow cannot add to earlier grants, so scopes should
+// include everything the token needs.
+func LoginDevice(ctx context.Context, clientID, clientSecret string, scopes []string) (*oauth2.Token, error) {
+	cfg := scopedConfig(clientID, clientSecret, scopes)
+
+	da, err := cfg.DeviceAuth(ctx)
+	if err != nil {
+		var rerr *oauth2.RetrieveError
+		if errors.As(err, &rerr) && (rerr.ErrorCode == "invalid_client" || rerr.ErrorCode == "unauthorized_client" || rerr.ErrorCode == "invalid_scope") {
+			return nil, fmt.Errorf("this OAuth client cannot use the device flow (use --no-browser instead): %w", err)
+		}
+		return nil, fmt.Errorf("starting device authorization: %w", err)
+	}
+
+	fmt.Printf("On any device, open %s\n", da.VerificationURI)
+	fmt.Printf("and enter the code: %s\n", da.UserCode)
+	fmt.Println("Waiting for authorization...")
+
+	token, err := cfg.DeviceAccessToken(ctx, da)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for device authorization: %w", err)
+	}
+	return token, nil
+}
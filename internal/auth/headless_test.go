@@ -0,0 +1,28 @@
+package auth
+
+import "testing"
+
+func TestParseAuthResponse(t *testing.T) {
+	const state = "s3cret"
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"4/0Abc", "4/0Abc", true},
+		{"http://localhost/?state=s3cret&code=4/0Abc&scope=x", "4/0Abc", true},
+		{"state=s3cret&code=4/0Abc", "4/0Abc", true},
+		{"http://localhost/?state=other&code=4/0Abc", "", false},
+		{"http://localhost/?code=4/0Abc", "", false},
+		{"code=4/0Abc", "", false},
+		{"http://localhost/?state=s3cret&error=access_denied", "", false},
+		{"http://localhost/?state=s3cret&code=", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, err := parseAuthResponse(tt.input, state)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("parseAuthResponse(%q) = %q, %v; want %q, ok %v", tt.input, got, err, tt.want, tt.ok)
+		}
+	}
+}
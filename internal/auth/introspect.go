@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenInfoURL is Google's token introspection endpoint.
+const TokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+// TokenInfo is what Google's token introspection reports about an access
+// token.
+type TokenInfo struct {
+	// Subject is the ID of the Google account the token was issued to,
+	// which is also its Chat user ID.
+	Subject string `json:"sub"`
+	// Email is only reported for tokens with the userinfo.email scope.
+	Email string `json:"email"`
+	// Audience is the OAuth2 client the token was issued to.
+	Audience string `json:"aud"`
+	// Scope is the space-separated list of granted scopes.
+	Scope string `json:"scope"`
+	// ExpiresIn is the remaining lifetime of the token in seconds.
+	ExpiresIn string `json:"expires_in"`
+}
+
+// User returns the Chat resource name of the token's account,
+// "users/{id}", or "" if the token is not tied to a Google account.
+func (ti *TokenInfo) User() string {
+	if ti.Subject == "" {
+		return ""
+	}
+	return "users/" + ti.Subject
+}
+
+// Scopes returns the granted scopes.
+func (ti *TokenInfo) Scopes() []string {
+	return strings.Fields(ti.Scope)
+}
+
+// Introspect asks Google about accessToken: whose it is and which scopes
+// it carries. The request goes through the client set on ctx with
+// WithHTTPClient, if any.
+func Introspect(ctx context.Context, accessToken string) (*TokenInfo, error) {
+	client := http.DefaultClient
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && c != nil {
+		client = c
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, TokenInfoURL,
+		strings.NewReader(url.Values{"access_token": {accessToken}}.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("introspecting token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspecting token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("introspecting token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &e) == nil && e.Description != "" {
+			return nil, fmt.Errorf("introspecting token: %s (%s)", e.Description, resp.Status)
+		}
+		return nil, fmt.Errorf("introspecting token: %s", resp.Status)
+	}
+
+	var info TokenInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("parsing token introspection: %w", err)
+	}
+	return &info, nil
+}
@@ -0,0 +1,11 @@
+//go:build !unix && !windows
+
+package auth
+
+import "os"
+
+// lockFile is a no-op on platforms without file locking.
+func lockFile(f *os.File) error { return nil }
+
+// unlockFile is a no-op on platforms without file locking.
+func unlockFile(f *os.File) error { return nil }
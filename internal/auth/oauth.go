@@ -2,20 +2,29 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os/exec"
 	"runtime"
+	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
+// ScopePrefix is the common prefix of the Google Chat API OAuth2 scopes,
+// which are often written without it, e.g. "chat.messages".
+const ScopePrefix = "https://www.googleapis.com/auth/"
+
 // Scopes contains the Google Chat API OAuth2 scopes requested during user
-// authentication. These are the scopes that work with the standard OAuth2
-// consent flow for desktop applications.
+// authentication, plus the email scope for 'auth whoami'. These are the
+// scopes that work with the standard OAuth2 consent flow for desktop
+// applications.
 var Scopes = []string{
 	"https://www.googleapis.com/auth/chat.spaces",
 	"https://www.googleapis.com/auth/chat.spaces.readonly",
@@ -33,6 +42,8 @@ var Scopes = []string{
 	"https://www.googleapis.com/auth/chat.users.readstate",
 	"https://www.googleapis.com/auth/chat.users.readstate.readonly",
 	"https://www.googleapis.com/auth/chat.users.spacesettings",
+	// Lets token introspection report the account's email address.
+	"https://www.googleapis.com/auth/userinfo.email",
 }
 
 // RestrictedScopes contains scopes that require special access such as
@@ -98,68 +109,116 @@ func ValidateCredentials(clientID, clientSecret string) error {
 	return nil
 }
 
-// redirectURI is the local callback address used during the OAuth2 flow.
-const redirectURI = "http://localhost:8085"
-
 // GetOAuthConfig creates an OAuth2 configuration for the Google Chat API
-// using the provided client credentials.
+// using the provided client credentials. Login fills in the RedirectURL.
 func GetOAuthConfig(clientID, clientSecret string) *oauth2.Config {
 	return &oauth2.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		Endpoint:     google.Endpoint,
-		RedirectURL:  redirectURI,
 		Scopes:       Scopes,
 	}
 }
 
-// Login performs the full interactive OAuth2 authorization-code flow.
-// It starts a local HTTP server on localhost:8085 to receive the callback,
-// opens the user's browser to the consent screen, waits for the authorization
-// code, exchanges it for a token, and returns the resulting token.
-func Login(clientID, clientSecret string) (*oauth2.Token, error) {
+// scopedConfig is GetOAuthConfig requesting scopes, or Scopes if it is
+// empty.
+func scopedConfig(clientID, clientSecret string, scopes []string) *oauth2.Config {
 	cfg := GetOAuthConfig(clientID, clientSecret)
+	if len(scopes) > 0 {
+		cfg.Scopes = scopes
+	}
+	return cfg
+}
+
+// includeGrantedScopes makes the authorization server add the scopes the
+// user granted before to the new token (incremental authorization).
+var includeGrantedScopes = oauth2.SetAuthURLParam("include_granted_scopes", "true")
+
+// callbackTimeout is how long Login waits for the browser to come back to
+// the loopback redirect before giving up.
+const callbackTimeout = 5 * time.Minute
+
+// Login performs the full interactive OAuth2 authorization-code flow.
+// It starts a local HTTP server on an ephemeral loopback port to receive
+// the callback, opens the user's browser to the consent screen, waits for
+// the authorization code, exchanges it for a token, and returns the
+// resulting token. Login gives up when ctx is done, e.g. when the user
+// presses Ctrl-C, or when no callback arrives within callbackTimeout. The
+// token exchange goes through the client set on ctx with WithHTTPClient, if
+// any.
+//
+// The request carries a random state, which the callback must echo, and a
+// PKCE (S256) code challenge, so that an authorization code injected into
+// the callback or intercepted on its way cannot be redeemed.
+//
+// Login requests scopes, or Scopes if it is empty. Scopes granted earlier
+// are kept (include_granted_scopes), so asking for a single scope adds it
+// to an existing login.
+func Login(ctx context.Context, clientID, clientSecret string, scopes []string) (*oauth2.Token, error) {
+	cfg := scopedConfig(clientID, clientSecret, scopes)
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	// Bind the listener before opening the browser. Port 0 lets the OS pick
+	// a free port; Google accepts any port on a loopback redirect URI.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("starting local HTTP server: %w", err)
+	}
+	cfg.RedirectURL = fmt.Sprintf("http://%s/", listener.Addr())
 
 	// Generate the authorization URL requesting offline access so that a
 	// refresh token is included in the response.
-	authURL := cfg.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	authURL := cfg.AuthCodeURL(state, oauth2.AccessTypeOffline, includeGrantedScopes, oauth2.S256ChallengeOption(verifier))
 
 	// Channel to receive the authorization code (or an error) from the
-	// callback handler.
+	// callback handler. Only the first callback with the right state is
+	// delivered.
 	type callbackResult struct {
 		code string
 		err  error
 	}
 	resultCh := make(chan callbackResult, 1)
+	deliver := func(res callbackResult) {
+		select {
+		case resultCh <- res:
+		default:
+		}
+	}
 
 	// Set up a temporary HTTP server to handle the OAuth2 redirect.
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		code := r.URL.Query().Get("code")
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		// A request without the right state did not come from this login
+		// attempt; turn it away and keep waiting for the real callback.
+		if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			http.Error(w, "Authentication failed: state mismatch", http.StatusBadRequest)
+			return
+		}
+
+		code := query.Get("code")
 		if code == "" {
-			errMsg := r.URL.Query().Get("error")
+			errMsg := query.Get("error")
 			if errMsg == "" {
 				errMsg = "no authorization code received"
 			}
 			http.Error(w, "Authentication failed: "+errMsg, http.StatusBadRequest)
-			resultCh <- callbackResult{err: fmt.Errorf("OAuth callback error: %s", errMsg)}
+			deliver(callbackResult{err: fmt.Errorf("OAuth callback error: %s", errMsg)})
 			return
 		}
 
 		// Show a success page in the browser.
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		fmt.Fprint(w, "<html><body><h1>Authentication successful!</h1><p>You may close this window and return to the terminal.</p></body></html>")
-		resultCh <- callbackResult{code: code}
+		deliver(callbackResult{code: code})
 	})
 
-	// Bind the listener before opening the browser so we know the port is
-	// available.
-	listener, err := net.Listen("tcp", "localhost:8085")
-	if err != nil {
-		return nil, fmt.Errorf("starting local HTTP server: %w", err)
-	}
-
-	server := &http.Server{Handler: mux}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
 	go func() {
 		_ = server.Serve(listener)
 	}()
@@ -171,8 +230,18 @@ func Login(clientID, clientSecret string) (*oauth2.Token, error) {
 		fmt.Printf("Warning: could not open browser automatically: %v\n", err)
 	}
 
-	// Block until the callback delivers a result.
-	res := <-resultCh
+	// Block until the callback delivers a result, the caller gives up or
+	// the callback times out.
+	timer := time.NewTimer(callbackTimeout)
+	defer timer.Stop()
+	var res callbackResult
+	select {
+	case res = <-resultCh:
+	case <-ctx.Done():
+		res.err = ctx.Err()
+	case <-timer.C:
+		res.err = fmt.Errorf("no response from the browser within %s", callbackTimeout)
+	}
 
 	// Shut down the temporary server; ignore errors since we only care about
 	// the token exchange at this point.
@@ -182,8 +251,9 @@ func Login(clientID, clientSecret string) (*oauth2.Token, error) {
 		return nil, res.err
 	}
 
-	// Exchange the authorization code for a token.
-	token, err := cfg.Exchange(context.Background(), res.code)
+	// Exchange the authorization code for a token, proving possession of
+	// the PKCE verifier.
+	token, err := cfg.Exchange(ctx, res.code, oauth2.VerifierOption(verifier))
 	if err != nil {
 		return nil, fmt.Errorf("exchanging authorization code: %w", err)
 	}
@@ -191,6 +261,15 @@ func Login(clientID, clientSecret string) (*oauth2.Token, error) {
 	return token, nil
 }
 
+// randomState returns an unguessable OAuth2 state value.
+func randomState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating OAuth state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 // RefreshToken uses the refresh token embedded in the provided token to obtain
 // a new access token from Google's token endpoint.
 func RefreshToken(clientID, clientSecret string, token *oauth2.Token) (*oauth2.Token, error) {
@@ -213,9 +292,17 @@ func TokenSource(clientID, clientSecret string, token *oauth2.Token) oauth2.Toke
 
 // HTTPClient returns an *http.Client that automatically attaches OAuth2
 // credentials to every outgoing request and refreshes the token as needed.
-func HTTPClient(clientID, clientSecret string, token *oauth2.Token) *http.Client {
-	cfg := GetOAuthConfig(clientID, clientSecret)
-	return cfg.Client(context.Background(), token)
+// Refreshed tokens are saved back to store, so that later invocations
+// reuse them instead of refreshing again. API requests and token refreshes
+// go through the client set on ctx with WithHTTPClient, if any.
+func HTTPClient(ctx context.Context, clientID, clientSecret string, store TokenStore, token *StoredToken) *http.Client {
+	src := &persistingSource{
+		ctx:    ctx,
+		cfg:    GetOAuthConfig(clientID, clientSecret),
+		store:  store,
+		stored: *token,
+	}
+	return oauth2.NewClient(ctx, src)
 }
 
 // openBrowser attempts to open the given URL in the user's default browser.
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// RevokeURL is Google's OAuth2 token revocation endpoint.
+const RevokeURL = "https://oauth2.googleapis.com/revoke"
+
+// Revoke asks Google to revoke token, an access or refresh token.
+// Revoking a refresh token also revokes the access tokens issued with it
+// and withdraws the consent the user gave, so that a copy of the token
+// left anywhere stops working. A token that Google reports as already
+// invalid, e.g. because it was revoked before or has expired, counts as
+// revoked. The request goes through the client set on ctx with
+// WithHTTPClient, if any.
+func Revoke(ctx context.Context, token string) error {
+	client := http.DefaultClient
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && c != nil {
+		client = c
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, RevokeURL,
+		strings.NewReader(url.Values{"token": {token}}.Encode()))
+	if err != nil {
+		return fmt.Errorf("revoking token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("revoking token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	var e struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if json.Unmarshal(body, &e) == nil {
+		if e.Error == "invalid_token" {
+			return nil
+		}
+		if e.Description != "" {
+			return fmt.Errorf("revoking token: %s (%s)", e.Description, resp.Status)
+		}
+	}
+	return fmt.Errorf("revoking token: %s", resp.Status)
+}
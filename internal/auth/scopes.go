@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// ScopeBundles are the named sets of scopes that 'auth login --scopes'
+// accepts. "default" is what a plain login requests; the others hold the
+// RestrictedScopes, which need admin privileges or Google's approval and
+// are only requested on demand.
+var ScopeBundles = map[string][]string{
+	"default": Scopes,
+	"admin": {
+		ScopePrefix + "chat.admin.spaces",
+		ScopePrefix + "chat.admin.spaces.readonly",
+		ScopePrefix + "chat.admin.memberships",
+		ScopePrefix + "chat.admin.memberships.readonly",
+		ScopePrefix + "chat.admin.delete",
+	},
+	"import": {ScopePrefix + "chat.import"},
+	"delete": {ScopePrefix + "chat.delete"},
+	"apps":   {ScopePrefix + "chat.memberships.app"},
+	// email is part of default since 'auth whoami'; logins from before
+	// can add it.
+	"email": {ScopePrefix + "userinfo.email"},
+}
+
+// ResolveScopes expands names, each a bundle from ScopeBundles or a single
+// scope written in full or without ScopePrefix ("chat.messages"), into a
+// list of full scopes without duplicates.
+func ResolveScopes(names []string) ([]string, error) {
+	var scopes []string
+	add := func(scope string) {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+		case ScopeBundles[name] != nil:
+			for _, scope := range ScopeBundles[name] {
+				add(scope)
+			}
+		case strings.HasPrefix(name, ScopePrefix):
+			add(name)
+		case strings.HasPrefix(name, "chat."):
+			add(ScopePrefix + name)
+		default:
+			return nil, fmt.Errorf("unknown scope bundle %q (want %s, or a scope such as chat.messages)", name, strings.Join(slices.Sorted(maps.Keys(ScopeBundles)), ", "))
+		}
+	}
+	return scopes, nil
+}
+
+// GrantedScopes returns the scopes that the authorization server reported
+// granting with token, or nil if the response did not say.
+func GrantedScopes(token *oauth2.Token) []string {
+	scope, _ := token.Extra("scope").(string)
+	return strings.Fields(scope)
+}
+
+// MissingScopes returns the scopes in required that are not in granted.
+func MissingScopes(required, granted []string) []string {
+	var missing []string
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// ShortScope returns scope without ScopePrefix, for display.
+func ShortScope(scope string) string {
+	return strings.TrimPrefix(scope, ScopePrefix)
+}
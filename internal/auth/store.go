@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -19,52 +23,322 @@ func DefaultTokenPath() string {
 	return filepath.Join(home, ".config", "gogchat", "token.json")
 }
 
-// SaveToken serialises the given OAuth2 token as JSON and writes it to the
-// specified path. Parent directories are created automatically. The file is
-// written with 0600 permissions so that only the current user can read it.
-func SaveToken(path string, token *oauth2.Token) error {
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0o700); err != nil {
-		return fmt.Errorf("creating token directory %s: %w", dir, err)
+// Login flows recorded in StoredToken.Flow.
+const (
+	// FlowBrowser is the authorization-code flow with a loopback redirect
+	// to a local server (Login).
+	FlowBrowser = "browser"
+	// FlowManual is the authorization-code flow where the user pastes the
+	// redirect URL back into the terminal (LoginManual).
+	FlowManual = "no-browser"
+	// FlowDevice is the OAuth2 device authorization flow (LoginDevice).
+	FlowDevice = "device"
+)
+
+// StoredToken is the content of a token store: the OAuth2 token together
+// with metadata about how it was obtained. Token files written before the
+// metadata existed load with empty metadata.
+type StoredToken struct {
+	oauth2.Token
+
+	// Flow is the login flow that produced the token, e.g. FlowBrowser.
+	Flow string `json:"flow,omitempty"`
+	// Refreshed is when the access token was last refreshed, or zero if it
+	// is still the one issued at login.
+	Refreshed time.Time `json:"refreshed,omitzero"`
+	// Scopes are the scopes granted with the token, as reported by the
+	// authorization server, or nil if unknown.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// User ("users/{id}") and Email identify the account the token
+	// belongs to, as resolved by token introspection at Resolved; see
+	// Introspect. They are empty until resolved, and Email stays empty
+	// without the userinfo.email scope.
+	User     string    `json:"user,omitempty"`
+	Email    string    `json:"email,omitempty"`
+	Resolved time.Time `json:"resolved,omitzero"`
+}
+
+// TokenStore keeps the token of one profile. Load, Save and Delete do not
+// lock: callers that may race with other gogchat processes hold Lock
+// around them, or use SaveToken and DeleteToken.
+type TokenStore interface {
+	// Load returns the stored token.
+	Load() (*StoredToken, error)
+	// Save replaces the stored token. A concurrent Load sees either the old
+	// or the new token, never a mix.
+	Save(token *StoredToken) error
+	// Delete removes the stored token.
+	Delete() error
+	// Exists reports whether a token is stored.
+	Exists() bool
+	// Lock takes an exclusive lock on the store, shared with other
+	// processes, and returns the function that releases it.
+	Lock() (unlock func(), err error)
+	// String describes the store for humans, e.g. "file /path/token.json".
+	String() string
+}
+
+// Token store kinds, selected with StoreOptions.Kind.
+const (
+	StoreFile          = "file"
+	StoreEncryptedFile = "encrypted-file"
+	StoreCommand       = "command"
+)
+
+// StoreOptions selects and configures a TokenStore.
+type StoreOptions struct {
+	// Kind is StoreFile (the default), StoreEncryptedFile or StoreCommand.
+	Kind string
+	// Path is the token file. Every kind locks Path + ".lock".
+	Path string
+
+	// KeyFile is the file whose contents are the secret of an encrypted
+	// store. Without it, Passphrase is used.
+	KeyFile    string
+	Passphrase string
+
+	// ReadCommand prints the token of a command store on stdout;
+	// WriteCommand reads a new token on stdin and DeleteCommand removes
+	// it. Commands run through the shell. Without WriteCommand the store
+	// is read-only.
+	ReadCommand   string
+	WriteCommand  string
+	DeleteCommand string
+}
+
+// NewTokenStore returns the TokenStore described by opts.
+func NewTokenStore(opts StoreOptions) (TokenStore, error) {
+	switch opts.Kind {
+	case "", StoreFile:
+		return &FileStore{Path: opts.Path}, nil
+	case StoreEncryptedFile:
+		var secret []byte
+		switch {
+		case opts.KeyFile != "":
+			key, err := os.ReadFile(opts.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading token key file: %w", err)
+			}
+			secret = key
+		case opts.Passphrase != "":
+			secret = []byte(opts.Passphrase)
+		default:
+			return nil, errors.New("the encrypted-file token store needs a key file (token_key_file) or a passphrase (GOGCHAT_TOKEN_PASSPHRASE)")
+		}
+		return &EncryptedFileStore{Path: opts.Path, Secret: secret}, nil
+	case StoreCommand:
+		if opts.ReadCommand == "" {
+			return nil, errors.New("the command token store needs a read command (token_command_read)")
+		}
+		return &CommandStore{
+			Path:          opts.Path,
+			ReadCommand:   opts.ReadCommand,
+			WriteCommand:  opts.WriteCommand,
+			DeleteCommand: opts.DeleteCommand,
+		}, nil
 	}
+	return nil, fmt.Errorf("unknown token store %q (want %s, %s or %s)", opts.Kind, StoreFile, StoreEncryptedFile, StoreCommand)
+}
 
-	data, err := json.MarshalIndent(token, "", "  ")
+// SaveToken saves token to store while holding the store's lock.
+func SaveToken(store TokenStore, token *StoredToken) error {
+	unlock, err := store.Lock()
 	if err != nil {
-		return fmt.Errorf("marshalling token: %w", err)
+		return err
 	}
+	defer unlock()
+	return store.Save(token)
+}
 
-	if err := os.WriteFile(path, data, 0o600); err != nil {
-		return fmt.Errorf("writing token file %s: %w", path, err)
+// DeleteToken deletes the token in store while holding the store's lock.
+func DeleteToken(store TokenStore) error {
+	unlock, err := store.Lock()
+	if err != nil {
+		return err
 	}
+	defer unlock()
+	return store.Delete()
+}
 
+// CanDelete returns the error that deleting the token of store would fail
+// with for lack of configuration, or nil. It lets a caller find out before
+// doing something that cannot be undone, like revoking the token.
+func CanDelete(store TokenStore) error {
+	if s, ok := store.(*CommandStore); ok && s.DeleteCommand == "" {
+		return errNoDeleteCommand
+	}
 	return nil
 }
 
-// LoadToken reads an OAuth2 token from the JSON file at the given path.
-func LoadToken(path string) (*oauth2.Token, error) {
-	data, err := os.ReadFile(path)
+// FileStore keeps the token as plain JSON in a file readable only by the
+// current user.
+type FileStore struct {
+	Path string
+}
+
+// Load reads the token from the file.
+func (s *FileStore) Load() (*StoredToken, error) {
+	data, err := os.ReadFile(s.Path)
 	if err != nil {
-		return nil, fmt.Errorf("reading token file %s: %w", path, err)
+		return nil, fmt.Errorf("reading token file %s: %w", s.Path, err)
 	}
 
-	var token oauth2.Token
+	var token StoredToken
 	if err := json.Unmarshal(data, &token); err != nil {
-		return nil, fmt.Errorf("parsing token file %s: %w", path, err)
+		return nil, fmt.Errorf("parsing token file %s: %w", s.Path, err)
 	}
 
 	return &token, nil
 }
 
-// DeleteToken removes the token file at the given path.
-func DeleteToken(path string) error {
-	if err := os.Remove(path); err != nil {
-		return fmt.Errorf("removing token file %s: %w", path, err)
+// Save writes the token to the file, replacing it atomically. Parent
+// directories are created automatically.
+func (s *FileStore) Save(token *StoredToken) error {
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling token: %w", err)
+	}
+	return writeTokenFile(s.Path, data)
+}
+
+// Delete removes the file.
+func (s *FileStore) Delete() error {
+	if err := os.Remove(s.Path); err != nil {
+		return fmt.Errorf("removing token file %s: %w", s.Path, err)
 	}
 	return nil
 }
 
-// TokenExists reports whether a token file exists at the given path.
-func TokenExists(path string) bool {
-	_, err := os.Stat(path)
+// Exists reports whether the file exists.
+func (s *FileStore) Exists() bool {
+	_, err := os.Stat(s.Path)
 	return err == nil
 }
+
+// Lock locks the file's companion ".lock" file.
+func (s *FileStore) Lock() (func(), error) {
+	return lockToken(s.Path)
+}
+
+func (s *FileStore) String() string {
+	return "file " + s.Path
+}
+
+// writeTokenFile writes data to path with 0600 permissions, creating parent
+// directories as needed. The file is replaced atomically, so that a
+// concurrent reader never sees a partially written token.
+func writeTokenFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating token directory %s: %w", dir, err)
+	}
+
+	// CreateTemp creates the file with 0600 permissions.
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("writing token file %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing token file %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing token file %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing token file %s: %w", path, err)
+	}
+	return nil
+}
+
+// persistingSource is an oauth2.TokenSource that refreshes the token when
+// it expires and writes the new token back to the token store.
+//
+// Refreshes are serialised across processes with the store's lock: a
+// process that finds the token expired takes the lock, then re-reads the
+// store, and only refreshes if no other process has done so while it
+// waited. Parallel invocations thus refresh once and share the result.
+type persistingSource struct {
+	ctx   context.Context
+	cfg   *oauth2.Config
+	store TokenStore
+
+	mu     sync.Mutex
+	stored StoredToken
+}
+
+// Token returns a valid token, refreshing it if needed.
+func (s *persistingSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stored.Valid() {
+		return &s.stored.Token, nil
+	}
+
+	unlock, err := s.store.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if saved, err := s.store.Load(); err == nil && saved.Valid() {
+		s.stored = *saved
+		return &s.stored.Token, nil
+	}
+
+	if err := refresh(s.ctx, s.cfg, &s.stored); err != nil {
+		return nil, err
+	}
+	// The refreshed token is still good for this process, so failing to
+	// save it is only worth a warning.
+	if err := s.store.Save(&s.stored); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save refreshed token: %v\n", err)
+	}
+	return &s.stored.Token, nil
+}
+
+// refresh replaces the access token of stored with a new one obtained with
+// its refresh token, whether or not the current one has expired.
+func refresh(ctx context.Context, cfg *oauth2.Config, stored *StoredToken) error {
+	token, err := cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: stored.RefreshToken}).Token()
+	if err != nil {
+		return err
+	}
+	stored.Token = *token
+	stored.Refreshed = time.Now()
+	if scopes := GrantedScopes(token); scopes != nil {
+		stored.Scopes = scopes
+	}
+	return nil
+}
+
+// ForceRefresh refreshes the token in store even if it is still valid,
+// which proves that its refresh token works, and saves the result. It
+// holds the store's lock throughout, like the refreshes of HTTPClient. The
+// token request goes through the client set on ctx with WithHTTPClient, if
+// any.
+func ForceRefresh(ctx context.Context, clientID, clientSecret string, store TokenStore) (*StoredToken, error) {
+	unlock, err := store.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	stored, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if stored.RefreshToken == "" {
+		return nil, errors.New("the stored token has no refresh token")
+	}
+	if err := refresh(ctx, GetOAuthConfig(clientID, clientSecret), stored); err != nil {
+		return nil, fmt.Errorf("refreshing token: %w", err)
+	}
+	if err := store.Save(stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// CommandStore keeps the token in an external secret manager, reached
+// through shell commands, e.g. "pass show gogchat/token" or
+// "op read op://Private/gogchat/token". The token is exchanged as JSON on
+// the commands' stdout and stdin.
+type CommandStore struct {
+	// Path is the token file path whose ".lock" companion serialises
+	// gogchat processes; the token itself is never written there.
+	Path string
+
+	ReadCommand   string
+	WriteCommand  string
+	DeleteCommand string
+}
+
+// Load runs the read command and parses its output.
+func (s *CommandStore) Load() (*StoredToken, error) {
+	out, err := runShell(s.ReadCommand, nil, os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("reading token: %w", err)
+	}
+
+	var token StoredToken
+	if err := json.Unmarshal(out, &token); err != nil {
+		return nil, fmt.Errorf("parsing token from %q: %w", s.ReadCommand, err)
+	}
+	return &token, nil
+}
+
+// Save runs the write command with the token on its stdin.
+func (s *CommandStore) Save(token *StoredToken) error {
+	if s.WriteCommand == "" {
+		return errors.New("saving token: the command token store has no write command (token_command_write)")
+	}
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling token: %w", err)
+	}
+	if _, err := runShell(s.WriteCommand, data, os.Stderr); err != nil {
+		return fmt.Errorf("saving token: %w", err)
+	}
+	return nil
+}
+
+// errNoDeleteCommand is returned by a command store without a delete
+// command.
+var errNoDeleteCommand = errors.New("removing token: the command token store has no delete command (token_command_delete)")
+
+// Delete runs the delete command.
+func (s *CommandStore) Delete() error {
+	if s.DeleteCommand == "" {
+		return errNoDeleteCommand
+	}
+	if _, err := runShell(s.DeleteCommand, nil, os.Stderr); err != nil {
+		return fmt.Errorf("removing token: %w", err)
+	}
+	return nil
+}
+
+// Exists reports whether the read command succeeds. Its error output is
+// discarded, since a missing token is not an error here.
+func (s *CommandStore) Exists() bool {
+	_, err := runShell(s.ReadCommand, nil, io.Discard)
+	return err == nil
+}
+
+// Lock locks the ".lock" companion of Path.
+func (s *CommandStore) Lock() (func(), error) {
+	return lockToken(s.Path)
+}
+
+func (s *CommandStore) String() string {
+	return fmt.Sprintf("command %q", s.ReadCommand)
+}
+
+// runShell runs command through the platform shell with stdin as its
+// input and returns its stdout. The command's stderr goes to stderr, which
+// is normally os.Stderr so that a secret manager can prompt for its own
+// unlock.
+func runShell(command string, stdin []byte, stderr io.Writer) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running %q: %w", command, err)
+	}
+	return out, nil
+}
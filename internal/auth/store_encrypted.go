@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// encryptedFormat identifies the envelope written by EncryptedFileStore.
+const encryptedFormat = "gogchat-encrypted-token-v1"
+
+// kdfIterations is the PBKDF2-SHA256 work factor for new files. It is
+// stored in each file, so it can be raised without breaking old ones.
+const kdfIterations = 200_000
+
+// envelope is the on-disk form of an encrypted token. Binary fields are
+// base64-encoded by encoding/json.
+type envelope struct {
+	Format     string `json:"format"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedFileStore keeps the token in a file encrypted with AES-256-GCM,
+// under a key derived from Secret (a passphrase or the contents of a key
+// file) with PBKDF2-SHA256 and a random salt. Every save uses a new salt
+// and nonce.
+type EncryptedFileStore struct {
+	Path   string
+	Secret []byte
+}
+
+// Load reads and decrypts the token.
+func (s *EncryptedFileStore) Load() (*StoredToken, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("reading token file %s: %w", s.Path, err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil || env.Format != encryptedFormat {
+		return nil, fmt.Errorf("token file %s is not an encrypted token (run 'gogchat auth migrate-store' to convert it)", s.Path)
+	}
+	if env.KDF != "pbkdf2-sha256" {
+		return nil, fmt.Errorf("token file %s: unsupported key derivation %q", s.Path, env.KDF)
+	}
+
+	aead, err := s.aead(env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, env.Nonce, env.Ciphertext, []byte(encryptedFormat))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting token file %s: wrong passphrase or key file, or the file is damaged", s.Path)
+	}
+
+	var token StoredToken
+	if err := json.Unmarshal(plain, &token); err != nil {
+		return nil, fmt.Errorf("parsing token file %s: %w", s.Path, err)
+	}
+	return &token, nil
+}
+
+// Save encrypts the token and replaces the file atomically.
+func (s *EncryptedFileStore) Save(token *StoredToken) error {
+	plain, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("marshalling token: %w", err)
+	}
+
+	env := envelope{
+		Format:     encryptedFormat,
+		KDF:        "pbkdf2-sha256",
+		Iterations: kdfIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(env.Salt); err != nil {
+		return fmt.Errorf("encrypting token: %w", err)
+	}
+	aead, err := s.aead(env.Salt, env.Iterations)
+	if err != nil {
+		return err
+	}
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return fmt.Errorf("encrypting token: %w", err)
+	}
+	env.Ciphertext = aead.Seal(nil, env.Nonce, plain, []byte(encryptedFormat))
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling token: %w", err)
+	}
+	return writeTokenFile(s.Path, data)
+}
+
+// aead derives the file key from the secret and salt.
+func (s *EncryptedFileStore) aead(salt []byte, iterations int) (cipher.AEAD, error) {
+	if len(s.Secret) == 0 {
+		return nil, errors.New("no passphrase or key for the encrypted token store")
+	}
+	key, err := pbkdf2.Key(sha256.New, string(s.Secret), salt, iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("deriving token key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("deriving token key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Delete removes the file.
+func (s *EncryptedFileStore) Delete() error {
+	return (&FileStore{Path: s.Path}).Delete()
+}
+
+// Exists reports whether the file exists.
+func (s *EncryptedFileStore) Exists() bool {
+	return (&FileStore{Path: s.Path}).Exists()
+}
+
+// Lock locks the file's companion ".lock" file.
+func (s *EncryptedFileStore) Lock() (func(), error) {
+	return lockToken(s.Path)
+}
+
+func (s *EncryptedFileStore) String() string {
+	return "encrypted file " + s.Path
+}
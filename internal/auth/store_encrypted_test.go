@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestEncryptedFileStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := &EncryptedFileStore{Path: path, Secret: []byte("correct horse")}
+	want := &StoredToken{Token: oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}, Flow: FlowDevice}
+	if err := store.Save(want); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "refresh") {
+		t.Errorf("token file holds the refresh token in the clear:\n%s", data)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken || got.Flow != want.Flow {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+
+	wrong := &EncryptedFileStore{Path: path, Secret: []byte("battery staple")}
+	if _, err := wrong.Load(); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("Load() with the wrong passphrase: err = %v, want a wrong passphrase error", err)
+	}
+}
+
+func TestEncryptedFileStoreRejectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := &EncryptedFileStore{Path: path, Secret: []byte("correct horse")}
+	if err := store.Save(&StoredToken{Token: oauth2.Token{AccessToken: "access"}}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var orig envelope
+	if err := json.Unmarshal(data, &orig); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(*envelope)
+		want   string
+	}{
+		{"ciphertext", func(e *envelope) { e.Ciphertext[0] ^= 1 }, "damaged"},
+		{"nonce", func(e *envelope) { e.Nonce[0] ^= 1 }, "damaged"},
+		{"salt", func(e *envelope) { e.Salt[0] ^= 1 }, "damaged"},
+		{"iterations", func(e *envelope) { e.Iterations-- }, "damaged"},
+		{"kdf", func(e *envelope) { e.KDF = "md5" }, "unsupported key derivation"},
+		{"format", func(e *envelope) { e.Format = "" }, "not an encrypted token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := orig
+			env.Salt = append([]byte(nil), orig.Salt...)
+			env.Nonce = append([]byte(nil), orig.Nonce...)
+			env.Ciphertext = append([]byte(nil), orig.Ciphertext...)
+			tt.tamper(&env)
+			data, err := json.Marshal(env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.Load(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
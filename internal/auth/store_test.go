@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestPersistingSourceRefreshesOnce(t *testing.T) {
+	var refreshes atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refreshes.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"access_token":"fresh","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer srv.Close()
+
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "token.json")}
+	expired := StoredToken{Token: oauth2.Token{AccessToken: "stale", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)}}
+	if err := store.Save(&expired); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &oauth2.Config{ClientID: "id", Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+
+	// Two sources over one store stand in for two gogchat processes that
+	// loaded the same expired token.
+	var wg sync.WaitGroup
+	for range 2 {
+		src := &persistingSource{ctx: context.Background(), cfg: cfg, store: store, stored: expired}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := src.Token()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if token.AccessToken != "fresh" {
+				t.Errorf("Token() = %q, want the refreshed token", token.AccessToken)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("token refreshed %d times, want once", n)
+	}
+	saved, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.AccessToken != "fresh" || saved.Refreshed.IsZero() {
+		t.Errorf("stored token = %q refreshed at %v, want the refreshed token", saved.AccessToken, saved.Refreshed)
+	}
+}
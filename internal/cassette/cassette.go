@@ -0,0 +1,190 @@
+// Package cassette records the HTTP traffic of a gogchat session to a file
+// and replays it later without network access or credentials.
+//
+// A cassette is a JSON file holding every request/response pair in the
+// order it happened. Credentials are scrubbed before anything is written,
+// so cassettes can be attached to bug reports or checked in as fixtures.
+package cassette
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// Version is the cassette file format version.
+const Version = 1
+
+// maxBodySize is the number of bytes of each request and response body kept
+// in a cassette. Longer bodies, typically media, are truncated, so their
+// replay is cut short as well.
+const maxBodySize = 1 << 20
+
+// redacted replaces the value of every scrubbed header or query parameter.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never written to a cassette.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Goog-Api-Key",
+}
+
+// sensitiveParams are query parameters that carry credentials.
+var sensitiveParams = []string{"access_token", "key"}
+
+// Cassette is the on-disk representation of a recorded session.
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+	// BodySize is the length of the original body when Body was
+	// truncated, and zero otherwise.
+	BodySize int64 `json:"bodySize,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+	// BodySize is the length of the original body when Body was
+	// truncated, and zero otherwise.
+	BodySize int64 `json:"bodySize,omitempty"`
+}
+
+// Body is a request or response payload. Text is stored as-is so that
+// cassettes stay readable; binary data (e.g. media downloads) is stored as
+// base64.
+type Body []byte
+
+// MarshalJSON encodes b as a string, or as {"base64": "..."} when it is not
+// valid UTF-8.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(struct {
+		Base64 string `json:"base64"`
+	}{base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON decodes either form written by MarshalJSON.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+	var enc struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return fmt.Errorf("body must be a string or {\"base64\": ...}: %w", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(enc.Base64)
+	if err != nil {
+		return fmt.Errorf("decoding base64 body: %w", err)
+	}
+	*b = raw
+	return nil
+}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	if c.Version != Version {
+		return nil, fmt.Errorf("cassette %s has unsupported version %d (want %d)", path, c.Version, Version)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path. The file is replaced atomically so an
+// interrupted session never leaves a truncated cassette behind.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling cassette: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("writing cassette %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing cassette %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing cassette %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing cassette %s: %w", path, err)
+	}
+	return nil
+}
+
+// scrubHeader returns a copy of h with credentials replaced by a marker.
+func scrubHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := out[name]; ok {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+// scrubURL returns rawURL with credential query parameters redacted.
+func scrubURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	changed := false
+	for _, p := range sensitiveParams {
+		if q.Has(p) {
+			q.Set(p, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return rawURL
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,165 @@
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// echoServer answers every request with its method, URL and body, or with
+// size bytes of binary data for /media.
+func echoServer(t *testing.T, size int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/media" {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(bytes.Repeat([]byte{0xff}, size))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Set-Cookie", "session=secret")
+		io.WriteString(w, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func do(t *testing.T, client *http.Client, method, url, body string) string {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading %s %s: %v", method, url, err)
+	}
+	return string(data)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := echoServer(t, 0)
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	rec := NewRecorder(path, nil)
+	client := &http.Client{Transport: rec}
+	first := do(t, client, "GET", srv.URL+"/v1/spaces?access_token=secret&pageSize=2", "")
+	second := do(t, client, "POST", srv.URL+"/v1/spaces", `{"displayName":"A"}`)
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Interactions) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(c.Interactions))
+	}
+	got := c.Interactions[1]
+	if string(got.Request.Body) != `{"displayName":"A"}` || string(got.Response.Body) != second {
+		t.Errorf("recorded bodies %q -> %q, want %q -> %q", got.Request.Body, got.Response.Body, `{"displayName":"A"}`, second)
+	}
+	for _, it := range c.Interactions {
+		if h := it.Request.Header.Get("Authorization"); h != redacted {
+			t.Errorf("Authorization recorded as %q", h)
+		}
+		if h := it.Response.Header.Get("Set-Cookie"); h != redacted {
+			t.Errorf("Set-Cookie recorded as %q", h)
+		}
+		if strings.Contains(it.Request.URL, "secret") {
+			t.Errorf("URL recorded as %q", it.Request.URL)
+		}
+	}
+
+	// The replay answers from the cassette, on any host.
+	client = &http.Client{Transport: NewReplayer(c)}
+	if got := do(t, client, "GET", "http://replay.invalid/v1/spaces?access_token=other&pageSize=2", ""); got != first {
+		t.Errorf("replayed %q, want %q", got, first)
+	}
+	if got := do(t, client, "POST", "http://replay.invalid/v1/spaces", "ignored"); got != second {
+		t.Errorf("replayed %q, want %q", got, second)
+	}
+	if _, err := client.Get("http://replay.invalid/v1/spaces?pageSize=2"); err == nil {
+		t.Error("replaying an interaction twice succeeded")
+	}
+}
+
+func TestRecorderTruncatesLargeBodies(t *testing.T) {
+	srv := echoServer(t, maxBodySize+100)
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	rec := NewRecorder(path, nil)
+	got := do(t, &http.Client{Transport: rec}, "GET", srv.URL+"/media", "")
+	if len(got) != maxBodySize+100 {
+		t.Errorf("client read %d bytes, want all %d", len(got), maxBodySize+100)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := c.Interactions[0].Response
+	if len(resp.Body) != maxBodySize || resp.BodySize != maxBodySize+100 {
+		t.Errorf("recorded %d of %d bytes, want %d of %d", len(resp.Body), resp.BodySize, maxBodySize, maxBodySize+100)
+	}
+}
+
+func TestRecorderWritesOnClose(t *testing.T) {
+	srv := echoServer(t, 0)
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	rec := NewRecorder(path, nil)
+	client := &http.Client{Transport: rec}
+	do(t, client, "GET", srv.URL+"/a", "")
+	if _, err := Load(path); err == nil {
+		t.Fatal("cassette written before Close")
+	}
+
+	// A response that is still being read is saved without its body.
+	resp, err := client.Get(srv.URL + "/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Interactions) != 2 || len(c.Interactions[0].Response.Body) == 0 || len(c.Interactions[1].Response.Body) != 0 {
+		t.Errorf("interactions = %+v, want /a with a body and /b without", c.Interactions)
+	}
+}
+
+func TestBodyJSON(t *testing.T) {
+	for _, body := range []Body{Body("plain text"), Body{0xff, 0x00, 0xfe}} {
+		data, err := body.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Body
+		if err := got.UnmarshalJSON(data); err != nil {
+			t.Fatalf("UnmarshalJSON(%s): %v", data, err)
+		}
+		if !bytes.Equal(got, body) {
+			t.Errorf("%q round-tripped through %s as %q", body, data, got)
+		}
+	}
+}
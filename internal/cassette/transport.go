@@ -0,0 +1,229 @@
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Recorder is an http.RoundTripper that forwards requests to another
+// transport and collects every completed exchange for a cassette file.
+// Bodies are copied as they stream through, up to maxBodySize bytes each,
+// and an exchange is complete once its response body has been read to the
+// end or closed. Nothing is written until Close.
+type Recorder struct {
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder that writes to path and sends requests
+// through next (http.DefaultTransport if nil). Any existing file at path is
+// overwritten when the recorder is closed.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{path: path, next: next, cassette: Cassette{Version: Version}}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody *capture
+	sent := req
+	if req.Body != nil && req.Body != http.NoBody {
+		reqBody = &capture{}
+		sent = req.WithContext(req.Context())
+		sent.Body = &teeBody{ReadCloser: req.Body, capture: reqBody}
+	}
+
+	resp, err := r.next.RoundTrip(sent)
+	if err != nil {
+		// Transport failures are not recorded: there is nothing a replay
+		// could faithfully reproduce.
+		return nil, err
+	}
+
+	// The interaction takes its place in the cassette now, so that
+	// exchanges are kept in the order they were answered; its bodies are
+	// filled in once the response body is done.
+	r.mu.Lock()
+	i := len(r.cassette.Interactions)
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    scrubURL(req.URL.String()),
+			Header: scrubHeader(req.Header),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     scrubHeader(resp.Header),
+		},
+	})
+	r.mu.Unlock()
+
+	respBody := &capture{}
+	var once sync.Once
+	resp.Body = &teeBody{ReadCloser: resp.Body, capture: respBody, done: func() {
+		once.Do(func() { r.complete(i, reqBody, respBody) })
+	}}
+	return resp, nil
+}
+
+// complete stores the captured bodies of interaction i.
+func (r *Recorder) complete(i int, reqBody, respBody *capture) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	it := &r.cassette.Interactions[i]
+	it.Request.Body, it.Request.BodySize = reqBody.body()
+	it.Response.Body, it.Response.BodySize = respBody.body()
+}
+
+// Close writes the recorded interactions to the cassette file. An exchange
+// whose response body is still open is written without its bodies.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// capture keeps the first maxBodySize bytes written to it and counts the
+// rest. The transport may still be sending a request body while the
+// response is read, so access is locked.
+type capture struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+	n   int64
+}
+
+func (c *capture) Write(p []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n += int64(len(p))
+	if room := maxBodySize - c.buf.Len(); room > 0 {
+		c.buf.Write(p[:min(len(p), room)])
+	}
+}
+
+// body returns a copy of the captured bytes and, if they are truncated, the
+// full body size. A nil capture has no body.
+func (c *capture) body() (Body, int64) {
+	if c == nil {
+		return nil, 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.n == 0 {
+		return nil, 0
+	}
+	data := Body(bytes.Clone(c.buf.Bytes()))
+	if c.n > int64(len(data)) {
+		return data, c.n
+	}
+	return data, 0
+}
+
+// teeBody copies everything read from a body into a capture. done, if set,
+// is called when the body reaches EOF, fails or is closed.
+type teeBody struct {
+	io.ReadCloser
+	capture *capture
+	done    func()
+}
+
+func (b *teeBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.capture.Write(p[:n])
+	if err != nil && b.done != nil {
+		b.done()
+	}
+	return n, err
+}
+
+func (b *teeBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.done != nil {
+		b.done()
+	}
+	return err
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette
+// instead of the network.
+//
+// A request is matched by method, path and query against the first
+// interaction that has not been replayed yet. Request bodies and headers
+// are ignored, since they may contain random multipart boundaries or
+// credentials. Repeated identical requests (e.g. retries) therefore replay
+// their recorded responses in the original order.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer serving the interactions in c.
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{
+		interactions: c.Interactions,
+		used:         make([]bool, len(c.Interactions)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	key := matchKey(req.Method, req.URL.String())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, it := range r.interactions {
+		if r.used[i] || matchKey(it.Request.Method, it.Request.URL) != key {
+			continue
+		}
+		r.used[i] = true
+
+		header := it.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        it.Response.Status,
+			StatusCode:    it.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(it.Response.Body)),
+			ContentLength: int64(len(it.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette has no unplayed interaction for %s", key)
+}
+
+// matchKey identifies a request for replay matching: its method plus the
+// path and query of its (scrubbed) URL. The host is ignored so that a
+// cassette recorded against one endpoint replays against another.
+func matchKey(method, rawURL string) string {
+	scrubbed := scrubURL(rawURL)
+	u, err := url.Parse(scrubbed)
+	if err != nil {
+		return method + " " + scrubbed
+	}
+	return method + " " + u.RequestURI()
+}
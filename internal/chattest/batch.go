@@ -0,0 +1,89 @@
+package chattest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+)
+
+// maxBatchSize is the largest number of calls one batch request may carry.
+const maxBatchSize = 1000
+
+// serveBatch answers POST /batch: a multipart/mixed body whose parts are
+// HTTP requests, each dispatched as if it had been sent on its own. The
+// reply is a multipart/mixed body of HTTP responses, with Content-IDs
+// derived from the request parts.
+func (s *Server) serveBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, newError(http.StatusMethodNotAllowed, "Batch requests must use POST."))
+		return
+	}
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		writeError(w, invalidArgument("Batch requests must be multipart/mixed."))
+		return
+	}
+
+	type item struct {
+		id  string
+		req *http.Request
+	}
+	var items []item
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeError(w, invalidArgument("Malformed batch body: %v", err))
+			return
+		}
+		if len(items) == maxBatchSize {
+			writeError(w, invalidArgument("A batch request may contain at most %d calls.", maxBatchSize))
+			return
+		}
+		raw, err := io.ReadAll(part)
+		if err != nil {
+			writeError(w, invalidArgument("Reading batch part: %v", err))
+			return
+		}
+		req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(raw)))
+		if err != nil {
+			writeError(w, invalidArgument("Batch part %d is not an HTTP request: %v", len(items), err))
+			return
+		}
+		items = append(items, item{id: part.Header.Get("Content-ID"), req: req.WithContext(r.Context())})
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, it := range items {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, it.req)
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/http")
+		if it.id != "" {
+			header.Set("Content-ID", "<response-"+strings.Trim(it.id, "<>")+">")
+		}
+		part, _ := mw.CreatePart(header)
+		resp := rec.Result()
+		fmt.Fprintf(part, "HTTP/1.1 %s\r\n", resp.Status)
+		resp.Header.Write(part)
+		fmt.Fprint(part, "\r\n")
+		io.Copy(part, resp.Body)
+	}
+	mw.Close()
+
+	w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,134 @@
+package chattest
+
+import (
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// emojiName matches valid custom emoji names such as ":party-parrot:".
+var emojiName = regexp.MustCompile(`^:[a-z0-9_-]{1,61}:$`)
+
+// AddCustomEmoji stores a custom emoji created by the caller and returns the
+// stored copy. The payload, if any, becomes its image.
+func (s *Server) AddCustomEmoji(emoji api.CustomEmoji) *api.CustomEmoji {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var image []byte
+	filename := "emoji.png"
+	if emoji.Payload != nil {
+		image, _ = base64.StdEncoding.DecodeString(emoji.Payload.FileContent)
+		filename = emoji.Payload.Filename
+	}
+	return clone(s.insertEmoji(&emoji, filename, image))
+}
+
+func (s *Server) insertEmoji(emoji *api.CustomEmoji, filename string, image []byte) *api.CustomEmoji {
+	if emoji.UID == "" {
+		emoji.UID = s.newID("EMJ")
+	}
+	emoji.Name = "customEmojis/" + emoji.UID
+	emoji.Payload = nil
+
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = "image/png"
+	}
+	s.media[emoji.Name] = &mediaBlob{filename: filename, contentType: contentType, data: image}
+	emoji.TemporaryImageURI = s.URL + "/v1/media/" + emoji.Name + "?alt=media"
+
+	s.emojis.put(emoji.Name, emoji)
+	s.emojiCreators[emoji.Name] = s.caller.Name
+	return emoji
+}
+
+// lookupEmoji finds a custom emoji by UID or by its ":name:".
+func (s *Server) lookupEmoji(id string) (*api.CustomEmoji, error) {
+	if strings.HasPrefix(id, ":") {
+		for _, e := range s.emojis.list("customEmojis/") {
+			if e.EmojiName == id {
+				return e, nil
+			}
+		}
+	} else if e, ok := s.emojis.get("customEmojis/" + id); ok {
+		return e, nil
+	}
+	return nil, notFound("Custom emoji customEmojis/%s not found.", id)
+}
+
+func (s *Server) listEmojis(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	f, err := parseFilter(r.URL.Query().Get("filter"), "creator")
+	if err != nil {
+		return nil, invalidArgument("Invalid filter: %v", err)
+	}
+	f.rewrite(s.aliasUsers)
+
+	var emojis []*api.CustomEmoji
+	for _, e := range s.emojis.list("customEmojis/") {
+		creator := s.emojiCreators[e.Name]
+		match := func(field, op, value string) (bool, bool) {
+			if field == "creator" {
+				return compare(creator, op, value), true
+			}
+			return false, false
+		}
+		if f.matches(e, match) {
+			emojis = append(emojis, e)
+		}
+	}
+
+	page, next, err := paginate(r, emojis, 25, 200)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListCustomEmojisResponse{CustomEmojis: page, NextPageToken: next}, nil
+}
+
+func (s *Server) createEmoji(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	var emoji api.CustomEmoji
+	if err := decodeBody(r, &emoji); err != nil {
+		return nil, err
+	}
+	if !emojiName.MatchString(emoji.EmojiName) {
+		return nil, invalidArgument("Invalid emojiName %q: it must be enclosed in colons and contain only lowercase letters, numbers, hyphens and underscores.", emoji.EmojiName)
+	}
+	if emoji.Name != "" || emoji.UID != "" {
+		return nil, invalidArgument("name and uid are assigned by the server.")
+	}
+	if emoji.Payload == nil || emoji.Payload.FileContent == "" || emoji.Payload.Filename == "" {
+		return nil, invalidArgument("payload.fileContent and payload.filename are required.")
+	}
+	image, err := base64.StdEncoding.DecodeString(emoji.Payload.FileContent)
+	if err != nil {
+		return nil, invalidArgument("payload.fileContent is not valid base64: %v", err)
+	}
+	if _, err := s.lookupEmoji(emoji.EmojiName); err == nil {
+		return nil, alreadyExists("Custom emoji %s already exists.", emoji.EmojiName)
+	}
+
+	return s.insertEmoji(&emoji, emoji.Payload.Filename, image), nil
+}
+
+func (s *Server) getEmoji(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	return s.lookupEmoji(vars[0])
+}
+
+func (s *Server) deleteEmoji(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	e, err := s.lookupEmoji(vars[0])
+	if err != nil {
+		return nil, err
+	}
+	if s.emojiCreators[e.Name] != s.caller.Name && !s.admin {
+		return nil, permissionDenied("Only the creator or an administrator can delete %s.", e.Name)
+	}
+	s.emojis.delete(e.Name)
+	delete(s.emojiCreators, e.Name)
+	delete(s.media, e.Name)
+	return &api.Empty{}, nil
+}
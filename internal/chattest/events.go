@@ -0,0 +1,79 @@
+package chattest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// eventRetention is how far back spaceEvents.list can look.
+const eventRetention = 28 * 24 * time.Hour
+
+func (s *Server) listEvents(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	sp, err := s.lookupSpace(vars[0], false)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := parseFilter(r.URL.Query().Get("filter"), "eventTypes", "startTime", "endTime")
+	if err != nil {
+		return nil, invalidArgument("Invalid filter: %v", err)
+	}
+	if !f.has("eventTypes") {
+		return nil, invalidArgument("The filter must specify at least one event type, e.g. event_types:\"google.workspace.chat.message.v1.created\".")
+	}
+
+	oldest := time.Now().Add(-eventRetention).UTC().Format(time.RFC3339Nano)
+	var events []*api.SpaceEvent
+	for _, ev := range s.events.list(sp.Name + "/spaceEvents/") {
+		if ev.EventTime < oldest {
+			continue
+		}
+		match := func(field, op, value string) (bool, bool) {
+			switch field {
+			case "eventTypes":
+				// Filtering by a type also returns its batch variants.
+				return ev.EventType == value || strings.Replace(ev.EventType, ".batch", ".", 1) == batchBase(value), true
+			case "startTime":
+				return compare(ev.EventTime, ">", value), true
+			case "endTime":
+				return compare(ev.EventTime, "<=", value), true
+			}
+			return false, false
+		}
+		if f.matches(ev, match) {
+			events = append(events, ev)
+		}
+	}
+
+	page, next, err := paginate(r, events, 100, 1000)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListSpaceEventsResponse{SpaceEvents: page, NextPageToken: next}, nil
+}
+
+// batchBase maps "….v1.created" to the form produced by stripping "batch"
+// from "….v1.batchCreated", so both compare equal.
+func batchBase(eventType string) string {
+	i := strings.LastIndex(eventType, ".")
+	if i < 0 || i+1 >= len(eventType) {
+		return eventType
+	}
+	return eventType[:i+1] + strings.ToUpper(eventType[i+1:i+2]) + eventType[i+2:]
+}
+
+func (s *Server) getEvent(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	sp, err := s.lookupSpace(vars[0], false)
+	if err != nil {
+		return nil, err
+	}
+	name := sp.Name + "/spaceEvents/" + vars[1]
+	ev, ok := s.events.get(name)
+	if !ok {
+		return nil, notFound("Space event %s not found.", name)
+	}
+	return ev, nil
+}
@@ -0,0 +1,129 @@
+package chattest
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// selection is a parsed "fields" system parameter: the selected field names,
+// each with an optional nested selection (nil selects the whole value).
+type selection map[string]selection
+
+// parseFields parses a field selector such as "name,sender(displayName)" or
+// "messages/name,nextPageToken".
+func parseFields(fields string) (selection, error) {
+	sel, rest, err := parseSelection(fields)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, invalidArgument("Invalid field selection %q.", fields)
+	}
+	return sel, nil
+}
+
+// parseSelection parses a comma-separated list of field paths up to an
+// unmatched ")" and returns the unparsed remainder.
+func parseSelection(s string) (selection, string, error) {
+	sel := selection{}
+	for {
+		end := strings.IndexAny(s, ",()")
+		if end < 0 {
+			end = len(s)
+		}
+		path := strings.TrimSpace(s[:end])
+		if path == "" {
+			return nil, "", invalidArgument("Invalid field selection: empty field name.")
+		}
+		s = s[end:]
+
+		var sub selection
+		if strings.HasPrefix(s, "(") {
+			var err error
+			if sub, s, err = parseSelection(s[1:]); err != nil {
+				return nil, "", err
+			}
+			if !strings.HasPrefix(s, ")") {
+				return nil, "", invalidArgument("Invalid field selection: missing \")\".")
+			}
+			s = s[1:]
+		}
+		sel.add(strings.Split(path, "/"), sub)
+
+		if !strings.HasPrefix(s, ",") {
+			return sel, s, nil
+		}
+		s = s[1:]
+	}
+}
+
+// add records the field path a/b/c with the nested selection sub.
+func (sel selection) add(path []string, sub selection) {
+	name := path[0]
+	if len(path) == 1 {
+		if existing, ok := sel[name]; sub == nil || (ok && existing == nil) {
+			sel[name] = nil
+			return
+		}
+		if sel[name] == nil {
+			sel[name] = selection{}
+		}
+		for k, v := range sub {
+			sel[name][k] = v
+		}
+		return
+	}
+	if existing, ok := sel[name]; ok && existing == nil {
+		return
+	}
+	if sel[name] == nil {
+		sel[name] = selection{}
+	}
+	sel[name].add(path[1:], sub)
+}
+
+// apply returns the parts of a decoded JSON value picked by sel. Selections
+// apply to each element of an array.
+func (sel selection) apply(v any) any {
+	if sel == nil {
+		return v
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		out := map[string]any{}
+		for name, sub := range sel {
+			if field, ok := v[name]; ok {
+				out[name] = sub.apply(field)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = sel.apply(elem)
+		}
+		return out
+	}
+	return v
+}
+
+// selectFields trims a response down to the fields requested by the
+// "fields" system parameter.
+func selectFields(resp any, fields string) (any, error) {
+	if fields == "*" {
+		return resp, nil
+	}
+	sel, err := parseFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return sel.apply(v), nil
+}
@@ -0,0 +1,393 @@
+package chattest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// filter is a parsed list filter such as
+//
+//	spaceType = "SPACE" AND (createTime > "2024-01-01T00:00:00Z" OR displayName:"ops")
+//
+// It implements the subset of the AIP-160 grammar the Chat API accepts:
+// comparisons (=, !=, <, <=, >, >=, and ":" for "has"), AND, OR, NOT,
+// parentheses, and function-style predicates such as creator("users/me"),
+// which are treated as creator = "users/me". Field names may be given in
+// snake_case or camelCase.
+type filter struct {
+	root   filterNode
+	fields map[string]bool
+}
+
+// matchFunc lets an endpoint override how a single comparison is evaluated.
+// It returns handled=false to fall back to comparing the resource field.
+type matchFunc func(field, op, value string) (result, handled bool)
+
+type filterNode interface {
+	eval(fields map[string]any, match matchFunc) bool
+}
+
+type andNode struct{ left, right filterNode }
+type orNode struct{ left, right filterNode }
+type notNode struct{ node filterNode }
+type cmpNode struct{ field, op, value string }
+
+func (n andNode) eval(f map[string]any, m matchFunc) bool {
+	return n.left.eval(f, m) && n.right.eval(f, m)
+}
+func (n orNode) eval(f map[string]any, m matchFunc) bool {
+	return n.left.eval(f, m) || n.right.eval(f, m)
+}
+func (n notNode) eval(f map[string]any, m matchFunc) bool { return !n.node.eval(f, m) }
+
+func (n *cmpNode) eval(fields map[string]any, match matchFunc) bool {
+	if match != nil {
+		if result, handled := match(n.field, n.op, n.value); handled {
+			return result
+		}
+	}
+	actual, ok := lookupField(fields, n.field)
+	if !ok {
+		return n.op == "!="
+	}
+	return compare(actual, n.op, n.value)
+}
+
+// parseFilter parses expr and checks that it only references the allowed
+// fields. An empty expression yields a nil filter, which matches everything.
+func parseFilter(expr string, allowed ...string) (*filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	toks, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{toks: toks, fields: map[string]bool{}}
+	root, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q", p.toks[p.pos].text)
+	}
+
+	allow := map[string]bool{}
+	for _, a := range allowed {
+		allow[a] = true
+	}
+	for field := range p.fields {
+		if !allow[field] {
+			return nil, fmt.Errorf("unsupported filter field %q", field)
+		}
+	}
+	return &filter{root: root, fields: p.fields}, nil
+}
+
+// has reports whether the filter references field.
+func (f *filter) has(field string) bool {
+	return f != nil && f.fields[field]
+}
+
+// rewrite replaces the value of every comparison with fn(field, value),
+// e.g. to expand the "users/me" alias.
+func (f *filter) rewrite(fn func(field, value string) string) {
+	if f == nil {
+		return
+	}
+	var walk func(n filterNode)
+	walk = func(n filterNode) {
+		switch n := n.(type) {
+		case andNode:
+			walk(n.left)
+			walk(n.right)
+		case orNode:
+			walk(n.left)
+			walk(n.right)
+		case notNode:
+			walk(n.node)
+		case *cmpNode:
+			n.value = fn(n.field, n.value)
+		}
+	}
+	walk(f.root)
+}
+
+// matches evaluates the filter against v, which is marshaled to JSON so that
+// dotted field paths can be resolved generically.
+func (f *filter) matches(v any, match matchFunc) bool {
+	if f == nil {
+		return true
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return false
+	}
+	return f.root.eval(fields, match)
+}
+
+// lookupField resolves a dotted camelCase path in a JSON object.
+func lookupField(fields map[string]any, path string) (string, bool) {
+	var cur any = fields
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if cur, ok = obj[part]; !ok {
+			return "", false
+		}
+	}
+	switch v := cur.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// compare applies op to actual and want, comparing as timestamps or numbers
+// when both sides parse as such and as strings otherwise.
+func compare(actual, op, want string) bool {
+	if op == ":" {
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(want))
+	}
+
+	c := strings.Compare(actual, want)
+	if at, err := time.Parse(time.RFC3339Nano, actual); err == nil {
+		if wt, err := time.Parse(time.RFC3339Nano, want); err == nil {
+			c = at.Compare(wt)
+		}
+	} else if af, err := strconv.ParseFloat(actual, 64); err == nil {
+		if wf, err := strconv.ParseFloat(want, 64); err == nil {
+			switch {
+			case af < wf:
+				c = -1
+			case af > wf:
+				c = 1
+			default:
+				c = 0
+			}
+		}
+	}
+
+	switch op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// camelField converts a snake_case field path to the camelCase JSON names
+// used by the API resources ("emoji.custom_emoji.uid" → "emoji.customEmoji.uid").
+func camelField(s string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range s {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			toks = append(toks, token{tokLParen, "("})
+			i++
+		case c == ')':
+			toks = append(toks, token{tokRParen, ")"})
+			i++
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string in filter")
+			}
+			toks = append(toks, token{tokString, b.String()})
+			i = j + 1
+		case strings.HasPrefix(s[i:], "!=") || strings.HasPrefix(s[i:], "<=") || strings.HasPrefix(s[i:], ">="):
+			toks = append(toks, token{tokOp, s[i : i+2]})
+			i += 2
+		case c == '=' || c == '<' || c == '>' || c == ':':
+			toks = append(toks, token{tokOp, string(c)})
+			i++
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n()\"=!<>:", rune(s[j])) {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("unexpected character %q in filter", c)
+			}
+			toks = append(toks, token{tokIdent, s[i:j]})
+			i = j
+		}
+	}
+	return toks, nil
+}
+
+type filterParser struct {
+	toks   []token
+	pos    int
+	fields map[string]bool
+}
+
+func (p *filterParser) peek() *token {
+	if p.pos < len(p.toks) {
+		return &p.toks[p.pos]
+	}
+	return nil
+}
+
+func (p *filterParser) keyword(kw string) bool {
+	if t := p.peek(); t != nil && t.kind == tokIdent && t.text == kw {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.keyword("NOT") {
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	if t.kind == tokLParen {
+		p.pos++
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.peek(); t == nil || t.kind != tokRParen {
+			return nil, fmt.Errorf("missing closing parenthesis in filter")
+		}
+		p.pos++
+		return n, nil
+	}
+	if t.kind != tokIdent {
+		return nil, fmt.Errorf("expected field name, got %q", t.text)
+	}
+	field := camelField(t.text)
+	p.pos++
+	p.fields[field] = true
+
+	// Function-style predicate: creator("users/me").
+	if t := p.peek(); t != nil && t.kind == tokLParen {
+		p.pos++
+		arg := p.peek()
+		if arg == nil || (arg.kind != tokString && arg.kind != tokIdent) {
+			return nil, fmt.Errorf("expected argument to %s()", field)
+		}
+		p.pos++
+		if t := p.peek(); t == nil || t.kind != tokRParen {
+			return nil, fmt.Errorf("missing closing parenthesis after %s(", field)
+		}
+		p.pos++
+		return &cmpNode{field: field, op: "=", value: arg.text}, nil
+	}
+
+	op := p.peek()
+	if op == nil || op.kind != tokOp {
+		return nil, fmt.Errorf("expected operator after %q", field)
+	}
+	p.pos++
+	val := p.peek()
+	if val == nil || (val.kind != tokString && val.kind != tokIdent) {
+		return nil, fmt.Errorf("expected value after %s %s", field, op.text)
+	}
+	p.pos++
+	return &cmpNode{field: field, op: op.text, value: val.text}, nil
+}
@@ -0,0 +1,263 @@
+package chattest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// maxUploadSize is the largest attachment the Chat API accepts.
+const maxUploadSize = 200 << 20
+
+// mediaBlob is uploaded or seeded media content.
+type mediaBlob struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+// AddMedia stores downloadable content under a media resource name, as
+// used by GET /v1/media/{resourceName}, and returns an attachment data
+// reference that messages can use.
+func (s *Server) AddMedia(resourceName, filename, contentType string, data []byte) *api.AttachmentDataRef {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.media[resourceName] = &mediaBlob{filename: filename, contentType: contentType, data: bytes.Clone(data)}
+	return &api.AttachmentDataRef{ResourceName: resourceName, AttachmentUploadToken: resourceName}
+}
+
+// Media returns the content stored under a media resource name.
+func (s *Server) Media(resourceName string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blob, ok := s.media[resourceName]
+	if !ok {
+		return nil, false
+	}
+	return bytes.Clone(blob.data), true
+}
+
+func (s *Server) uploadAttachment(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	sp, err := s.lookupSpace(vars[0], false)
+	if err != nil {
+		return nil, err
+	}
+	if r.URL.Query().Get("uploadType") == "resumable" {
+		return s.startUpload(w, r, sp.Name)
+	}
+
+	blob, err := readUpload(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob.data) > maxUploadSize {
+		return nil, invalidArgument("Attachments can be at most %d bytes.", maxUploadSize)
+	}
+	return s.storeUpload(sp.Name, blob), nil
+}
+
+// storeUpload saves an uploaded file as a new attachment of space.
+func (s *Server) storeUpload(space string, blob *mediaBlob) *api.UploadAttachmentResponse {
+	resourceName := space + "/attachments/" + s.newID("UPL")
+	s.media[resourceName] = blob
+	return &api.UploadAttachmentResponse{
+		AttachmentDataRef: &api.AttachmentDataRef{
+			ResourceName:          resourceName,
+			AttachmentUploadToken: resourceName,
+		},
+	}
+}
+
+// uploadSession is a resumable upload in progress.
+type uploadSession struct {
+	space string
+	blob  *mediaBlob
+	size  int64
+}
+
+// startUpload opens a resumable upload session. The session URL is returned
+// in the Location header, as with the real service.
+func (s *Server) startUpload(w http.ResponseWriter, r *http.Request, space string) (any, error) {
+	var meta struct {
+		Filename string `json:"filename"`
+	}
+	if err := decodeBody(r, &meta); err != nil {
+		return nil, err
+	}
+	if meta.Filename == "" {
+		return nil, invalidArgument("filename is required.")
+	}
+	size, err := strconv.ParseInt(r.Header.Get("X-Upload-Content-Length"), 10, 64)
+	if err != nil || size < 0 {
+		return nil, invalidArgument("X-Upload-Content-Length is required.")
+	}
+	if size > maxUploadSize {
+		return nil, invalidArgument("Attachments can be at most %d bytes.", maxUploadSize)
+	}
+	contentType := r.Header.Get("X-Upload-Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	id := s.newID("UPLOAD")
+	s.uploads[id] = &uploadSession{
+		space: space,
+		blob:  &mediaBlob{filename: meta.Filename, contentType: contentType},
+		size:  size,
+	}
+	w.Header().Set("Location", s.URL+"/upload/v1/"+space+"/attachments:upload?uploadType=resumable&upload_id="+id)
+	w.WriteHeader(http.StatusOK)
+	return nil, nil
+}
+
+// resumeUpload receives a chunk of a resumable upload, or with
+// "Content-Range: bytes */SIZE" reports how much has been committed.
+// Incomplete sessions answer 308 with a Range header; the final chunk
+// answers with the upload response.
+func (s *Server) resumeUpload(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	id := r.URL.Query().Get("upload_id")
+	up, ok := s.uploads[id]
+	if !ok {
+		return nil, notFound("Upload session %q not found.", id)
+	}
+
+	var first, last, size int64
+	cr := r.Header.Get("Content-Range")
+	if _, err := fmt.Sscanf(cr, "bytes */%d", &size); err == nil {
+		first = -1
+	} else if _, err := fmt.Sscanf(cr, "bytes %d-%d/%d", &first, &last, &size); err != nil {
+		return nil, invalidArgument("Invalid Content-Range %q.", cr)
+	}
+	if size != up.size {
+		return nil, invalidArgument("Content-Range size %d does not match the session size %d.", size, up.size)
+	}
+
+	if first >= 0 {
+		if first != int64(len(up.blob.data)) || last < first || last >= size {
+			return nil, invalidArgument("Content-Range %q does not continue from byte %d.", cr, len(up.blob.data))
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, invalidArgument("Reading upload: %v", err)
+		}
+		if int64(len(data)) != last-first+1 {
+			return nil, invalidArgument("Received %d bytes, Content-Range announced %d.", len(data), last-first+1)
+		}
+		up.blob.data = append(up.blob.data, data...)
+	}
+
+	if int64(len(up.blob.data)) < up.size {
+		if len(up.blob.data) > 0 {
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(up.blob.data)-1))
+		}
+		w.WriteHeader(http.StatusPermanentRedirect)
+		return nil, nil
+	}
+
+	delete(s.uploads, id)
+	return s.storeUpload(up.space, up.blob), nil
+}
+
+// readUpload extracts the file from an upload request. It accepts a
+// multipart/form-data body with a "file" part, a multipart/related body
+// (JSON metadata followed by the media), or the raw media itself.
+func readUpload(r *http.Request) (*mediaBlob, error) {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "application/octet-stream"
+	}
+	body := http.MaxBytesReader(nil, r.Body, maxUploadSize+1)
+
+	switch mediaType {
+	case "multipart/form-data", "multipart/related":
+		mr := multipart.NewReader(body, params["boundary"])
+		blob := &mediaBlob{}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, invalidArgument("Malformed multipart body: %v", err)
+			}
+			data, err := io.ReadAll(part)
+			if err != nil {
+				return nil, invalidArgument("Reading upload: %v", err)
+			}
+			partType := part.Header.Get("Content-Type")
+
+			switch {
+			case mediaType == "multipart/form-data" && part.FormName() == "filename":
+				blob.filename = string(data)
+			case mediaType == "multipart/form-data" && part.FormName() == "file":
+				blob.data = data
+				blob.contentType = partType
+				if blob.filename == "" {
+					blob.filename = part.FileName()
+				}
+			case mediaType == "multipart/related" && blob.data == nil && isJSON(partType):
+				var meta struct {
+					Filename string `json:"filename"`
+				}
+				if err := json.Unmarshal(data, &meta); err != nil {
+					return nil, invalidArgument("Invalid upload metadata: %v", err)
+				}
+				blob.filename = meta.Filename
+			case mediaType == "multipart/related":
+				blob.data = data
+				blob.contentType = partType
+			}
+		}
+		if blob.data == nil {
+			return nil, invalidArgument("The upload contains no file.")
+		}
+		if blob.filename == "" {
+			return nil, invalidArgument("filename is required.")
+		}
+		if blob.contentType == "" {
+			blob.contentType = "application/octet-stream"
+		}
+		return blob, nil
+
+	default:
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, invalidArgument("Reading upload: %v", err)
+		}
+		filename := r.URL.Query().Get("filename")
+		if filename == "" {
+			return nil, invalidArgument("filename is required.")
+		}
+		return &mediaBlob{filename: filename, contentType: mediaType, data: data}, nil
+	}
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json"
+}
+
+func (s *Server) downloadMedia(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	blob, ok := s.media[vars[0]]
+	if !ok {
+		return nil, notFound("Media %s not found.", vars[0])
+	}
+	if r.URL.Query().Get("alt") != "media" {
+		return &api.Media{ResourceName: vars[0]}, nil
+	}
+
+	w.Header().Set("Content-Type", blob.contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": blob.filename}))
+	http.ServeContent(w, r, blob.filename, time.Time{}, bytes.NewReader(blob.data))
+	return nil, nil
+}
@@ -0,0 +1,246 @@
+package chattest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// AddMember adds a user (or a Google Group, when user starts with
+// "groups/") to a space with the given role and state, e.g. "ROLE_MEMBER"
+// and "JOINED", and returns the stored membership.
+func (s *Server) AddMember(space, user, role, state string) *api.Membership {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var m *api.Membership
+	if strings.HasPrefix(user, "groups/") {
+		m = s.insertGroup(space, user, state)
+	} else {
+		m = s.insertMember(space, s.userInfo(user), role, state)
+	}
+	s.recount(space)
+	return clone(m)
+}
+
+func (s *Server) insertMember(space string, user *api.User, role, state string) *api.Membership {
+	m := &api.Membership{
+		Name:       memberName(space, user.Name),
+		Member:     user,
+		Role:       role,
+		State:      state,
+		CreateTime: s.now(),
+	}
+	s.members.put(m.Name, m)
+	return m
+}
+
+func (s *Server) insertGroup(space, group, state string) *api.Membership {
+	m := &api.Membership{
+		Name:        space + "/members/" + strings.TrimPrefix(group, "groups/"),
+		GroupMember: &api.Group{Name: group},
+		Role:        "ROLE_MEMBER",
+		State:       state,
+		CreateTime:  s.now(),
+	}
+	s.members.put(m.Name, m)
+	return m
+}
+
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := s.lookupSpace(vars[0], admin)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := parseFilter(r.URL.Query().Get("filter"), "member.type", "role")
+	if err != nil {
+		return nil, invalidArgument("Invalid filter: %v", err)
+	}
+	if admin && !f.has("member.type") {
+		return nil, invalidArgument("With useAdminAccess, the filter must include a member.type condition, e.g. member.type = \"HUMAN\".")
+	}
+	showInvited, err := boolParam(r, "showInvited")
+	if err != nil {
+		return nil, err
+	}
+	showGroups, err := boolParam(r, "showGroups")
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*api.Membership
+	for _, m := range s.members.list(sp.Name + "/members/") {
+		if m.State == "INVITED" && !showInvited {
+			continue
+		}
+		if m.State == "NOT_A_MEMBER" {
+			continue
+		}
+		if m.GroupMember != nil && !showGroups {
+			continue
+		}
+		if f.matches(m, nil) {
+			members = append(members, m)
+		}
+	}
+
+	page, next, err := paginate(r, members, 100, 1000)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListMembershipsResponse{Memberships: page, NextPageToken: next}, nil
+}
+
+// lookupMember returns the membership addressed by vars (space, member)
+// after checking that the caller may see the space.
+func (s *Server) lookupMember(vars []string, admin bool) (*api.Membership, error) {
+	sp, err := s.lookupSpace(vars[0], admin)
+	if err != nil {
+		return nil, err
+	}
+	name := sp.Name + "/members/" + vars[1]
+	m, ok := s.members.get(name)
+	if !ok {
+		return nil, notFound("Membership %s not found.", name)
+	}
+	return m, nil
+}
+
+func (s *Server) getMember(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.lookupMember(vars, admin)
+}
+
+func (s *Server) createMember(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := s.lookupSpace(vars[0], admin)
+	if err != nil {
+		return nil, err
+	}
+	if sp.SpaceType == "DIRECT_MESSAGE" {
+		return nil, invalidArgument("Members cannot be added to direct messages.")
+	}
+
+	var body api.Membership
+	if err := decodeBody(r, &body); err != nil {
+		return nil, err
+	}
+
+	var m *api.Membership
+	switch {
+	case body.GroupMember != nil && body.GroupMember.Name != "":
+		if _, exists := s.members.get(sp.Name + "/members/" + strings.TrimPrefix(body.GroupMember.Name, "groups/")); exists {
+			return nil, alreadyExists("%s is already a member of %s.", body.GroupMember.Name, sp.Name)
+		}
+		m = s.insertGroup(sp.Name, body.GroupMember.Name, "JOINED")
+	case body.Member != nil && body.Member.Name != "":
+		user := s.userInfo(s.resolveUser(body.Member.Name))
+		if body.Member.Type != "" {
+			user.Type = body.Member.Type
+		}
+		if user.Type == "BOT" && admin {
+			return nil, invalidArgument("Chat apps cannot be added with admin access.")
+		}
+		if _, exists := s.members.get(memberName(sp.Name, user.Name)); exists {
+			return nil, alreadyExists("%s is already a member of %s.", user.Name, sp.Name)
+		}
+		role := body.Role
+		if role == "" {
+			role = "ROLE_MEMBER"
+		}
+		m = s.insertMember(sp.Name, user, role, "JOINED")
+	default:
+		return nil, invalidArgument("Either member or groupMember must be set.")
+	}
+
+	s.recount(sp.Name)
+	s.emit(sp.Name, "membership.v1.created", func(ev *api.SpaceEvent) {
+		ev.MembershipCreatedEventData = &api.MembershipCreatedEventData{Membership: clone(m)}
+	})
+	return m, nil
+}
+
+func (s *Server) patchMember(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.lookupMember(vars, admin)
+	if err != nil {
+		return nil, err
+	}
+	space := "spaces/" + vars[0]
+	if !admin && !s.isManager(space) {
+		return nil, permissionDenied("Only space managers can change member roles in %s.", space)
+	}
+
+	var patch api.Membership
+	if err := decodeBody(r, &patch); err != nil {
+		return nil, err
+	}
+	updated := clone(m)
+	if err := applyMask(updated, &patch, r.URL.Query().Get("updateMask"), "role"); err != nil {
+		return nil, err
+	}
+	if updated.Role != "ROLE_MEMBER" && updated.Role != "ROLE_MANAGER" {
+		return nil, invalidArgument("Invalid role %q.", updated.Role)
+	}
+	if m.Role == "ROLE_MANAGER" && updated.Role != "ROLE_MANAGER" && s.managerCount(space) == 1 {
+		return nil, newError(http.StatusPreconditionFailed, "A space must keep at least one manager.")
+	}
+
+	s.members.put(m.Name, updated)
+	s.emit(space, "membership.v1.updated", func(ev *api.SpaceEvent) {
+		ev.MembershipUpdatedEventData = &api.MembershipUpdatedEventData{Membership: clone(updated)}
+	})
+	return updated, nil
+}
+
+func (s *Server) deleteMember(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.lookupMember(vars, admin)
+	if err != nil {
+		return nil, err
+	}
+	space := "spaces/" + vars[0]
+	self := m.Member != nil && m.Member.Name == s.caller.Name
+	if !admin && !self && !s.isManager(space) {
+		return nil, permissionDenied("Only space managers can remove other members from %s.", space)
+	}
+	if m.Role == "ROLE_MANAGER" && s.managerCount(space) == 1 && len(s.members.list(space+"/members/")) > 1 {
+		return nil, newError(http.StatusPreconditionFailed, "The last manager cannot leave %s while it has other members.", space)
+	}
+
+	s.members.delete(m.Name)
+	s.recount(space)
+	s.emit(space, "membership.v1.deleted", func(ev *api.SpaceEvent) {
+		ev.MembershipDeletedEventData = &api.MembershipDeletedEventData{Membership: clone(m)}
+	})
+	return m, nil
+}
+
+// managerCount returns the number of joined managers in a space.
+func (s *Server) managerCount(space string) int {
+	n := 0
+	for _, m := range s.members.list(space + "/members/") {
+		if m.Role == "ROLE_MANAGER" && m.State == "JOINED" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,353 @@
+package chattest
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// clientMessageID matches the custom IDs accepted by messages.create.
+var clientMessageID = regexp.MustCompile(`^client-[a-z0-9-]{1,56}$`)
+
+// AddMessage posts a message to a space as its sender (the caller if the
+// message has none) and returns the stored copy. Thread, createTime and name
+// are filled in when missing.
+func (s *Server) AddMessage(space string, msg api.Message) *api.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sender := s.caller.Name
+	if msg.Sender != nil && msg.Sender.Name != "" {
+		sender = msg.Sender.Name
+	}
+	threadKey := ""
+	if msg.Thread != nil {
+		threadKey = msg.Thread.ThreadKey
+	}
+	m := s.insertMessage(space, &msg, s.userInfo(sender), threadKey, "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+	return clone(m)
+}
+
+// insertMessage stores a new message in space, assigning it to a thread.
+func (s *Server) insertMessage(space string, msg *api.Message, sender *api.User, threadKey, replyOption string) *api.Message {
+	if msg.Name == "" {
+		msg.Name = space + "/messages/" + s.newID("MSG")
+	}
+	if msg.CreateTime == "" {
+		msg.CreateTime = s.now()
+	}
+	for _, a := range msg.Attachment {
+		if a.Name == "" {
+			a.Name = msg.Name + "/attachments/" + s.newID("ATT")
+		}
+	}
+	msg.Sender = sender
+	msg.Space = &api.Space{Name: space}
+	msg.ArgumentText = msg.Text
+	if msg.FormattedText == "" {
+		msg.FormattedText = msg.Text
+	}
+
+	threadName := ""
+	if msg.Thread != nil {
+		threadName = msg.Thread.Name
+	}
+	if threadKey != "" && threadName == "" {
+		threadName = s.threadKeys[space+"/"+threadKey]
+	}
+	if replyOption == "" || replyOption == "MESSAGE_REPLY_OPTION_UNSPECIFIED" {
+		threadName = ""
+	}
+
+	if threadName != "" && s.threadExists(threadName) {
+		msg.Thread = &api.Thread{Name: threadName, ThreadKey: threadKey}
+		msg.ThreadReply = true
+	} else {
+		msg.Thread = &api.Thread{Name: space + "/threads/" + s.newID("TH"), ThreadKey: threadKey}
+		msg.ThreadReply = false
+		if threadKey != "" {
+			s.threadKeys[space+"/"+threadKey] = msg.Thread.Name
+		}
+	}
+
+	s.messages.put(msg.Name, msg)
+	if sp, ok := s.spaces.get(space); ok {
+		sp.LastActiveTime = msg.CreateTime
+	}
+	s.emit(space, "message.v1.created", func(ev *api.SpaceEvent) {
+		ev.MessageCreatedEventData = &api.MessageCreatedEventData{Message: clone(msg)}
+	})
+	return msg
+}
+
+// threadExists reports whether any message belongs to the thread.
+func (s *Server) threadExists(thread string) bool {
+	return len(s.threadMessages(thread)) > 0
+}
+
+// threadMessages returns the live messages in a thread, oldest first.
+func (s *Server) threadMessages(thread string) []*api.Message {
+	space, _, _ := strings.Cut(thread, "/threads/")
+	var out []*api.Message
+	for _, m := range s.messages.list(space + "/messages/") {
+		if m.Thread != nil && m.Thread.Name == thread && m.DeleteTime == "" {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func (s *Server) listMessages(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	sp, err := s.lookupSpace(vars[0], false)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"), "createTime", "thread.name")
+	if err != nil {
+		return nil, invalidArgument("Invalid filter: %v", err)
+	}
+	showDeleted, err := boolParam(r, "showDeleted")
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []*api.Message
+	for _, m := range s.messages.list(sp.Name + "/messages/") {
+		if m.DeleteTime != "" && !showDeleted {
+			continue
+		}
+		if f.matches(m, nil) {
+			msgs = append(msgs, m)
+		}
+	}
+
+	field, dir, _ := strings.Cut(strings.TrimSpace(r.URL.Query().Get("orderBy")), " ")
+	if field != "" && camelField(field) != "createTime" {
+		return nil, invalidArgument("Messages can only be ordered by createTime.")
+	}
+	switch strings.ToUpper(strings.TrimSpace(dir)) {
+	case "", "ASC":
+		sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].CreateTime < msgs[j].CreateTime })
+	case "DESC":
+		sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].CreateTime > msgs[j].CreateTime })
+	default:
+		return nil, invalidArgument("Invalid orderBy direction %q.", dir)
+	}
+
+	page, next, err := paginate(r, msgs, 25, 1000)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListMessagesResponse{Messages: page, NextPageToken: next}, nil
+}
+
+func (s *Server) createMessage(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	sp, err := s.lookupSpace(vars[0], false)
+	if err != nil {
+		return nil, err
+	}
+	q := r.URL.Query()
+
+	requestKey := ""
+	if id := q.Get("requestId"); id != "" {
+		requestKey = "messages:" + sp.Name + ":" + id
+		if name, ok := s.requestIDs[requestKey]; ok {
+			if existing, ok := s.messages.get(name); ok {
+				return existing, nil
+			}
+		}
+	}
+
+	var msg api.Message
+	if err := decodeBody(r, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Text == "" && len(msg.CardsV2) == 0 && len(msg.Cards) == 0 && len(msg.Attachment) == 0 {
+		return nil, invalidArgument("Message cannot be empty. Discarded message.")
+	}
+
+	msg.Name = ""
+	if id := q.Get("messageId"); id != "" {
+		if !clientMessageID.MatchString(id) {
+			return nil, invalidArgument("Invalid messageId %q: it must start with \"client-\" and contain only lowercase letters, numbers and hyphens.", id)
+		}
+		msg.Name = sp.Name + "/messages/" + id
+		msg.ClientAssignedMessageID = id
+		if _, exists := s.messages.get(msg.Name); exists {
+			return nil, alreadyExists("Message %s already exists.", msg.Name)
+		}
+	}
+
+	if err := s.resolveAttachments(&msg); err != nil {
+		return nil, err
+	}
+
+	threadKey := q.Get("threadKey")
+	if threadKey == "" && msg.Thread != nil {
+		threadKey = msg.Thread.ThreadKey
+	}
+	replyOption := q.Get("messageReplyOption")
+	switch replyOption {
+	case "", "MESSAGE_REPLY_OPTION_UNSPECIFIED", "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD":
+	case "REPLY_MESSAGE_OR_FAIL":
+		thread := ""
+		if msg.Thread != nil {
+			thread = msg.Thread.Name
+		}
+		if thread == "" && threadKey != "" {
+			thread = s.threadKeys[sp.Name+"/"+threadKey]
+		}
+		if thread == "" || !s.threadExists(thread) {
+			return nil, notFound("Thread not found and messageReplyOption is REPLY_MESSAGE_OR_FAIL.")
+		}
+	default:
+		return nil, invalidArgument("Invalid messageReplyOption %q.", replyOption)
+	}
+
+	created := s.insertMessage(sp.Name, &msg, s.userInfo(s.caller.Name), threadKey, replyOption)
+	if requestKey != "" {
+		s.requestIDs[requestKey] = created.Name
+	}
+	return created, nil
+}
+
+// resolveAttachments turns uploaded attachment references in a new message
+// into full attachment resources.
+func (s *Server) resolveAttachments(msg *api.Message) error {
+	for _, a := range msg.Attachment {
+		if a.AttachmentDataRef == nil || a.AttachmentDataRef.ResourceName == "" {
+			return invalidArgument("Attachments must reference uploaded data with attachmentDataRef.resourceName.")
+		}
+		blob, ok := s.media[a.AttachmentDataRef.ResourceName]
+		if !ok {
+			return invalidArgument("Unknown attachment %s.", a.AttachmentDataRef.ResourceName)
+		}
+		a.ContentName = blob.filename
+		a.ContentType = blob.contentType
+		a.Source = "UPLOADED_CONTENT"
+		a.DownloadURI = s.URL + "/v1/media/" + a.AttachmentDataRef.ResourceName + "?alt=media"
+	}
+	return nil
+}
+
+// lookupMessage returns a live message addressed by vars (space, message).
+func (s *Server) lookupMessage(vars []string) (*api.Message, error) {
+	sp, err := s.lookupSpace(vars[0], false)
+	if err != nil {
+		return nil, err
+	}
+	name := sp.Name + "/messages/" + vars[1]
+	m, ok := s.messages.get(name)
+	if !ok || m.DeleteTime != "" {
+		return nil, notFound("Message %s not found.", name)
+	}
+	return m, nil
+}
+
+func (s *Server) getMessage(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	return s.lookupMessage(vars)
+}
+
+func (s *Server) updateMessage(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	allowMissing, err := boolParam(r, "allowMissing")
+	if err != nil {
+		return nil, err
+	}
+	var patch api.Message
+	if err := decodeBody(r, &patch); err != nil {
+		return nil, err
+	}
+
+	m, err := s.lookupMessage(vars)
+	if err != nil {
+		apiErr, ok := err.(*Error)
+		if !ok || apiErr.Code != http.StatusNotFound || !allowMissing {
+			return nil, err
+		}
+		if !clientMessageID.MatchString(vars[1]) {
+			return nil, invalidArgument("allowMissing requires a client-assigned message ID.")
+		}
+		patch.Name = "spaces/" + vars[0] + "/messages/" + vars[1]
+		patch.ClientAssignedMessageID = vars[1]
+		if err := s.resolveAttachments(&patch); err != nil {
+			return nil, err
+		}
+		return s.insertMessage("spaces/"+vars[0], &patch, s.userInfo(s.caller.Name), "", ""), nil
+	}
+
+	if m.Sender == nil || m.Sender.Name != s.caller.Name {
+		return nil, permissionDenied("Only the sender can update %s.", m.Name)
+	}
+
+	updated := clone(m)
+	if err := applyMask(updated, &patch, r.URL.Query().Get("updateMask"),
+		"text", "attachment", "cards", "cardsV2", "accessoryWidgets"); err != nil {
+		return nil, err
+	}
+	if updated.Text == "" && len(updated.CardsV2) == 0 && len(updated.Cards) == 0 && len(updated.Attachment) == 0 {
+		return nil, invalidArgument("Message cannot be empty.")
+	}
+	updated.ArgumentText = updated.Text
+	updated.FormattedText = updated.Text
+	updated.LastUpdateTime = s.now()
+
+	s.messages.put(m.Name, updated)
+	s.emit("spaces/"+vars[0], "message.v1.updated", func(ev *api.SpaceEvent) {
+		ev.MessageUpdatedEventData = &api.MessageUpdatedEventData{Message: clone(updated)}
+	})
+	return updated, nil
+}
+
+func (s *Server) deleteMessage(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	m, err := s.lookupMessage(vars)
+	if err != nil {
+		return nil, err
+	}
+	force, err := boolParam(r, "force")
+	if err != nil {
+		return nil, err
+	}
+	space := "spaces/" + vars[0]
+
+	deletionType := "CREATOR"
+	if m.Sender == nil || m.Sender.Name != s.caller.Name {
+		if !s.isManager(space) {
+			return nil, permissionDenied("Only the sender or a space manager can delete %s.", m.Name)
+		}
+		deletionType = "SPACE_OWNER"
+	}
+
+	if !m.ThreadReply && m.Thread != nil && len(s.threadMessages(m.Thread.Name)) > 1 && !force {
+		return nil, newError(http.StatusPreconditionFailed, "Message %s has threaded replies; set force=true to delete it.", m.Name)
+	}
+
+	for _, rc := range s.reactions.list(m.Name + "/reactions/") {
+		s.reactions.delete(rc.Name)
+	}
+	m.DeleteTime = s.now()
+	m.DeletionMetadata = &api.DeletionMetadata{DeletionType: deletionType}
+	m.Text, m.ArgumentText, m.FormattedText = "", "", ""
+	m.Cards, m.CardsV2, m.Attachment, m.EmojiReactionSummaries = nil, nil, nil, nil
+
+	s.emit(space, "message.v1.deleted", func(ev *api.SpaceEvent) {
+		ev.MessageDeletedEventData = &api.MessageDeletedEventData{Message: &api.Message{Name: m.Name}}
+	})
+	return &api.Empty{}, nil
+}
+
+func (s *Server) getAttachment(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	m, err := s.lookupMessage(vars[:2])
+	if err != nil {
+		return nil, err
+	}
+	name := m.Name + "/attachments/" + vars[2]
+	for _, a := range m.Attachment {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return nil, notFound("Attachment %s not found.", name)
+}
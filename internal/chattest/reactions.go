@@ -0,0 +1,124 @@
+package chattest
+
+import (
+	"net/http"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// emojiKey identifies an emoji for duplicate detection and summaries.
+func emojiKey(e *api.Emoji) string {
+	if e == nil {
+		return ""
+	}
+	if e.CustomEmoji != nil {
+		return "custom:" + e.CustomEmoji.UID
+	}
+	return "unicode:" + e.Unicode
+}
+
+// summarize recomputes a message's emojiReactionSummaries.
+func (s *Server) summarize(msg *api.Message) {
+	var summaries []*api.EmojiReactionSummary
+	index := map[string]*api.EmojiReactionSummary{}
+	for _, rc := range s.reactions.list(msg.Name + "/reactions/") {
+		key := emojiKey(rc.Emoji)
+		sum, ok := index[key]
+		if !ok {
+			sum = &api.EmojiReactionSummary{Emoji: clone(rc.Emoji)}
+			index[key] = sum
+			summaries = append(summaries, sum)
+		}
+		sum.ReactionCount++
+	}
+	msg.EmojiReactionSummaries = summaries
+}
+
+func (s *Server) listReactions(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	m, err := s.lookupMessage(vars)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"), "emoji.unicode", "emoji.customEmoji.uid", "user.name")
+	if err != nil {
+		return nil, invalidArgument("Invalid filter: %v", err)
+	}
+	f.rewrite(s.aliasUsers)
+
+	var reactions []*api.Reaction
+	for _, rc := range s.reactions.list(m.Name + "/reactions/") {
+		if f.matches(rc, nil) {
+			reactions = append(reactions, rc)
+		}
+	}
+
+	page, next, err := paginate(r, reactions, 25, 200)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListReactionsResponse{Reactions: page, NextPageToken: next}, nil
+}
+
+func (s *Server) createReaction(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	m, err := s.lookupMessage(vars)
+	if err != nil {
+		return nil, err
+	}
+	var rc api.Reaction
+	if err := decodeBody(r, &rc); err != nil {
+		return nil, err
+	}
+	if rc.Emoji == nil || (rc.Emoji.Unicode == "" && rc.Emoji.CustomEmoji == nil) {
+		return nil, invalidArgument("emoji.unicode or emoji.customEmoji is required.")
+	}
+	if ce := rc.Emoji.CustomEmoji; ce != nil {
+		emoji, ok := s.emojis.get("customEmojis/" + ce.UID)
+		if !ok {
+			return nil, notFound("Custom emoji %s not found.", ce.UID)
+		}
+		rc.Emoji.CustomEmoji = &api.CustomEmoji{UID: emoji.UID}
+	}
+
+	key := emojiKey(rc.Emoji)
+	for _, existing := range s.reactions.list(m.Name + "/reactions/") {
+		if existing.User != nil && existing.User.Name == s.caller.Name && emojiKey(existing.Emoji) == key {
+			return nil, alreadyExists("The caller already reacted to %s with this emoji.", m.Name)
+		}
+	}
+
+	rc.Name = m.Name + "/reactions/" + s.newID("RC")
+	rc.User = &api.User{Name: s.caller.Name, Type: s.caller.Type}
+	s.reactions.put(rc.Name, &rc)
+	s.summarize(m)
+	s.emit("spaces/"+vars[0], "reaction.v1.created", func(ev *api.SpaceEvent) {
+		ev.ReactionCreatedEventData = &api.ReactionCreatedEventData{Reaction: clone(&rc)}
+	})
+	return &rc, nil
+}
+
+func (s *Server) deleteReaction(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	m, err := s.lookupMessage(vars)
+	if err != nil {
+		return nil, err
+	}
+	name := m.Name + "/reactions/" + vars[2]
+	rc, ok := s.reactions.get(name)
+	if !ok {
+		return nil, notFound("Reaction %s not found.", name)
+	}
+	if rc.User == nil || rc.User.Name != s.caller.Name {
+		return nil, permissionDenied("Only the user who reacted can delete %s.", name)
+	}
+
+	s.reactions.delete(name)
+	s.summarize(m)
+	s.emit("spaces/"+vars[0], "reaction.v1.deleted", func(ev *api.SpaceEvent) {
+		ev.ReactionDeletedEventData = &api.ReactionDeletedEventData{Reaction: clone(rc)}
+	})
+	return &api.Empty{}, nil
+}
+
+// aliasUsers expands "users/me" in filter values to the caller's name.
+func (s *Server) aliasUsers(field, value string) string {
+	return s.resolveUser(value)
+}
@@ -0,0 +1,505 @@
+// Package chattest provides an in-memory fake of the Google Chat REST API for
+// offline testing. Like net/http/httptest, NewServer starts a local HTTP
+// server; point an api.Client at its BaseURL, or run the gogchat CLI against
+// it with the hidden --base-url flag.
+//
+// The fake implements the endpoints used by internal/api: spaces, messages,
+// memberships, reactions, custom emoji, media upload and download, space
+// events, read state and notification settings, as well as batch requests.
+// It paginates like the real service, supports the documented list filters,
+// honors the "fields" partial-response parameter, answers errors with the
+// Google error envelope and enforces useAdminAccess.
+// Requests are not authenticated; every request acts as the configured
+// caller.
+package chattest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// DefaultCaller is the user every request acts as until SetCaller is called.
+var DefaultCaller = api.User{
+	Name:        "users/100000000000000000001",
+	DisplayName: "Test User",
+	Type:        "HUMAN",
+}
+
+// Server is an in-memory Google Chat API server.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	caller *api.User
+	admin  bool
+	last   time.Time
+	nextID int
+
+	users         map[string]*api.User
+	spaces        *store[api.Space]
+	members       *store[api.Membership]
+	messages      *store[api.Message]
+	reactions     *store[api.Reaction]
+	emojis        *store[api.CustomEmoji]
+	events        *store[api.SpaceEvent]
+	readStates    map[string]*api.SpaceReadState
+	notifications map[string]*api.SpaceNotificationSetting
+	media         map[string]*mediaBlob
+	uploads       map[string]*uploadSession // upload_id → resumable session
+	emojiCreators map[string]string         // custom emoji name → creator user name
+	threadKeys    map[string]string         // space + "/" + threadKey → thread name
+	requestIDs    map[string]string         // requestId → name of the created resource
+	failures      []*failure
+}
+
+// failure is an error queued with FailNext.
+type failure struct {
+	method string
+	path   string
+	err    *Error
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished to shut it down.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a new Server that has not been started yet.
+// Set s.Listener to serve on a specific address, then call Start.
+func NewUnstartedServer() *Server {
+	caller := DefaultCaller
+	s := &Server{
+		caller:        &caller,
+		admin:         true,
+		users:         map[string]*api.User{caller.Name: &caller},
+		spaces:        newStore[api.Space](),
+		members:       newStore[api.Membership](),
+		messages:      newStore[api.Message](),
+		reactions:     newStore[api.Reaction](),
+		emojis:        newStore[api.CustomEmoji](),
+		events:        newStore[api.SpaceEvent](),
+		readStates:    map[string]*api.SpaceReadState{},
+		notifications: map[string]*api.SpaceNotificationSetting{},
+		media:         map[string]*mediaBlob{},
+		uploads:       map[string]*uploadSession{},
+		emojiCreators: map[string]string{},
+		threadKeys:    map[string]string{},
+		requestIDs:    map[string]string{},
+	}
+	s.Server = httptest.NewUnstartedServer(s)
+	return s
+}
+
+// BaseURL returns the versioned API root, the value to use for
+// api.Client.BaseURL or gogchat's --base-url flag.
+func (s *Server) BaseURL() string {
+	return s.URL + "/v1"
+}
+
+// APIClient returns an api.Client talking to the server, with retries and
+// client-side rate limiting disabled so that tests run fast.
+func (s *Server) APIClient() *api.Client {
+	c := api.NewClient(s.Client())
+	c.BaseURL = s.BaseURL()
+	c.Retry.MaxAttempts = 1
+	c.Limiter = nil
+	return c
+}
+
+// SetCaller changes the user subsequent requests act as, and whether that
+// user is a Workspace administrator allowed to use useAdminAccess.
+func (s *Server) SetCaller(user api.User, admin bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.caller = &user
+	s.admin = admin
+	if _, ok := s.users[user.Name]; !ok {
+		s.users[user.Name] = &user
+	}
+}
+
+// AddUser registers a user so that memberships and messages referring to it
+// carry its display name and type.
+func (s *Server) AddUser(user api.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.Name] = &user
+}
+
+// FailNext makes the next request matching method and path (relative to the
+// API root, e.g. "spaces/AAAA/messages") fail with the given HTTP status.
+// Queued failures are consumed in order, which makes it easy to exercise
+// retry logic.
+func (s *Server) FailNext(method, path string, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure{
+		method: method,
+		path:   strings.Trim(path, "/"),
+		err:    newError(code, "Injected failure."),
+	})
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/batch" {
+		s.serveBatch(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := r.URL.Path
+	upload := false
+	if rest, ok := strings.CutPrefix(path, "/upload"); ok {
+		path, upload = rest, true
+	}
+	path, ok := strings.CutPrefix(path, "/v1/")
+	if !ok {
+		writeError(w, notFound("Unknown API version in %q.", r.URL.Path))
+		return
+	}
+	path = strings.Trim(path, "/")
+
+	for i, f := range s.failures {
+		if f.method == r.Method && f.path == path {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			writeError(w, f.err)
+			return
+		}
+	}
+
+	handler, vars, err := s.route(r.Method, path, upload)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp, err := handler(s, w, r, vars)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if resp == nil {
+		return
+	}
+	if fields := r.URL.Query().Get("fields"); fields != "" {
+		if resp, err = selectFields(resp, fields); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handlerFunc serves one endpoint. vars holds the path wildcards in order.
+// The returned value is written as JSON; a handler that writes the response
+// itself returns nil.
+type handlerFunc func(s *Server, w http.ResponseWriter, r *http.Request, vars []string) (any, error)
+
+type route struct {
+	method  string
+	pattern string
+	upload  bool
+	handler handlerFunc
+}
+
+// routes maps URL patterns to handlers. In a pattern, "*" matches one path
+// segment, "*:verb" a segment carrying a custom method, and a trailing "**"
+// the remainder of the path.
+var routes = []route{
+	{"GET", "spaces", false, (*Server).listSpaces},
+	{"POST", "spaces", false, (*Server).createSpace},
+	{"GET", "spaces:search", false, (*Server).searchSpaces},
+	{"POST", "spaces:setup", false, (*Server).setupSpace},
+	{"GET", "spaces:findDirectMessage", false, (*Server).findDirectMessage},
+	{"GET", "spaces/*", false, (*Server).getSpace},
+	{"PATCH", "spaces/*", false, (*Server).patchSpace},
+	{"DELETE", "spaces/*", false, (*Server).deleteSpace},
+	{"POST", "spaces/*:completeImport", false, (*Server).completeImport},
+
+	{"GET", "spaces/*/messages", false, (*Server).listMessages},
+	{"POST", "spaces/*/messages", false, (*Server).createMessage},
+	{"GET", "spaces/*/messages/*", false, (*Server).getMessage},
+	{"PATCH", "spaces/*/messages/*", false, (*Server).updateMessage},
+	{"PUT", "spaces/*/messages/*", false, (*Server).updateMessage},
+	{"DELETE", "spaces/*/messages/*", false, (*Server).deleteMessage},
+	{"GET", "spaces/*/messages/*/attachments/*", false, (*Server).getAttachment},
+
+	{"GET", "spaces/*/messages/*/reactions", false, (*Server).listReactions},
+	{"POST", "spaces/*/messages/*/reactions", false, (*Server).createReaction},
+	{"DELETE", "spaces/*/messages/*/reactions/*", false, (*Server).deleteReaction},
+
+	{"GET", "spaces/*/members", false, (*Server).listMembers},
+	{"POST", "spaces/*/members", false, (*Server).createMember},
+	{"GET", "spaces/*/members/*", false, (*Server).getMember},
+	{"PATCH", "spaces/*/members/*", false, (*Server).patchMember},
+	{"DELETE", "spaces/*/members/*", false, (*Server).deleteMember},
+
+	{"GET", "spaces/*/spaceEvents", false, (*Server).listEvents},
+	{"GET", "spaces/*/spaceEvents/*", false, (*Server).getEvent},
+
+	{"POST", "spaces/*/attachments:upload", false, (*Server).uploadAttachment},
+	{"POST", "spaces/*/attachments:upload", true, (*Server).uploadAttachment},
+	{"PUT", "spaces/*/attachments:upload", true, (*Server).resumeUpload},
+	{"GET", "media/**", false, (*Server).downloadMedia},
+
+	{"GET", "customEmojis", false, (*Server).listEmojis},
+	{"POST", "customEmojis", false, (*Server).createEmoji},
+	{"GET", "customEmojis/*", false, (*Server).getEmoji},
+	{"DELETE", "customEmojis/*", false, (*Server).deleteEmoji},
+
+	{"GET", "users/*/spaces/*/spaceReadState", false, (*Server).getSpaceReadState},
+	{"PATCH", "users/*/spaces/*/spaceReadState", false, (*Server).updateSpaceReadState},
+	{"GET", "users/*/spaces/*/threads/*/threadReadState", false, (*Server).getThreadReadState},
+	{"GET", "users/*/spaces/*/spaceNotificationSetting", false, (*Server).getNotificationSetting},
+	{"PATCH", "users/*/spaces/*/spaceNotificationSetting", false, (*Server).patchNotificationSetting},
+}
+
+// route finds the handler for a request path relative to the API root.
+func (s *Server) route(method, path string, upload bool) (handlerFunc, []string, error) {
+	segs := strings.Split(path, "/")
+	pathMatched := false
+	for _, rt := range routes {
+		vars, ok := matchPattern(strings.Split(rt.pattern, "/"), segs)
+		if !ok || rt.upload != upload {
+			continue
+		}
+		pathMatched = true
+		if rt.method == method {
+			return rt.handler, vars, nil
+		}
+	}
+	if pathMatched {
+		return nil, nil, newError(http.StatusMethodNotAllowed, "Method %s is not supported for %q.", method, path)
+	}
+	return nil, nil, notFound("The requested URL /v1/%s was not found on this server.", path)
+}
+
+func matchPattern(pattern, segs []string) ([]string, bool) {
+	var vars []string
+	for i, p := range pattern {
+		if p == "**" {
+			if i >= len(segs) {
+				return nil, false
+			}
+			return append(vars, strings.Join(segs[i:], "/")), true
+		}
+		if i >= len(segs) {
+			return nil, false
+		}
+		seg := segs[i]
+		switch {
+		case p == "*":
+			if seg == "" || strings.Contains(seg, ":") {
+				return nil, false
+			}
+			vars = append(vars, seg)
+		case strings.HasPrefix(p, "*:"):
+			id, ok := strings.CutSuffix(seg, p[1:])
+			if !ok || id == "" {
+				return nil, false
+			}
+			vars = append(vars, id)
+		default:
+			if p != seg {
+				return nil, false
+			}
+		}
+	}
+	return vars, len(pattern) == len(segs)
+}
+
+// Error is a Google API error, serialized as
+// {"error": {"code": ..., "message": ..., "status": ...}}.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Code, e.Status, e.Message)
+}
+
+// grpcStatus maps HTTP status codes to the canonical status names the Chat
+// API reports in error envelopes.
+var grpcStatus = map[int]string{
+	http.StatusBadRequest:          "INVALID_ARGUMENT",
+	http.StatusUnauthorized:        "UNAUTHENTICATED",
+	http.StatusForbidden:           "PERMISSION_DENIED",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusMethodNotAllowed:    "UNIMPLEMENTED",
+	http.StatusConflict:            "ALREADY_EXISTS",
+	http.StatusPreconditionFailed:  "FAILED_PRECONDITION",
+	http.StatusTooManyRequests:     "RESOURCE_EXHAUSTED",
+	http.StatusInternalServerError: "INTERNAL",
+	http.StatusNotImplemented:      "UNIMPLEMENTED",
+	http.StatusBadGateway:          "UNAVAILABLE",
+	http.StatusServiceUnavailable:  "UNAVAILABLE",
+	http.StatusGatewayTimeout:      "DEADLINE_EXCEEDED",
+}
+
+func newError(code int, format string, args ...any) *Error {
+	status, ok := grpcStatus[code]
+	if !ok {
+		status = "UNKNOWN"
+	}
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), Status: status}
+}
+
+func invalidArgument(format string, args ...any) *Error {
+	return newError(http.StatusBadRequest, format, args...)
+}
+
+func notFound(format string, args ...any) *Error {
+	return newError(http.StatusNotFound, format, args...)
+}
+
+func permissionDenied(format string, args ...any) *Error {
+	return newError(http.StatusForbidden, format, args...)
+}
+
+func alreadyExists(format string, args ...any) *Error {
+	return newError(http.StatusConflict, format, args...)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*Error)
+	if !ok {
+		apiErr = newError(http.StatusInternalServerError, "%v", err)
+	}
+	writeJSON(w, apiErr.Code, map[string]any{"error": apiErr})
+}
+
+// decodeBody decodes a JSON request body into v, rejecting unknown fields
+// the way the real API does.
+func decodeBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return invalidArgument("Invalid JSON payload received. %v", err)
+	}
+	return nil
+}
+
+// boolParam parses an optional boolean query parameter.
+func boolParam(r *http.Request, name string) (bool, error) {
+	switch v := r.URL.Query().Get(name); v {
+	case "", "false":
+		return false, nil
+	case "true":
+		return true, nil
+	default:
+		return false, invalidArgument("Invalid value %q for %s.", v, name)
+	}
+}
+
+// now returns a strictly increasing timestamp so that resources created in
+// quick succession still sort deterministically by time.
+func (s *Server) now() string {
+	t := time.Now().UTC()
+	if !t.After(s.last) {
+		t = s.last.Add(time.Microsecond)
+	}
+	s.last = t
+	return t.Format(time.RFC3339Nano)
+}
+
+// newID returns a fresh resource ID with the given prefix.
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%07d", prefix, s.nextID)
+}
+
+// resolveUser expands the "users/me" alias to the caller's resource name.
+func (s *Server) resolveUser(name string) string {
+	if name == "users/me" {
+		return s.caller.Name
+	}
+	return name
+}
+
+// userInfo returns the stored profile for a user name, or a bare user.
+func (s *Server) userInfo(name string) *api.User {
+	if u, ok := s.users[name]; ok {
+		return clone(u)
+	}
+	return &api.User{Name: name, Type: "HUMAN"}
+}
+
+// adminAccess reads the useAdminAccess parameter and rejects it for callers
+// that are not administrators.
+func (s *Server) adminAccess(r *http.Request) (bool, error) {
+	admin, err := boolParam(r, "useAdminAccess")
+	if err != nil {
+		return false, err
+	}
+	if admin && !s.admin {
+		return false, permissionDenied("The caller is not a Google Workspace administrator and cannot use admin access.")
+	}
+	return admin, nil
+}
+
+// memberName returns the membership resource name of a user in a space.
+func memberName(space, user string) string {
+	return space + "/members/" + strings.TrimPrefix(user, "users/")
+}
+
+// joined returns the caller's (or user's) joined membership in a space.
+func (s *Server) joined(space, user string) *api.Membership {
+	m, ok := s.members.get(memberName(space, user))
+	if !ok || m.State != "JOINED" {
+		return nil
+	}
+	return m
+}
+
+// lookupSpace returns the stored space and checks that the caller may see
+// it: either as a joined member or through admin access.
+func (s *Server) lookupSpace(id string, admin bool) (*api.Space, error) {
+	name := "spaces/" + id
+	sp, ok := s.spaces.get(name)
+	if !ok {
+		return nil, notFound("Space %s not found.", name)
+	}
+	if !admin && s.joined(name, s.caller.Name) == nil {
+		return nil, permissionDenied("The caller does not have permission to access %s.", name)
+	}
+	return sp, nil
+}
+
+// isManager reports whether the caller manages the space.
+func (s *Server) isManager(space string) bool {
+	m := s.joined(space, s.caller.Name)
+	return m != nil && m.Role == "ROLE_MANAGER"
+}
+
+// emit records a space event.
+func (s *Server) emit(space, eventType string, fill func(*api.SpaceEvent)) {
+	ev := &api.SpaceEvent{
+		Name:      space + "/spaceEvents/" + s.newID("EV"),
+		EventTime: s.now(),
+		EventType: "google.workspace.chat." + eventType,
+	}
+	fill(ev)
+	s.events.put(ev.Name, ev)
+}
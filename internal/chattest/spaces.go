@@ -0,0 +1,404 @@
+package chattest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// AddSpace stores a space and returns the stored copy. If the space has no
+// name, one is assigned. The given users become joined members, the first as
+// manager; without users, the caller becomes the space's only manager.
+func (s *Server) AddSpace(space api.Space, members ...string) *api.Space {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(members) == 0 {
+		members = []string{s.caller.Name}
+	}
+	sp := s.insertSpace(&space, members[0])
+	for _, user := range members[1:] {
+		s.insertMember(sp.Name, s.userInfo(user), "ROLE_MEMBER", "JOINED")
+	}
+	s.recount(sp.Name)
+	return clone(sp)
+}
+
+// insertSpace stores a new space, making creator its manager.
+func (s *Server) insertSpace(sp *api.Space, creator string) *api.Space {
+	if sp.Name == "" {
+		sp.Name = "spaces/" + s.newID("AAAA")
+	}
+	if sp.SpaceType == "" {
+		sp.SpaceType = "SPACE"
+	}
+	if sp.CreateTime == "" {
+		sp.CreateTime = s.now()
+	}
+	if sp.LastActiveTime == "" {
+		sp.LastActiveTime = sp.CreateTime
+	}
+	if sp.SpaceHistoryState == "" {
+		sp.SpaceHistoryState = "HISTORY_ON"
+	}
+	if sp.SpaceThreadingState == "" {
+		sp.SpaceThreadingState = "THREADED_MESSAGES"
+	}
+	sp.Threaded = sp.SpaceThreadingState == "THREADED_MESSAGES"
+	sp.SpaceURI = "https://mail.google.com/chat/u/0/#chat/space/" + strings.TrimPrefix(sp.Name, "spaces/")
+	s.spaces.put(sp.Name, sp)
+	s.insertMember(sp.Name, s.userInfo(creator), "ROLE_MANAGER", "JOINED")
+	return sp
+}
+
+// recount refreshes a space's membershipCount.
+func (s *Server) recount(space string) {
+	sp, ok := s.spaces.get(space)
+	if !ok {
+		return
+	}
+	count := &api.MembershipCount{}
+	for _, m := range s.members.list(space + "/members/") {
+		if m.State != "JOINED" {
+			continue
+		}
+		switch {
+		case m.GroupMember != nil:
+			count.JoinedGroupCount++
+		case m.Member != nil && m.Member.Type == "HUMAN":
+			count.JoinedDirectHumanUserCount++
+		}
+	}
+	sp.MembershipCount = count
+}
+
+// spaceByDisplayName finds a named space, used to reject duplicates.
+func (s *Server) spaceByDisplayName(displayName string) *api.Space {
+	for _, sp := range s.spaces.list("spaces/") {
+		if sp.SpaceType == "SPACE" && strings.EqualFold(sp.DisplayName, displayName) {
+			return sp
+		}
+	}
+	return nil
+}
+
+func (s *Server) listSpaces(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	f, err := parseFilter(r.URL.Query().Get("filter"), "spaceType")
+	if err != nil {
+		return nil, invalidArgument("Invalid filter: %v", err)
+	}
+
+	var spaces []*api.Space
+	for _, sp := range s.spaces.list("spaces/") {
+		if s.joined(sp.Name, s.caller.Name) != nil && f.matches(sp, nil) {
+			spaces = append(spaces, sp)
+		}
+	}
+
+	page, next, err := paginate(r, spaces, 100, 1000)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListSpacesResponse{Spaces: page, NextPageToken: next}, nil
+}
+
+func (s *Server) createSpace(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	var sp api.Space
+	if err := decodeBody(r, &sp); err != nil {
+		return nil, err
+	}
+
+	requestID := r.URL.Query().Get("requestId")
+	if name, ok := s.requestIDs["spaces:"+requestID]; ok && requestID != "" {
+		if existing, ok := s.spaces.get(name); ok {
+			return existing, nil
+		}
+	}
+
+	if sp.Name != "" {
+		return nil, invalidArgument("Space name must not be set on create.")
+	}
+	switch sp.SpaceType {
+	case "SPACE":
+		if sp.DisplayName == "" {
+			return nil, invalidArgument("displayName is required for spaces of type SPACE.")
+		}
+		if s.spaceByDisplayName(sp.DisplayName) != nil {
+			return nil, alreadyExists("A space with display name %q already exists.", sp.DisplayName)
+		}
+	case "GROUP_CHAT", "DIRECT_MESSAGE":
+		if !sp.ImportMode {
+			return nil, invalidArgument("Spaces of type %s can only be created with spaces.setup or in import mode.", sp.SpaceType)
+		}
+	case "":
+		return nil, invalidArgument("spaceType is required.")
+	default:
+		return nil, invalidArgument("Invalid spaceType %q.", sp.SpaceType)
+	}
+
+	created := s.insertSpace(&sp, s.caller.Name)
+	s.recount(created.Name)
+	if requestID != "" {
+		s.requestIDs["spaces:"+requestID] = created.Name
+	}
+	return created, nil
+}
+
+func (s *Server) getSpace(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.lookupSpace(vars[0], admin)
+}
+
+func (s *Server) patchSpace(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := s.lookupSpace(vars[0], admin)
+	if err != nil {
+		return nil, err
+	}
+	if !admin && !s.isManager(sp.Name) {
+		return nil, permissionDenied("Only space managers can update %s.", sp.Name)
+	}
+
+	var patch api.Space
+	if err := decodeBody(r, &patch); err != nil {
+		return nil, err
+	}
+	updated := clone(sp)
+	if err := applyMask(updated, &patch, r.URL.Query().Get("updateMask"),
+		"displayName", "spaceType", "spaceDetails", "spaceHistoryState",
+		"accessSettings", "permissionSettings"); err != nil {
+		return nil, err
+	}
+	if sp.SpaceType != updated.SpaceType && !(sp.SpaceType == "GROUP_CHAT" && updated.SpaceType == "SPACE") {
+		return nil, invalidArgument("Only GROUP_CHAT spaces can be converted to SPACE.")
+	}
+	if updated.SpaceType == "SPACE" && updated.DisplayName == "" {
+		return nil, invalidArgument("displayName is required for spaces of type SPACE.")
+	}
+
+	s.spaces.put(sp.Name, updated)
+	s.emit(sp.Name, "space.v1.updated", func(ev *api.SpaceEvent) {
+		ev.SpaceUpdatedEventData = &api.SpaceUpdatedEventData{Space: clone(updated)}
+	})
+	return updated, nil
+}
+
+func (s *Server) deleteSpace(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := s.lookupSpace(vars[0], admin)
+	if err != nil {
+		return nil, err
+	}
+	if !admin && !s.isManager(sp.Name) {
+		return nil, permissionDenied("Only space managers can delete %s.", sp.Name)
+	}
+
+	prefix := sp.Name + "/"
+	for _, m := range s.members.list(prefix) {
+		s.members.delete(m.Name)
+	}
+	for _, m := range s.messages.list(prefix) {
+		s.messages.delete(m.Name)
+	}
+	for _, rc := range s.reactions.list(prefix) {
+		s.reactions.delete(rc.Name)
+	}
+	for _, ev := range s.events.list(prefix) {
+		s.events.delete(ev.Name)
+	}
+	s.spaces.delete(sp.Name)
+	return &api.Empty{}, nil
+}
+
+func (s *Server) searchSpaces(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	admin, err := s.adminAccess(r)
+	if err != nil {
+		return nil, err
+	}
+	if !admin {
+		return nil, invalidArgument("useAdminAccess must be set to true for spaces.search.")
+	}
+
+	q, err := parseFilter(r.URL.Query().Get("query"),
+		"customer", "spaceType", "displayName", "externalUserAllowed",
+		"spaceHistoryState", "createTime", "lastActiveTime")
+	if err != nil {
+		return nil, invalidArgument("Invalid query: %v", err)
+	}
+	if !q.has("customer") {
+		return nil, invalidArgument("The query must specify customer = \"customers/my_customer\".")
+	}
+	customer := func(field, op, value string) (bool, bool) {
+		if field != "customer" {
+			return false, false
+		}
+		return value == "customers/my_customer", true
+	}
+
+	var spaces []*api.Space
+	for _, sp := range s.spaces.list("spaces/") {
+		if q.matches(sp, customer) {
+			spaces = append(spaces, sp)
+		}
+	}
+
+	if err := sortSpaces(spaces, r.URL.Query().Get("orderBy")); err != nil {
+		return nil, err
+	}
+
+	page, next, err := paginate(r, spaces, 100, 1000)
+	if err != nil {
+		return nil, err
+	}
+	return &api.SearchSpacesResponse{Spaces: page, NextPageToken: next, TotalSize: int64(len(spaces))}, nil
+}
+
+// sortSpaces orders search results as spaces.search does. An empty orderBy
+// sorts by createTime ascending.
+func sortSpaces(spaces []*api.Space, orderBy string) error {
+	field, dir, _ := strings.Cut(strings.TrimSpace(orderBy), " ")
+	desc := false
+	switch strings.ToUpper(strings.TrimSpace(dir)) {
+	case "", "ASC":
+	case "DESC":
+		desc = true
+	default:
+		return invalidArgument("Invalid orderBy direction %q.", dir)
+	}
+
+	var less func(a, b *api.Space) bool
+	switch camelField(field) {
+	case "", "createTime":
+		less = func(a, b *api.Space) bool { return a.CreateTime < b.CreateTime }
+	case "lastActiveTime":
+		less = func(a, b *api.Space) bool { return a.LastActiveTime < b.LastActiveTime }
+	case "membershipCount.joinedDirectHumanUserCount":
+		count := func(sp *api.Space) int64 {
+			if sp.MembershipCount == nil {
+				return 0
+			}
+			return sp.MembershipCount.JoinedDirectHumanUserCount
+		}
+		less = func(a, b *api.Space) bool { return count(a) < count(b) }
+	default:
+		return invalidArgument("Invalid orderBy field %q.", field)
+	}
+
+	sort.SliceStable(spaces, func(i, j int) bool {
+		if desc {
+			return less(spaces[j], spaces[i])
+		}
+		return less(spaces[i], spaces[j])
+	})
+	return nil
+}
+
+func (s *Server) setupSpace(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	var req api.SetUpSpaceRequest
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Space == nil {
+		return nil, invalidArgument("space is required.")
+	}
+	if name, ok := s.requestIDs["spaces:"+req.RequestID]; ok && req.RequestID != "" {
+		if existing, ok := s.spaces.get(name); ok {
+			return existing, nil
+		}
+	}
+
+	var users []string
+	for _, m := range req.Memberships {
+		if m.Member == nil || m.Member.Name == "" {
+			return nil, invalidArgument("Every membership must name a member.")
+		}
+		users = append(users, s.resolveUser(m.Member.Name))
+	}
+
+	switch req.Space.SpaceType {
+	case "SPACE":
+		if req.Space.DisplayName == "" {
+			return nil, invalidArgument("displayName is required for spaces of type SPACE.")
+		}
+		if s.spaceByDisplayName(req.Space.DisplayName) != nil {
+			return nil, alreadyExists("A space with display name %q already exists.", req.Space.DisplayName)
+		}
+	case "GROUP_CHAT":
+		if req.Space.DisplayName != "" {
+			return nil, invalidArgument("Group chats cannot have a displayName.")
+		}
+		if len(users) < 2 {
+			return nil, invalidArgument("Group chats need at least two other members.")
+		}
+	case "DIRECT_MESSAGE":
+		if len(users) != 1 {
+			return nil, invalidArgument("Direct messages need exactly one other member.")
+		}
+		if dm := s.directMessage(users[0]); dm != nil {
+			return dm, nil
+		}
+	default:
+		return nil, invalidArgument("Invalid spaceType %q.", req.Space.SpaceType)
+	}
+
+	sp := clone(req.Space)
+	sp.Name = ""
+	created := s.insertSpace(sp, s.caller.Name)
+	for _, user := range users {
+		if user != s.caller.Name {
+			s.insertMember(created.Name, s.userInfo(user), "ROLE_MEMBER", "JOINED")
+		}
+	}
+	s.recount(created.Name)
+	if req.RequestID != "" {
+		s.requestIDs["spaces:"+req.RequestID] = created.Name
+	}
+	return created, nil
+}
+
+// directMessage returns the direct message space between the caller and
+// user, if there is one.
+func (s *Server) directMessage(user string) *api.Space {
+	for _, sp := range s.spaces.list("spaces/") {
+		if sp.SpaceType == "DIRECT_MESSAGE" && s.joined(sp.Name, s.caller.Name) != nil && s.joined(sp.Name, user) != nil {
+			return sp
+		}
+	}
+	return nil
+}
+
+func (s *Server) findDirectMessage(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	user := r.URL.Query().Get("name")
+	if user == "" {
+		return nil, invalidArgument("name is required.")
+	}
+	dm := s.directMessage(s.resolveUser(user))
+	if dm == nil {
+		return nil, notFound("No direct message with %s found.", user)
+	}
+	return dm, nil
+}
+
+func (s *Server) completeImport(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	sp, err := s.lookupSpace(vars[0], true)
+	if err != nil {
+		return nil, err
+	}
+	if !sp.ImportMode {
+		return nil, newError(http.StatusPreconditionFailed, "Space %s is not in import mode.", sp.Name)
+	}
+	sp.ImportMode = false
+	sp.ImportModeExpireTime = ""
+	return &api.CompleteImportSpaceResponse{Space: clone(sp)}, nil
+}
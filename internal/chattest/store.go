@@ -0,0 +1,181 @@
+package chattest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// store keeps resources of one kind keyed by resource name, remembering
+// insertion order so that listings are stable.
+type store[T any] struct {
+	seq  int
+	rows map[string]*row[T]
+}
+
+type row[T any] struct {
+	seq int
+	v   *T
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{rows: make(map[string]*row[T])}
+}
+
+// put inserts or replaces the resource stored under name. Replacing keeps
+// the original position in listings.
+func (s *store[T]) put(name string, v *T) {
+	if r, ok := s.rows[name]; ok {
+		r.v = v
+		return
+	}
+	s.seq++
+	s.rows[name] = &row[T]{seq: s.seq, v: v}
+}
+
+func (s *store[T]) get(name string) (*T, bool) {
+	r, ok := s.rows[name]
+	if !ok {
+		return nil, false
+	}
+	return r.v, true
+}
+
+func (s *store[T]) delete(name string) {
+	delete(s.rows, name)
+}
+
+// list returns the resources whose name starts with prefix, in insertion
+// order.
+func (s *store[T]) list(prefix string) []*T {
+	rows := make([]*row[T], 0, len(s.rows))
+	for name, r := range s.rows {
+		if strings.HasPrefix(name, prefix) {
+			rows = append(rows, r)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].seq < rows[j].seq })
+
+	out := make([]*T, len(rows))
+	for i, r := range rows {
+		out[i] = r.v
+	}
+	return out
+}
+
+// paginate returns the page of items selected by the request's pageSize and
+// pageToken parameters, and the token of the following page. Like the real
+// API, a pageSize of 0 selects defaultSize and values above maxSize are
+// clamped.
+func paginate[T any](r *http.Request, items []*T, defaultSize, maxSize int) ([]*T, string, error) {
+	q := r.URL.Query()
+
+	size := defaultSize
+	if v := q.Get("pageSize"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, "", invalidArgument("Invalid pageSize %q.", v)
+		}
+		if n > 0 {
+			size = n
+		}
+	}
+	if size > maxSize {
+		size = maxSize
+	}
+
+	offset := 0
+	if tok := q.Get("pageToken"); tok != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(tok)
+		if err == nil {
+			offset, err = strconv.Atoi(strings.TrimPrefix(string(raw), "offset:"))
+		}
+		if err != nil || offset < 0 || offset > len(items) {
+			return nil, "", invalidArgument("Invalid page token.")
+		}
+	}
+
+	end := offset + size
+	if end >= len(items) {
+		return items[offset:], "", nil
+	}
+	next := base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(end)))
+	return items[offset:end], next, nil
+}
+
+// applyMask copies the fields named in updateMask from patch to dst. Mask
+// paths are top-level resource fields in snake_case or camelCase; "*"
+// replaces every field present in patch. Fields not listed in allowed are
+// rejected.
+func applyMask[T any](dst, patch *T, updateMask string, allowed ...string) error {
+	if strings.TrimSpace(updateMask) == "" {
+		return invalidArgument("updateMask is required.")
+	}
+
+	var dstFields, patchFields map[string]json.RawMessage
+	if err := roundTrip(dst, &dstFields); err != nil {
+		return err
+	}
+	if err := roundTrip(patch, &patchFields); err != nil {
+		return err
+	}
+
+	allow := map[string]bool{}
+	for _, a := range allowed {
+		allow[a] = true
+	}
+
+	var paths []string
+	for _, p := range strings.Split(updateMask, ",") {
+		p = camelField(strings.TrimSpace(p))
+		if p == "*" {
+			paths = allowed
+			break
+		}
+		// Nested paths such as "space_details.description" update the
+		// whole top-level field.
+		p, _, _ = strings.Cut(p, ".")
+		if !allow[p] {
+			return invalidArgument("Invalid field %q in updateMask.", p)
+		}
+		paths = append(paths, p)
+	}
+
+	for _, p := range paths {
+		if v, ok := patchFields[p]; ok {
+			dstFields[p] = v
+		} else {
+			delete(dstFields, p)
+		}
+	}
+
+	updated := new(T)
+	if err := roundTrip(dstFields, updated); err != nil {
+		return err
+	}
+	*dst = *updated
+	return nil
+}
+
+// roundTrip converts between two JSON-compatible representations.
+func roundTrip(from, to any) error {
+	raw, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, to)
+}
+
+// clone returns a deep copy of v so that handlers never hand out pointers
+// into the server's state.
+func clone[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	out := new(T)
+	_ = roundTrip(v, out)
+	return out
+}
@@ -0,0 +1,144 @@
+package chattest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+)
+
+// lookupUserSpace checks the users/{user}/spaces/{space} prefix shared by
+// read state and notification setting names: users may only address their
+// own settings, in spaces they have joined. It returns the canonical name
+// prefix using the caller's resource name.
+func (s *Server) lookupUserSpace(user, space string) (string, error) {
+	if s.resolveUser("users/"+user) != s.caller.Name {
+		return "", permissionDenied("Callers can only access their own settings.")
+	}
+	sp, err := s.lookupSpace(space, false)
+	if err != nil {
+		return "", err
+	}
+	return s.caller.Name + "/" + sp.Name, nil
+}
+
+func (s *Server) getSpaceReadState(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	prefix, err := s.lookupUserSpace(vars[0], vars[1])
+	if err != nil {
+		return nil, err
+	}
+	name := prefix + "/spaceReadState"
+	if st, ok := s.readStates[name]; ok {
+		return st, nil
+	}
+	return &api.SpaceReadState{Name: name}, nil
+}
+
+func (s *Server) updateSpaceReadState(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	prefix, err := s.lookupUserSpace(vars[0], vars[1])
+	if err != nil {
+		return nil, err
+	}
+	name := prefix + "/spaceReadState"
+
+	var patch api.SpaceReadState
+	if err := decodeBody(r, &patch); err != nil {
+		return nil, err
+	}
+	st := &api.SpaceReadState{Name: name}
+	if cur, ok := s.readStates[name]; ok {
+		st = clone(cur)
+	}
+	if err := applyMask(st, &patch, r.URL.Query().Get("updateMask"), "lastReadTime"); err != nil {
+		return nil, err
+	}
+
+	// Like the real API, a read time past the newest message is clamped to
+	// that message, and an empty one marks the whole space as read.
+	latest := ""
+	for _, m := range s.messages.list("spaces/" + vars[1] + "/messages/") {
+		if m.DeleteTime == "" && m.CreateTime > latest {
+			latest = m.CreateTime
+		}
+	}
+	if st.LastReadTime != "" {
+		if _, err := time.Parse(time.RFC3339Nano, st.LastReadTime); err != nil {
+			return nil, invalidArgument("Invalid lastReadTime %q.", st.LastReadTime)
+		}
+	}
+	if st.LastReadTime == "" || compare(st.LastReadTime, ">", latest) {
+		st.LastReadTime = latest
+	}
+
+	s.readStates[name] = st
+	return st, nil
+}
+
+func (s *Server) getThreadReadState(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	prefix, err := s.lookupUserSpace(vars[0], vars[1])
+	if err != nil {
+		return nil, err
+	}
+	thread := "spaces/" + vars[1] + "/threads/" + vars[2]
+	msgs := s.threadMessages(thread)
+	if len(msgs) == 0 {
+		return nil, notFound("Thread %s not found.", thread)
+	}
+
+	// Thread read state follows the space read state, capped at the newest
+	// message in the thread.
+	st := &api.ThreadReadState{Name: prefix + "/threads/" + vars[2] + "/threadReadState"}
+	if space, ok := s.readStates[prefix+"/spaceReadState"]; ok {
+		st.LastReadTime = space.LastReadTime
+		if newest := msgs[len(msgs)-1].CreateTime; compare(st.LastReadTime, ">", newest) {
+			st.LastReadTime = newest
+		}
+	}
+	return st, nil
+}
+
+func (s *Server) getNotificationSetting(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	prefix, err := s.lookupUserSpace(vars[0], vars[1])
+	if err != nil {
+		return nil, err
+	}
+	return s.notificationSetting(prefix + "/spaceNotificationSetting"), nil
+}
+
+// notificationSetting returns the stored setting or the default one.
+func (s *Server) notificationSetting(name string) *api.SpaceNotificationSetting {
+	if st, ok := s.notifications[name]; ok {
+		return st
+	}
+	return &api.SpaceNotificationSetting{Name: name, NotificationSetting: "ALL", MuteSetting: "UNMUTED"}
+}
+
+func (s *Server) patchNotificationSetting(w http.ResponseWriter, r *http.Request, vars []string) (any, error) {
+	prefix, err := s.lookupUserSpace(vars[0], vars[1])
+	if err != nil {
+		return nil, err
+	}
+	name := prefix + "/spaceNotificationSetting"
+
+	var patch api.SpaceNotificationSetting
+	if err := decodeBody(r, &patch); err != nil {
+		return nil, err
+	}
+	st := clone(s.notificationSetting(name))
+	if err := applyMask(st, &patch, r.URL.Query().Get("updateMask"), "notificationSetting", "muteSetting"); err != nil {
+		return nil, err
+	}
+	switch st.NotificationSetting {
+	case "ALL", "MAIN_CONVERSATIONS", "FOR_YOU", "OFF":
+	default:
+		return nil, invalidArgument("Invalid notificationSetting %q.", st.NotificationSetting)
+	}
+	switch st.MuteSetting {
+	case "UNMUTED", "MUTED":
+	default:
+		return nil, invalidArgument("Invalid muteSetting %q.", st.MuteSetting)
+	}
+
+	s.notifications[name] = st
+	return st, nil
+}
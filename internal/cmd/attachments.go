@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -42,32 +41,13 @@ func newAttachmentsGetCmd() *cobra.Command {
 
 			name := args[0]
 
-			raw, err := svc.Get(cmd.Context(), name)
+			attachment, err := svc.Get(cmd.Context(), name)
 			if err != nil {
 				return fmt.Errorf("getting attachment: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			// Parse the attachment for human-readable output.
-			var attachment struct {
-				Name              string `json:"name"`
-				ContentName       string `json:"contentName"`
-				ContentType       string `json:"contentType"`
-				DownloadURI       string `json:"downloadUri"`
-				Source            string `json:"source"`
-				ThumbnailURI      string `json:"thumbnailUri"`
-				AttachmentDataRef struct {
-					ResourceName string `json:"resourceName"`
-				} `json:"attachmentDataRef"`
-				DriveDataRef struct {
-					DriveFileID string `json:"driveFileId"`
-				} `json:"driveDataRef"`
-			}
-			if err := json.Unmarshal(raw, &attachment); err != nil {
-				return fmt.Errorf("parsing response: %w", err)
+				return formatter.Print(attachment)
 			}
 
 			fmt.Printf("Name:          %s\n", attachment.Name)
@@ -76,13 +56,11 @@ func newAttachmentsGetCmd() *cobra.Command {
 			fmt.Printf("Download URI:  %s\n", attachment.DownloadURI)
 			fmt.Printf("Source:        %s\n", attachment.Source)
 			fmt.Printf("Thumbnail URI: %s\n", attachment.ThumbnailURI)
-
-			// Show size if available from the raw JSON.
-			var rawMap map[string]json.RawMessage
-			if err := json.Unmarshal(raw, &rawMap); err == nil {
-				if sizeRaw, ok := rawMap["sizeBytes"]; ok {
-					fmt.Printf("Size:          %s bytes\n", string(sizeRaw))
-				}
+			if attachment.AttachmentDataRef != nil && attachment.AttachmentDataRef.ResourceName != "" {
+				fmt.Printf("Resource Name: %s\n", attachment.AttachmentDataRef.ResourceName)
+			}
+			if attachment.DriveDataRef != nil && attachment.DriveDataRef.DriveFileID != "" {
+				fmt.Printf("Drive File ID: %s\n", attachment.DriveDataRef.DriveFileID)
 			}
 
 			return nil
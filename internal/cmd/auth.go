@@ -1,14 +1,25 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
 
+	"github.com/cipher-shad0w/gogchat/internal/api"
 	"github.com/cipher-shad0w/gogchat/internal/auth"
 	"github.com/cipher-shad0w/gogchat/internal/config"
+	"github.com/cipher-shad0w/gogchat/internal/output"
 )
 
 // NewAuthCmd creates the top-level "auth" command with login, logout, and
@@ -24,6 +35,10 @@ func NewAuthCmd() *cobra.Command {
 		newLoginCmd(),
 		newLogoutCmd(),
 		newStatusCmd(),
+		newListCmd(),
+		newSwitchCmd(),
+		newMigrateStoreCmd(),
+		newWhoamiCmd(),
 	)
 
 	return cmd
@@ -69,76 +84,327 @@ func tokenPath() string {
 	return cfg.TokenFile
 }
 
+// tokenStore returns the token store configured for the active profile.
+func tokenStore() (auth.TokenStore, error) {
+	return newTokenStore(Cfg.TokenStore, tokenPath(), Cfg.TokenKeyFile)
+}
+
+// newTokenStore returns a token store of the given kind at path, with the
+// remaining settings taken from the active profile.
+func newTokenStore(kind, path, keyFile string) (auth.TokenStore, error) {
+	return auth.NewTokenStore(auth.StoreOptions{
+		Kind:          kind,
+		Path:          path,
+		KeyFile:       keyFile,
+		Passphrase:    os.Getenv("GOGCHAT_TOKEN_PASSPHRASE"),
+		ReadCommand:   Cfg.TokenCommandRead,
+		WriteCommand:  Cfg.TokenCommandWrite,
+		DeleteCommand: Cfg.TokenCommandDelete,
+	})
+}
+
+// activeProfile returns the name of the profile in use.
+func activeProfile() string {
+	if name := viper.GetString("profile"); name != "" {
+		return name
+	}
+	return config.DefaultProfile
+}
+
+// loginCommand returns the command that logs in to the active profile.
+func loginCommand() string {
+	if name := activeProfile(); name != config.DefaultProfile {
+		return "gogchat auth login --profile " + name
+	}
+	return "gogchat auth login"
+}
+
 // newLoginCmd creates the "auth login" subcommand.
 func newLoginCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate with Google Chat API via OAuth2",
-		Long:  "Run the interactive OAuth2 authorization flow, open a browser for consent, and save the resulting token locally.",
+		Long: `Run the interactive OAuth2 authorization flow, open a browser for consent, and save the resulting token locally.
+
+Without a local browser, e.g. over SSH or in a container, use --no-browser:
+it prints the consent URL to open on any machine and asks for the URL the
+browser is redirected to. --device uses the OAuth2 device flow instead,
+which only works with OAuth clients of the "TVs and Limited Input devices"
+type.
+
+With --service-account KEY.json, gogchat authenticates as a Chat app
+instead of a user: commands then call the API with the service account's
+key and the chat.bot scope, which app-only features such as private
+messages require. The key file's path is saved to the config file as
+service_account_key, along with auth_mode: app. Logging in without
+--service-account switches back to user authentication.
+
+A login requests the standard Chat scopes. --scopes adds scope bundles
+that need admin privileges or Google's approval, or single scopes:
+
+  admin   chat.admin.spaces[.readonly], chat.admin.memberships[.readonly]
+          and chat.admin.delete, for Workspace admins with --admin
+  import  chat.import, for import mode spaces
+  delete  chat.delete
+  apps    chat.memberships.app, to add Chat apps to spaces
+  email   userinfo.email, for 'auth whoami' on logins from before it
+
+Scopes granted earlier are kept, so --scopes works without logging out.
+
+With --profile NAME, the token is stored for that profile, which is
+created in the config file if needed; --client-id and --client-secret are
+then saved to the profile too.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if key, _ := cmd.Flags().GetString("service-account"); key != "" {
+				return loginServiceAccount(cmd, key)
+			}
+
 			clientID, clientSecret, err := resolveCredentials(cmd)
 			if err != nil {
 				return err
 			}
 
-			path := tokenPath()
+			store, err := tokenStore()
+			if err != nil {
+				return err
+			}
+
+			bundles, _ := cmd.Flags().GetStringSlice("scopes")
+			scopes, err := auth.ResolveScopes(append([]string{"default"}, bundles...))
+			if err != nil {
+				return err
+			}
 
-			// If the user is already logged in, ask before re-authenticating.
-			if auth.TokenExists(path) {
+			// If the user is already logged in, ask before re-authenticating,
+			// unless they are only adding scopes.
+			switch {
+			case store.Exists() && len(bundles) > 0 && Cfg.AuthMode != api.AuthApp:
+				fmt.Println("Adding scopes to your login.")
+			case store.Exists():
 				fmt.Println("You are already logged in.")
 				fmt.Print("Do you want to re-authenticate? [y/N]: ")
 
-				var answer string
-				fmt.Scanln(&answer)
+				answer, err := readLine(cmd.Context())
+				if err != nil && !errors.Is(err, io.EOF) {
+					return err
+				}
 				if answer != "y" && answer != "Y" {
 					fmt.Println("Login cancelled.")
 					return nil
 				}
 			}
 
-			token, err := auth.Login(clientID, clientSecret)
+			flow := auth.FlowBrowser
+			if noBrowser, _ := cmd.Flags().GetBool("no-browser"); noBrowser {
+				flow = auth.FlowManual
+			}
+			if device, _ := cmd.Flags().GetBool("device"); device {
+				flow = auth.FlowDevice
+			}
+			stored, err := userLogin(cmd.Context(), flow, clientID, clientSecret, scopes)
 			if err != nil {
-				return fmt.Errorf("login failed: %w", err)
+				return err
 			}
+			keepRefreshToken(store, stored)
 
-			if err := auth.SaveToken(path, token); err != nil {
+			if err := auth.SaveToken(store, stored); err != nil {
 				return fmt.Errorf("saving token: %w", err)
 			}
+			if err := registerProfile(cmd); err != nil {
+				return err
+			}
+			if Cfg.AuthMode == api.AuthApp {
+				if err := saveAuthMode(api.AuthUser); err != nil {
+					return err
+				}
+			}
 
 			fmt.Println("✓ Successfully logged in!")
-			fmt.Printf("  Token saved to: %s\n", path)
+			fmt.Printf("  Token saved to: %s\n", store)
 			return nil
 		},
 	}
 
 	cmd.Flags().String("client-id", "", "Google OAuth2 client ID")
 	cmd.Flags().String("client-secret", "", "Google OAuth2 client secret")
+	cmd.Flags().Bool("no-browser", false, "Don't start a browser; paste the redirect URL into the terminal instead")
+	cmd.Flags().Bool("device", false, "Use the OAuth2 device authorization flow (needs a TV/limited-input OAuth client)")
+	cmd.Flags().StringSlice("scopes", nil, "Also request these scope `bundles` (admin, import, delete, apps, email) or single scopes, e.g. chat.admin.spaces")
+	cmd.Flags().String("service-account", "", "Authenticate as a Chat app with this service account JSON key `file`")
+	cmd.MarkFlagsMutuallyExclusive("no-browser", "device", "service-account")
 
 	return cmd
 }
 
+// userLogin runs the user login flow named by flow (auth.FlowBrowser,
+// auth.FlowManual or auth.FlowDevice) for scopes, and returns the token to
+// store. Prompts are read under ctx.
+func userLogin(ctx context.Context, flow, clientID, clientSecret string, scopes []string) (*auth.StoredToken, error) {
+	base, err := newBaseHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	readInput := func() (string, error) { return readLine(ctx) }
+	ctx = auth.WithHTTPClient(ctx, base)
+
+	var token *oauth2.Token
+	switch flow {
+	case auth.FlowDevice:
+		token, err = auth.LoginDevice(ctx, clientID, clientSecret, scopes)
+	case auth.FlowManual:
+		token, err = auth.LoginManual(ctx, clientID, clientSecret, scopes, readInput)
+	default:
+		flow = auth.FlowBrowser
+		token, err = auth.Login(ctx, clientID, clientSecret, scopes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+	return &auth.StoredToken{Token: *token, Flow: flow, Scopes: auth.GrantedScopes(token)}, nil
+}
+
+// keepRefreshToken gives fresh the refresh token of the token in store if
+// it came without one, as a repeated consent may. With incremental
+// authorization, the old refresh token covers the new scopes too.
+func keepRefreshToken(store auth.TokenStore, fresh *auth.StoredToken) {
+	if fresh.RefreshToken != "" {
+		return
+	}
+	if old, err := store.Load(); err == nil {
+		fresh.RefreshToken = old.RefreshToken
+	}
+}
+
+// loginServiceAccount switches the active profile to app authentication
+// with the service account key at path, after checking that the key can
+// obtain a token.
+func loginServiceAccount(cmd *cobra.Command, path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	sa, err := auth.LoadServiceAccount(path)
+	if err != nil {
+		return err
+	}
+
+	base, err := newBaseHTTPClient()
+	if err != nil {
+		return err
+	}
+	if _, err := sa.Token(auth.WithHTTPClient(cmd.Context(), base)); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	if err := registerProfile(cmd); err != nil {
+		return err
+	}
+	if err := config.Save(config.ProfileKey(activeProfile(), "service_account_key"), path); err != nil {
+		return err
+	}
+	if err := saveAuthMode(api.AuthApp); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Logged in as Chat app %s\n", sa.Email)
+	fmt.Printf("  Service account key: %s\n", path)
+	return nil
+}
+
+// saveAuthMode saves mode as the auth_mode of the active profile.
+func saveAuthMode(mode string) error {
+	if err := config.Save(config.ProfileKey(activeProfile(), "auth_mode"), mode); err != nil {
+		return fmt.Errorf("saving auth mode: %w", err)
+	}
+	return nil
+}
+
 // newLogoutCmd creates the "auth logout" subcommand.
 func newLogoutCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "logout",
 		Short: "Remove stored authentication token",
-		Long:  "Delete the locally stored OAuth2 token, effectively logging out.",
+		Long: `Revoke the stored OAuth2 token at Google and delete it, effectively
+logging out. Revoking the refresh token withdraws the access granted at
+login, so that a copy of the token left behind, e.g. in a backup, stops
+working too. With --local-only, only the stored token is deleted.
+
+When authenticated as a Chat app, switch back to user authentication
+instead; the service account key file is left in place.`,
+		Example: `  gogchat auth logout
+  gogchat auth logout --local-only   # e.g. offline; the token stays valid at Google`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			path := tokenPath()
+			if Cfg.AuthMode == api.AuthApp {
+				if err := saveAuthMode(api.AuthUser); err != nil {
+					return err
+				}
+				fmt.Println("✓ Stopped authenticating as a Chat app.")
+				fmt.Printf("  The service account key %s was left in place.\n", Cfg.ServiceAccountKey)
+				return nil
+			}
+
+			store, err := tokenStore()
+			if err != nil {
+				return err
+			}
 
-			if !auth.TokenExists(path) {
+			if !store.Exists() {
 				fmt.Println("Not currently logged in — nothing to do.")
 				return nil
 			}
 
-			if err := auth.DeleteToken(path); err != nil {
+			// Find out whether the token can be deleted before revoking it,
+			// so that a failed logout does not leave a dead token behind.
+			if err := auth.CanDelete(store); err != nil {
+				return fmt.Errorf("logout failed: %w", err)
+			}
+
+			localOnly, _ := cmd.Flags().GetBool("local-only")
+			if !localOnly {
+				if err := revokeToken(cmd.Context(), store); err != nil {
+					return fmt.Errorf("%w (the token was kept; use --local-only to delete it anyway)", err)
+				}
+			}
+
+			if err := auth.DeleteToken(store); err != nil {
 				return fmt.Errorf("logout failed: %w", err)
 			}
 
-			fmt.Println("✓ Successfully logged out.")
+			if localOnly {
+				fmt.Println("✓ Successfully logged out. The token was not revoked at Google.")
+			} else {
+				fmt.Println("✓ Successfully logged out and revoked the token.")
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().Bool("local-only", false, "Only delete the stored token, without revoking it at Google")
+
+	return cmd
+}
+
+// revokeToken revokes the token in store at Google: its refresh token,
+// which takes the access tokens along, or else its access token. A stored
+// token with neither has nothing to revoke.
+func revokeToken(ctx context.Context, store auth.TokenStore) error {
+	stored, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("loading token: %w", err)
+	}
+	token := stored.RefreshToken
+	if token == "" {
+		token = stored.AccessToken
+	}
+	if token == "" {
+		return nil
+	}
+
+	base, err := newBaseHTTPClient()
+	if err != nil {
+		return err
+	}
+	return auth.Revoke(auth.WithHTTPClient(ctx, base), token)
 }
 
 // newStatusCmd creates the "auth status" subcommand.
@@ -146,39 +412,570 @@ func newStatusCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
 		Short: "Show current authentication status",
-		Long:  "Check whether a valid OAuth2 token exists and display its expiry information.",
+		Long:  "Check whether a valid OAuth2 token exists and display its expiry information. When authenticated as a Chat app, show the service account instead.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			path := tokenPath()
+			if Cfg.Impersonate != "" {
+				sa, err := serviceAccount()
+				if err != nil {
+					fmt.Printf("✗ Cannot impersonate %s\n", Cfg.Impersonate)
+					fmt.Printf("  Error: %v\n", err)
+					return nil
+				}
+				fmt.Printf("✓ Impersonating %s\n", Cfg.Impersonate)
+				fmt.Printf("  Profile: %s\n", activeProfile())
+				fmt.Printf("  Service account: %s (client ID %s)\n", sa.Email, sa.ClientID)
+				fmt.Printf("  Service account key: %s\n", sa.Path)
+				fmt.Println("  Scopes: requested per command")
+				return nil
+			}
+
+			if externalToken() {
+				_, from := externalTokenSource()
+				fmt.Printf("✓ Using the access token from %s\n", from)
+				fmt.Printf("  Profile: %s\n", activeProfile())
+				fmt.Println("  The stored login is not used while it is set; run 'gogchat auth whoami' to check the token.")
+				return nil
+			}
+
+			if Cfg.AuthMode == api.AuthApp {
+				sa, err := serviceAccount()
+				if err != nil {
+					fmt.Println("✗ Not logged in (service account key cannot be read)")
+					fmt.Printf("  Error: %v\n", err)
+					fmt.Printf("  Run '%s --service-account KEY.json' to re-authenticate\n", loginCommand())
+					return nil
+				}
+				fmt.Printf("✓ Logged in as Chat app %s\n", sa.Email)
+				fmt.Printf("  Profile: %s\n", activeProfile())
+				fmt.Printf("  Auth mode: %s\n", api.AuthApp)
+				fmt.Printf("  Service account key: %s\n", sa.Path)
+				return nil
+			}
+
+			store, err := tokenStore()
+			if err != nil {
+				return err
+			}
 
-			if !auth.TokenExists(path) {
+			if !store.Exists() {
 				fmt.Println("✗ Not logged in")
-				fmt.Println("  Run 'gogchat auth login' to authenticate")
+				fmt.Printf("  Run '%s' to authenticate\n", loginCommand())
 				return nil
 			}
 
-			token, err := auth.LoadToken(path)
+			token, err := store.Load()
 			if err != nil {
-				fmt.Println("✗ Not logged in (token file is corrupt)")
+				fmt.Println("✗ Not logged in (stored token cannot be read)")
 				fmt.Printf("  Error: %v\n", err)
-				fmt.Println("  Run 'gogchat auth login' to re-authenticate")
+				fmt.Printf("  Run '%s' to re-authenticate\n", loginCommand())
 				return nil
 			}
 
 			if token.Expiry.IsZero() {
 				fmt.Println("✓ Logged in")
 				fmt.Println("  Token expires: (no expiry set)")
-				fmt.Printf("  Token file: %s\n", path)
 			} else if token.Expiry.Before(time.Now()) {
 				fmt.Println("✓ Logged in (token expired — will refresh on next use)")
 				fmt.Printf("  Token expired: %s\n", token.Expiry.UTC().Format("2006-01-02 15:04:05 UTC"))
-				fmt.Printf("  Token file: %s\n", path)
 			} else {
 				fmt.Println("✓ Logged in")
 				fmt.Printf("  Token expires: %s\n", token.Expiry.UTC().Format("2006-01-02 15:04:05 UTC"))
-				fmt.Printf("  Token file: %s\n", path)
+			}
+			if !token.Refreshed.IsZero() {
+				fmt.Printf("  Last refreshed: %s\n", token.Refreshed.UTC().Format("2006-01-02 15:04:05 UTC"))
+			}
+			switch {
+			case token.Email != "":
+				fmt.Printf("  User: %s (%s)\n", token.User, token.Email)
+			case token.User != "":
+				fmt.Printf("  User: %s\n", token.User)
+			}
+			fmt.Printf("  Profile: %s\n", activeProfile())
+			fmt.Printf("  Auth mode: %s\n", api.AuthUser)
+			if token.Flow != "" {
+				fmt.Printf("  Login flow: %s\n", token.Flow)
+			}
+			fmt.Printf("  Token store: %s\n", store)
+			if len(token.Scopes) == 0 {
+				fmt.Println("  Scopes: unknown (recorded from the next login)")
+			} else {
+				fmt.Println("  Scopes:")
+				for _, scope := range token.Scopes {
+					fmt.Printf("    %s\n", auth.ShortScope(scope))
+				}
 			}
 
 			return nil
 		},
 	}
 }
+
+// registerProfile adds the active profile, with its token file, to the
+// config file after a login, unless it is the default profile, and saves
+// the client credentials given on the command line to it.
+func registerProfile(cmd *cobra.Command) error {
+	name := activeProfile()
+	if name == config.DefaultProfile {
+		return nil
+	}
+
+	settings := viper.GetStringMap("profiles." + name)
+	// Record the token file, so that the profile is never saved empty.
+	settings["token_file"] = tokenPath()
+	for _, flag := range []string{"client-id", "client-secret"} {
+		if value, _ := cmd.Flags().GetString(flag); value != "" {
+			settings[strings.ReplaceAll(flag, "-", "_")] = value
+		}
+	}
+	if err := config.Save("profiles."+name, settings); err != nil {
+		return fmt.Errorf("saving profile %s: %w", name, err)
+	}
+	return nil
+}
+
+// newListCmd creates the "auth list" subcommand.
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List profiles and their login status",
+		Long:  "List the default profile and the profiles defined in the config file, with the login status of each. The active profile is marked with '*'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := getFormatter()
+			active := activeProfile()
+
+			type profileStatus struct {
+				Name              string `json:"name"`
+				Active            bool   `json:"active"`
+				LoggedIn          bool   `json:"loggedIn"`
+				AuthMode          string `json:"authMode"`
+				ServiceAccountKey string `json:"serviceAccountKey,omitempty"`
+				TokenStore        string `json:"tokenStore"`
+				TokenFile         string `json:"tokenFile"`
+			}
+			var profiles []profileStatus
+			for _, name := range config.Profiles() {
+				p := profileStatus{
+					Name:       name,
+					Active:     name == active,
+					AuthMode:   config.ProfileSetting(name, "auth_mode"),
+					TokenStore: config.ProfileSetting(name, "token_store"),
+					TokenFile:  config.TokenFile(name),
+				}
+				if p.AuthMode == "" {
+					p.AuthMode = api.AuthUser
+				}
+				if p.TokenStore == "" {
+					p.TokenStore = auth.StoreFile
+				}
+				// Checking for the token never needs the key of an
+				// encrypted store.
+				switch {
+				case p.AuthMode == api.AuthApp:
+					p.ServiceAccountKey = config.ProfileSetting(name, "service_account_key")
+					_, err := os.Stat(p.ServiceAccountKey)
+					p.LoggedIn = p.ServiceAccountKey != "" && err == nil
+				case p.TokenStore == auth.StoreCommand:
+					p.LoggedIn = (&auth.CommandStore{ReadCommand: config.ProfileSetting(name, "token_command_read")}).Exists()
+				default:
+					p.LoggedIn = (&auth.FileStore{Path: p.TokenFile}).Exists()
+				}
+				profiles = append(profiles, p)
+			}
+
+			if formatter.IsJSON() {
+				return formatter.Print(map[string]any{"profiles": profiles})
+			}
+
+			table := output.NewTable("", "PROFILE", "STATUS", "MODE", "STORE", "TOKEN_FILE")
+			for _, p := range profiles {
+				marker, status := "", "not logged in"
+				if p.Active {
+					marker = "*"
+				}
+				if p.LoggedIn {
+					status = "logged in"
+				}
+				table.AddRow(marker, p.Name, status, p.AuthMode, p.TokenStore, p.TokenFile)
+			}
+			fmt.Print(table.Render())
+			return nil
+		},
+	}
+}
+
+// newSwitchCmd creates the "auth switch" subcommand.
+func newSwitchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "switch PROFILE",
+		Short: "Change the active profile",
+		Long:  "Make PROFILE the profile used by commands run without --profile, by saving it to the config file. Use \"default\" to go back to the top-level settings.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !slices.Contains(config.Profiles(), name) {
+				return fmt.Errorf("unknown profile %q (create it with 'gogchat auth login --profile %s')", name, name)
+			}
+
+			if err := config.Save("profile", name); err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Switched to profile %s\n", name)
+			if env := os.Getenv("GOGCHAT_PROFILE"); env != "" && env != name {
+				fmt.Printf("  Note: GOGCHAT_PROFILE=%s overrides it in this shell\n", env)
+			}
+			return nil
+		},
+	}
+}
+
+// newMigrateStoreCmd creates the "auth migrate-store" subcommand.
+func newMigrateStoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-store",
+		Short: "Move the stored token to another token store",
+		Long: `Move the token of the active profile from its current token store to
+another one, and make that the profile's token store in the config file.
+
+Stores:
+  file            plain JSON file, readable only by you (the default)
+  encrypted-file  file encrypted with AES-256-GCM under a key derived from
+                  --key-file (token_key_file) or GOGCHAT_TOKEN_PASSPHRASE
+  command         external secret manager, through the token_command_read,
+                  token_command_write and token_command_delete settings
+
+The old copy is removed unless --keep is given.`,
+		Example: `  # Encrypt the token file in place with a passphrase
+  GOGCHAT_TOKEN_PASSPHRASE=... gogchat auth migrate-store --to encrypted-file
+
+  # Hand the token to pass(1), after setting the token_command_* settings
+  gogchat auth migrate-store --to command`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			to, _ := cmd.Flags().GetString("to")
+			path, _ := cmd.Flags().GetString("token-file")
+			keyFile, _ := cmd.Flags().GetString("key-file")
+			keep, _ := cmd.Flags().GetBool("keep")
+
+			from, err := tokenStore()
+			if err != nil {
+				return err
+			}
+			if path == "" {
+				path = tokenPath()
+			}
+			if keyFile == "" {
+				keyFile = Cfg.TokenKeyFile
+			}
+			dest, err := newTokenStore(to, path, keyFile)
+			if err != nil {
+				return err
+			}
+			if to == Cfg.TokenStore && path == tokenPath() && keyFile == Cfg.TokenKeyFile {
+				return fmt.Errorf("the token is already in %s", from)
+			}
+			// A file-backed source at the destination path is replaced by
+			// the migration itself.
+			samePlace := path == tokenPath() && Cfg.TokenStore != auth.StoreCommand && to != auth.StoreCommand
+
+			unlock, err := from.Lock()
+			if err != nil {
+				return err
+			}
+			defer unlock()
+			if path != tokenPath() {
+				unlockDest, err := dest.Lock()
+				if err != nil {
+					return err
+				}
+				defer unlockDest()
+			}
+
+			token, err := from.Load()
+			if err != nil {
+				return fmt.Errorf("reading the current token: %w", err)
+			}
+			if err := dest.Save(token); err != nil {
+				return err
+			}
+			if _, err := dest.Load(); err != nil {
+				return fmt.Errorf("checking the migrated token: %w", err)
+			}
+
+			profile := activeProfile()
+			settings := map[string]any{"token_store": to}
+			if cmd.Flags().Changed("token-file") {
+				settings["token_file"] = path
+			}
+			if keyFile != "" {
+				settings["token_key_file"] = keyFile
+			}
+			for key, value := range settings {
+				if err := config.Save(config.ProfileKey(profile, key), value); err != nil {
+					return err
+				}
+			}
+
+			fmt.Printf("✓ Token moved to %s\n", dest)
+			if !keep && !samePlace {
+				if err := from.Delete(); err != nil {
+					fmt.Printf("  Warning: could not remove the old copy from %s: %v\n", from, err)
+				} else {
+					fmt.Printf("  Removed the old copy from %s\n", from)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("to", "", "Token store to move to: file, encrypted-file or command (required)")
+	cmd.Flags().String("token-file", "", "Token file of the new store (default: the current token_file)")
+	cmd.Flags().String("key-file", "", "Key file for the encrypted-file store (default: token_key_file, else GOGCHAT_TOKEN_PASSPHRASE)")
+	cmd.Flags().Bool("keep", false, "Keep the token in the old store")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// identity is the output of "auth whoami".
+type identity struct {
+	User     string `json:"user,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Profile  string `json:"profile"`
+	AuthMode string `json:"authMode"`
+	// ServiceAccount is set under app authentication and --impersonate.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// TokenSource is set for a token from GOGCHAT_ACCESS_TOKEN or
+	// token_command.
+	TokenSource string    `json:"tokenSource,omitempty"`
+	Scopes      []string  `json:"scopes"`
+	Expires     time.Time `json:"expires,omitzero"`
+	// RefreshToken is "valid", "not checked" (with --cached) or "none"
+	// (service accounts have none).
+	RefreshToken string    `json:"refreshToken"`
+	Resolved     time.Time `json:"resolved,omitzero"`
+}
+
+// newWhoamiCmd creates the "auth whoami" subcommand.
+func newWhoamiCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the authenticated account, checked with Google",
+		Long: `Show who commands run as: the Chat user resource name (users/{id}),
+email address and granted scopes, as reported by Google's token
+introspection. For a user login, whoami first refreshes the access token,
+which checks that the refresh token still works, and caches the result
+with the token; --cached prints the cache without contacting Google.
+
+The email address is only known to logins that granted the
+userinfo.email scope, which older logins can add with
+'gogchat auth login --scopes email'.`,
+		Example: `  gogchat auth whoami
+  gogchat auth whoami --json
+  gogchat auth whoami --cached --json   # e.g. for scripts, no network`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cached, _ := cmd.Flags().GetBool("cached")
+
+			var id *identity
+			var err error
+			switch {
+			case externalToken():
+				id, err = whoamiExternal(cmd.Context(), cached)
+			case Cfg.Impersonate != "" || Cfg.AuthMode == api.AuthApp:
+				id, err = whoamiServiceAccount(cmd.Context(), cached)
+			default:
+				id, err = whoamiUser(cmd, cached)
+			}
+			if err != nil {
+				return err
+			}
+
+			f := getFormatter()
+			if f.IsJSON() {
+				return f.Print(id)
+			}
+
+			name := id.Email
+			if name == "" {
+				name = id.User
+			}
+			f.PrintSuccess("Logged in as " + name)
+			if id.User != "" {
+				f.PrintMessage(fmt.Sprintf("  User: %s", id.User))
+			}
+			if id.Email != "" {
+				f.PrintMessage(fmt.Sprintf("  Email: %s", id.Email))
+			} else if id.AuthMode == api.AuthUser && id.ServiceAccount == "" && id.TokenSource == "" {
+				f.PrintMessage(fmt.Sprintf("  Email: unknown (run '%s --scopes email' to grant it)", loginCommand()))
+			}
+			if id.ServiceAccount != "" {
+				f.PrintMessage(fmt.Sprintf("  Service account: %s", id.ServiceAccount))
+			}
+			if id.TokenSource != "" {
+				f.PrintMessage(fmt.Sprintf("  Access token from: %s", id.TokenSource))
+			}
+			f.PrintMessage(fmt.Sprintf("  Profile: %s", id.Profile))
+			f.PrintMessage(fmt.Sprintf("  Auth mode: %s", id.AuthMode))
+			f.PrintMessage(fmt.Sprintf("  Refresh token: %s", id.RefreshToken))
+			if !id.Expires.IsZero() {
+				f.PrintMessage(fmt.Sprintf("  Access token expires: %s", id.Expires.UTC().Format("2006-01-02 15:04:05 UTC")))
+			}
+			if !id.Resolved.IsZero() {
+				f.PrintMessage(fmt.Sprintf("  Checked: %s", id.Resolved.UTC().Format("2006-01-02 15:04:05 UTC")))
+			}
+			f.PrintMessage("  Scopes:")
+			for _, scope := range id.Scopes {
+				f.PrintMessage("    " + auth.ShortScope(scope))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("cached", false, "Print the identity cached by the last whoami without contacting Google")
+
+	return cmd
+}
+
+// whoamiUser resolves the identity of the user login of the active
+// profile. Unless cached is set, it refreshes the token to check the
+// refresh token, introspects the new access token and caches the result in
+// the token store.
+func whoamiUser(cmd *cobra.Command, cached bool) (*identity, error) {
+	store, err := tokenStore()
+	if err != nil {
+		return nil, err
+	}
+	stored, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading token (run '%s' first): %w", loginCommand(), err)
+	}
+
+	refreshState := "not checked"
+	if cached {
+		if stored.Resolved.IsZero() {
+			return nil, fmt.Errorf("no identity cached yet (run '%s' once)", strings.Replace(loginCommand(), "login", "whoami", 1))
+		}
+	} else {
+		clientID, clientSecret, err := resolveCredentials(cmd)
+		if err != nil {
+			return nil, err
+		}
+		base, err := newBaseHTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		ctx := auth.WithHTTPClient(cmd.Context(), base)
+
+		stored, err = auth.ForceRefresh(ctx, clientID, clientSecret, store)
+		if err != nil {
+			return nil, fmt.Errorf("the stored login no longer works (run '%s' to log in again): %w", loginCommand(), err)
+		}
+		refreshState = "valid"
+
+		info, err := auth.Introspect(ctx, stored.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		stored.User, stored.Email, stored.Resolved = info.User(), info.Email, time.Now()
+		if scopes := info.Scopes(); len(scopes) > 0 {
+			stored.Scopes = scopes
+		}
+		if err := auth.SaveToken(store, stored); err != nil {
+			return nil, fmt.Errorf("saving token: %w", err)
+		}
+	}
+
+	return &identity{
+		User:         stored.User,
+		Email:        stored.Email,
+		Profile:      activeProfile(),
+		AuthMode:     api.AuthUser,
+		Scopes:       stored.Scopes,
+		Expires:      stored.Expiry,
+		RefreshToken: refreshState,
+		Resolved:     stored.Resolved,
+	}, nil
+}
+
+// whoamiExternal resolves the identity behind the access token from
+// GOGCHAT_ACCESS_TOKEN or token_command by introspecting it. There is no
+// cache, since the token is not stored.
+func whoamiExternal(ctx context.Context, cached bool) (*identity, error) {
+	source, from := externalTokenSource()
+	if cached {
+		return nil, fmt.Errorf("nothing is cached for the access token from %s (run without --cached)", from)
+	}
+	token, err := source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := newBaseHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	info, err := auth.Introspect(auth.WithHTTPClient(ctx, base), token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	id := &identity{
+		User:         info.User(),
+		Email:        info.Email,
+		Profile:      activeProfile(),
+		AuthMode:     api.AuthUser,
+		TokenSource:  from,
+		Scopes:       info.Scopes(),
+		RefreshToken: "none",
+		Resolved:     time.Now(),
+	}
+	if n, err := strconv.Atoi(info.ExpiresIn); err == nil {
+		id.Expires = time.Now().Add(time.Duration(n) * time.Second)
+	}
+	return id, nil
+}
+
+// whoamiServiceAccount resolves the identity of the service account of the
+// active profile, or of the user it impersonates. Unless cached is set, it
+// obtains a token and introspects it; nothing is cached.
+func whoamiServiceAccount(ctx context.Context, cached bool) (*identity, error) {
+	sa, err := serviceAccount()
+	if err != nil {
+		return nil, err
+	}
+	id := &identity{
+		Email:          sa.Email,
+		Profile:        activeProfile(),
+		AuthMode:       api.AuthApp,
+		ServiceAccount: sa.Email,
+		Scopes:         []string{auth.BotScope},
+		RefreshToken:   "none",
+	}
+	if Cfg.Impersonate != "" {
+		sa = sa.Impersonate(Cfg.Impersonate, requestScopes)
+		id.Email, id.AuthMode, id.Scopes = sa.Subject, api.AuthUser, requestScopes
+	}
+	if cached {
+		return id, nil
+	}
+
+	base, err := newBaseHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx = auth.WithHTTPClient(ctx, base)
+	token, err := sa.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info, err := auth.Introspect(ctx, token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if Cfg.Impersonate != "" {
+		id.User = info.User()
+	}
+	if scopes := info.Scopes(); len(scopes) > 0 {
+		id.Scopes = scopes
+	}
+	id.Expires, id.Resolved = token.Expiry, time.Now()
+	return id, nil
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -47,97 +46,61 @@ func newEmojiListCmd() *cobra.Command {
 			formatter := getFormatter()
 			svc := api.NewEmojiService(client)
 
-			pageSize, _ := cmd.Flags().GetInt("page-size")
-			pageToken, _ := cmd.Flags().GetString("page-token")
 			filter, _ := cmd.Flags().GetString("filter")
 			all, _ := cmd.Flags().GetBool("all")
+			opts := pageOptions(cmd)
 
 			ctx := cmd.Context()
 
-			var allEmojis []json.RawMessage
-
-			for {
-				raw, err := svc.List(ctx, filter, pageSize, pageToken)
-				if err != nil {
+			if all {
+				if err := streamList(formatter, "customEmojis", svc.All(ctx, filter, opts), func(emojis []*api.CustomEmoji) {
+					printEmojiTable(formatter, emojis)
+				}); err != nil {
 					return fmt.Errorf("listing emojis: %w", err)
 				}
-
-				if formatter.IsJSON() && !all {
-					return formatter.PrintRaw(raw)
-				}
-
-				var resp struct {
-					CustomEmojis []json.RawMessage `json:"customEmojis"`
-					NextPage     string            `json:"nextPageToken"`
-				}
-				if err := json.Unmarshal(raw, &resp); err != nil {
-					return fmt.Errorf("parsing response: %w", err)
-				}
-
-				allEmojis = append(allEmojis, resp.CustomEmojis...)
-
-				if !all || resp.NextPage == "" {
-					pageToken = resp.NextPage
-					break
-				}
-				pageToken = resp.NextPage
-			}
-
-			if formatter.IsJSON() {
-				// --all + --json: emit collected emojis as a JSON array.
-				return formatter.Print(allEmojis)
-			}
-
-			if len(allEmojis) == 0 {
-				formatter.PrintMessage("No custom emojis found.")
 				return nil
 			}
 
-			table := output.NewTable("NAME", "SHORT_NAME", "CREATOR", "CREATE_TIME")
-			for _, e := range allEmojis {
-				var emoji struct {
-					Name         string `json:"name"`
-					UID          string `json:"uid"`
-					EmojiName    string `json:"emojiName"`
-					TemporaryURI string `json:"temporaryImageUri"`
-					Creator      struct {
-						Name        string `json:"name"`
-						DisplayName string `json:"displayName"`
-					} `json:"creator"`
-					CreateTime string `json:"createTime"`
-				}
-				if err := json.Unmarshal(e, &emoji); err != nil {
-					continue
-				}
-
-				shortName := emoji.EmojiName
-				creator := emoji.Creator.DisplayName
-				if creator == "" {
-					creator = emoji.Creator.Name
-				}
-				createTime := output.FormatTime(emoji.CreateTime)
+			resp, err := svc.List(ctx, filter, opts.PageSize, opts.PageToken)
+			if err != nil {
+				return fmt.Errorf("listing emojis: %w", err)
+			}
 
-				table.AddRow(emoji.Name, shortName, creator, createTime)
+			if formatter.IsJSON() {
+				return formatter.Print(resp)
 			}
 
-			fmt.Print(table.Render())
+			printEmojiTable(formatter, resp.CustomEmojis)
 
-			if !all && pageToken != "" {
-				formatter.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", pageToken))
+			if resp.NextPageToken != "" {
+				formatter.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", resp.NextPageToken))
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().Int("page-size", 25, "Maximum number of emojis to return per page")
-	cmd.Flags().String("page-token", "", "Page token for pagination")
 	cmd.Flags().String("filter", "", "Filter expression for custom emojis")
-	cmd.Flags().Bool("all", false, "Fetch all pages of results")
+	addPaginationFlags(cmd, 25, "emojis")
 
 	return cmd
 }
 
+// printEmojiTable renders custom emojis as a table.
+func printEmojiTable(f *output.Formatter, emojis []*api.CustomEmoji) {
+	if len(emojis) == 0 {
+		f.PrintMessage("No custom emojis found.")
+		return
+	}
+
+	table := newTable("NAME", "SHORT_NAME", "EMOJI_ID")
+	for _, emoji := range emojis {
+		table.AddRow(emoji.Name, emoji.EmojiName, emoji.UID)
+	}
+
+	fmt.Print(table.Render())
+}
+
 // newEmojiGetCmd creates the "emoji get" subcommand.
 func newEmojiGetCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -153,54 +116,27 @@ func newEmojiGetCmd() *cobra.Command {
 			formatter := getFormatter()
 			svc := api.NewEmojiService(client)
 
-			raw, err := svc.Get(cmd.Context(), args[0])
+			emoji, err := svc.Get(cmd.Context(), args[0])
 			if err != nil {
 				return fmt.Errorf("getting emoji: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			// Parse the emoji for human-readable output.
-			var emoji struct {
-				Name         string `json:"name"`
-				UID          string `json:"uid"`
-				EmojiName    string `json:"emojiName"`
-				TemporaryURI string `json:"temporaryImageUri"`
-				Creator      struct {
-					Name        string `json:"name"`
-					DisplayName string `json:"displayName"`
-				} `json:"creator"`
-				Payload struct {
-					FileContent string `json:"fileContent"`
-					Filename    string `json:"filename"`
-				} `json:"payload"`
-				CreateTime string `json:"createTime"`
-			}
-			if err := json.Unmarshal(raw, &emoji); err != nil {
-				return fmt.Errorf("parsing emoji: %w", err)
-			}
-
-			creator := emoji.Creator.DisplayName
-			if creator == "" {
-				creator = emoji.Creator.Name
+				return formatter.Print(emoji)
 			}
 
 			payloadInfo := "(none)"
-			if emoji.Payload.Filename != "" {
+			if emoji.Payload != nil && emoji.Payload.Filename != "" {
 				payloadInfo = emoji.Payload.Filename
 			}
-			if emoji.TemporaryURI != "" {
-				payloadInfo = emoji.TemporaryURI
+			if emoji.TemporaryImageURI != "" {
+				payloadInfo = emoji.TemporaryImageURI
 			}
 
 			fmt.Printf("Name:        %s\n", emoji.Name)
 			fmt.Printf("Short Name:  %s\n", emoji.EmojiName)
 			fmt.Printf("Emoji ID:    %s\n", emoji.UID)
-			fmt.Printf("Creator:     %s\n", creator)
 			fmt.Printf("Payload:     %s\n", payloadInfo)
-			fmt.Printf("Create Time: %s\n", output.FormatTime(emoji.CreateTime))
 
 			return nil
 		},
@@ -234,49 +170,27 @@ func newEmojiCreateCmd() *cobra.Command {
 			encoded := base64.StdEncoding.EncodeToString(data)
 			filename := filepath.Base(imageFile)
 
-			body := map[string]interface{}{
-				"shortName": shortName,
-				"payload": map[string]interface{}{
-					"fileContent": encoded,
-					"filename":    filename,
+			body := &api.CustomEmoji{
+				EmojiName: shortName,
+				Payload: &api.CustomEmojiPayload{
+					FileContent: encoded,
+					Filename:    filename,
 				},
 			}
 
-			raw, err := svc.Create(cmd.Context(), body)
+			emoji, err := svc.Create(cmd.Context(), body)
 			if err != nil {
 				return fmt.Errorf("creating emoji: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			// Parse and display the created emoji.
-			var emoji struct {
-				Name      string `json:"name"`
-				UID       string `json:"uid"`
-				EmojiName string `json:"emojiName"`
-				Creator   struct {
-					Name        string `json:"name"`
-					DisplayName string `json:"displayName"`
-				} `json:"creator"`
-				CreateTime string `json:"createTime"`
-			}
-			if err := json.Unmarshal(raw, &emoji); err != nil {
-				return fmt.Errorf("parsing response: %w", err)
-			}
-
-			creator := emoji.Creator.DisplayName
-			if creator == "" {
-				creator = emoji.Creator.Name
+				return formatter.Print(emoji)
 			}
 
 			formatter.PrintSuccess("Custom emoji created!")
 			fmt.Printf("Name:        %s\n", emoji.Name)
 			fmt.Printf("Short Name:  %s\n", emoji.EmojiName)
 			fmt.Printf("Emoji ID:    %s\n", emoji.UID)
-			fmt.Printf("Creator:     %s\n", creator)
-			fmt.Printf("Create Time: %s\n", output.FormatTime(emoji.CreateTime))
 
 			return nil
 		},
@@ -318,13 +232,13 @@ func newEmojiDeleteCmd() *cobra.Command {
 				}
 			}
 
-			raw, err := svc.Delete(cmd.Context(), name)
+			resp, err := svc.Delete(cmd.Context(), name)
 			if err != nil {
 				return fmt.Errorf("deleting emoji: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
+				return formatter.Print(resp)
 			}
 
 			formatter.PrintSuccess(fmt.Sprintf("Custom emoji %s deleted.", name))
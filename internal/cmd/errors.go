@@ -8,18 +8,77 @@ import (
 
 	"github.com/cipher-shad0w/gogchat/internal/api"
 	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
 )
 
 // knownErrors maps specific API error signatures to user-friendly hints.
 // Each entry is checked against the error and if matched, the hint is displayed.
+// Entries with an authMode only match under that auth_mode, and come first
+// so that they win over the general ones.
 var knownErrors = []struct {
 	// match criteria
 	code        int
 	status      string
 	msgContains string
+	authMode    string
 	// hint to display
 	hint string
 }{
+	{
+		code:        403,
+		status:      "PERMISSION_DENIED",
+		msgContains: "doesn't support app authentication",
+		authMode:    api.AuthApp,
+		hint: `This method can only be called as a user, not as a Chat app.
+
+To fix this, run the command with a profile that uses user authentication:
+  gogchat --profile NAME ...
+or switch this profile back to user authentication:
+  gogchat auth login`,
+	},
+	{
+		code:        403,
+		status:      "PERMISSION_DENIED",
+		msgContains: "insufficient authentication scopes",
+		authMode:    api.AuthApp,
+		hint: `As a Chat app, gogchat only has the chat.bot scope, and this method
+needs a user's authorization (or admin-approved app scopes).
+
+To fix this, run the command with a profile that uses user authentication,
+or switch back to it with: gogchat auth login`,
+	},
+	{
+		code:     403,
+		status:   "PERMISSION_DENIED",
+		authMode: api.AuthApp,
+		hint: `A Chat app can only act in spaces it is a member of.
+
+To fix this:
+  1. Add the app to the space (in Google Chat: space name → Apps & integrations)
+  2. Make sure the service account belongs to the Cloud project whose
+     Chat app configuration you set up
+  3. Re-run your command`,
+	},
+	{
+		code:        404,
+		status:      "NOT_FOUND",
+		authMode:    api.AuthApp,
+		msgContains: "requested entity was not found",
+		hint: `The resource does not exist, or the Chat app cannot see it: an app only
+sees spaces it has been added to, and messages in them.`,
+	},
+	{
+		code:     401,
+		status:   "UNAUTHENTICATED",
+		authMode: api.AuthApp,
+		hint: `The service account's credentials were rejected.
+
+To fix this:
+  1. Check that the key has not been deleted or disabled:
+     https://console.cloud.google.com/iam-admin/serviceaccounts
+  2. Log in again with a current key:
+     gogchat auth login --service-account KEY.json`,
+	},
 	{
 		code:        404,
 		status:      "NOT_FOUND",
@@ -38,12 +97,11 @@ To fix this:
 		code:        403,
 		status:      "PERMISSION_DENIED",
 		msgContains: "insufficient authentication scopes",
-		hint: `Your access token is missing the required scopes for this operation.
-
-To fix this:
-  1. Run: gogchat auth logout
-  2. Run: gogchat auth login
-  3. Re-authorize when prompted in your browser`,
+		hint: `Your login has not granted a scope this operation needs. Scopes that
+need admin privileges or Google's approval are only requested on demand:
+  gogchat auth login --scopes admin,import,delete,apps
+(any of these bundles, or single scopes such as chat.admin.spaces).
+Scopes granted before are kept; there is no need to log out.`,
 	},
 	{
 		code:        403,
@@ -85,6 +143,50 @@ Make sure you have the required role in Google Workspace admin console.`,
 	},
 }
 
+// serviceAccountTokenHint is shown when a service account cannot obtain
+// an access token, before any API call is made.
+const serviceAccountTokenHint = `Google did not issue a token for the service account. Usually its key
+has been deleted or disabled, the service account itself has been
+deleted, or this machine's clock is off by more than a few minutes.
+
+To fix this, check the service account at
+  https://console.cloud.google.com/iam-admin/serviceaccounts
+and log in again with a current key:
+  gogchat auth login --service-account KEY.json`
+
+// externalTokenHint replaces the login advice for a rejected token that
+// came from GOGCHAT_ACCESS_TOKEN or token_command.
+const externalTokenHint = `The access token from GOGCHAT_ACCESS_TOKEN or token_command was rejected.
+It has probably expired: gogchat cannot refresh such a token, so pass a
+fresh one. Unset both to use the login from 'gogchat auth login' instead.`
+
+// delegationHint explains how to authorize the service account for the
+// scopes that an --impersonate token request was refused.
+func delegationHint() string {
+	clientID := "the service account's client ID"
+	if Cfg != nil {
+		if sa, err := serviceAccount(); err == nil && sa.ClientID != "" {
+			clientID = sa.ClientID
+		}
+	}
+	return fmt.Sprintf(`The service account is not authorized to impersonate users with the
+scopes this command needs. A Workspace super admin must grant them:
+  1. Open: https://admin.google.com/ac/owl/domainwidedelegation
+  2. Add (or edit) client ID %s
+  3. Authorize these OAuth scopes:
+     %s
+  4. Wait a few minutes for the change to propagate, then re-run your command`,
+		clientID, strings.Join(requestScopes, ","))
+}
+
+// printHint prints hint, indented, under a "Hint:" heading on stderr.
+func printHint(hint string) {
+	fmt.Fprintf(os.Stderr, "\n  Hint:\n")
+	for _, line := range strings.Split(hint, "\n") {
+		fmt.Fprintf(os.Stderr, "  %s\n", line)
+	}
+}
+
 // findHint searches for an actionable hint matching the given API error.
 func findHint(apiErr *api.APIError) string {
 	for _, ke := range knownErrors {
@@ -97,6 +199,9 @@ func findHint(apiErr *api.APIError) string {
 		if ke.msgContains != "" && !strings.Contains(strings.ToLower(apiErr.Message), strings.ToLower(ke.msgContains)) {
 			continue
 		}
+		if ke.authMode != "" && ke.authMode != authMode() {
+			continue
+		}
 		return ke.hint
 	}
 	return ""
@@ -109,6 +214,17 @@ func printRichError(err error) {
 	if !errors.As(err, &apiErr) {
 		// Not an API error – print as-is.
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		var tokenErr *oauth2.RetrieveError
+		if !errors.As(err, &tokenErr) {
+			return
+		}
+		switch {
+		// The JWT flow leaves ErrorCode empty, so look at the body too.
+		case viper.GetString("impersonate") != "" && (tokenErr.ErrorCode == "unauthorized_client" || strings.Contains(string(tokenErr.Body), "unauthorized_client")):
+			printHint(delegationHint())
+		case viper.GetString("impersonate") != "" || authMode() == api.AuthApp:
+			printHint(serviceAccountTokenHint)
+		}
 		return
 	}
 
@@ -117,11 +233,10 @@ func printRichError(err error) {
 	fmt.Fprintf(os.Stderr, "  %s\n", apiErr.Message)
 
 	// Check for a known error hint
-	if hint := findHint(apiErr); hint != "" {
-		fmt.Fprintf(os.Stderr, "\n  Hint:\n")
-		for _, line := range strings.Split(hint, "\n") {
-			fmt.Fprintf(os.Stderr, "  %s\n", line)
-		}
+	if apiErr.Code == 401 && externalToken() {
+		printHint(externalTokenHint)
+	} else if hint := findHint(apiErr); hint != "" {
+		printHint(hint)
 	}
 
 	// Show help links from the API response details
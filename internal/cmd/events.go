@@ -44,69 +44,33 @@ func newEventsListCmd() *cobra.Command {
 
 			parent := args[0]
 			filter, _ := cmd.Flags().GetString("filter")
-			pageSize, _ := cmd.Flags().GetInt("page-size")
-			pageToken, _ := cmd.Flags().GetString("page-token")
 			all, _ := cmd.Flags().GetBool("all")
+			opts := pageOptions(cmd)
 
 			ctx := cmd.Context()
 
-			var allEvents []json.RawMessage
-
-			for {
-				raw, err := svc.List(ctx, parent, filter, pageSize, pageToken)
-				if err != nil {
+			if all {
+				if err := streamList(formatter, "spaceEvents", svc.All(ctx, parent, filter, opts), func(events []*api.SpaceEvent) {
+					printEventsTable(formatter, events)
+				}); err != nil {
 					return fmt.Errorf("listing events: %w", err)
 				}
-
-				if formatter.IsJSON() && !all {
-					return formatter.PrintRaw(raw)
-				}
-
-				var resp struct {
-					SpaceEvents []json.RawMessage `json:"spaceEvents"`
-					NextPage    string            `json:"nextPageToken"`
-				}
-				if err := json.Unmarshal(raw, &resp); err != nil {
-					return fmt.Errorf("parsing response: %w", err)
-				}
-
-				allEvents = append(allEvents, resp.SpaceEvents...)
-
-				if !all || resp.NextPage == "" {
-					pageToken = resp.NextPage
-					break
-				}
-				pageToken = resp.NextPage
-			}
-
-			if formatter.IsJSON() {
-				// --all + --json: emit collected events as a JSON array.
-				return formatter.Print(allEvents)
-			}
-
-			if len(allEvents) == 0 {
-				formatter.PrintMessage("No events found.")
 				return nil
 			}
 
-			table := output.NewTable("EVENT_NAME", "EVENT_TYPE", "EVENT_TIME")
-			for _, e := range allEvents {
-				var event struct {
-					Name      string `json:"name"`
-					EventType string `json:"eventType"`
-					EventTime string `json:"eventTime"`
-				}
-				if err := json.Unmarshal(e, &event); err != nil {
-					continue
-				}
+			resp, err := svc.List(ctx, parent, filter, opts.PageSize, opts.PageToken)
+			if err != nil {
+				return fmt.Errorf("listing events: %w", err)
+			}
 
-				table.AddRow(event.Name, event.EventType, output.FormatTime(event.EventTime))
+			if formatter.IsJSON() {
+				return formatter.Print(resp)
 			}
 
-			fmt.Print(table.Render())
+			printEventsTable(formatter, resp.SpaceEvents)
 
-			if !all && pageToken != "" {
-				formatter.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", pageToken))
+			if resp.NextPageToken != "" {
+				formatter.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", resp.NextPageToken))
 			}
 
 			return nil
@@ -115,13 +79,26 @@ func newEventsListCmd() *cobra.Command {
 
 	cmd.Flags().String("filter", "", "Filter for events (required, must include event_type)")
 	_ = cmd.MarkFlagRequired("filter")
-	cmd.Flags().Int("page-size", 0, "Maximum number of events to return per page")
-	cmd.Flags().String("page-token", "", "Page token for pagination")
-	cmd.Flags().Bool("all", false, "Fetch all pages of results")
+	addPaginationFlags(cmd, 0, "events")
 
 	return cmd
 }
 
+// printEventsTable renders space events as a table.
+func printEventsTable(f *output.Formatter, events []*api.SpaceEvent) {
+	if len(events) == 0 {
+		f.PrintMessage("No events found.")
+		return
+	}
+
+	table := newTable("EVENT_NAME", "EVENT_TYPE", "EVENT_TIME")
+	for _, event := range events {
+		table.AddRow(event.Name, event.EventType, output.FormatTime(event.EventTime))
+	}
+
+	fmt.Print(table.Render())
+}
+
 // newEventsGetCmd creates the "events get" subcommand.
 func newEventsGetCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -139,27 +116,17 @@ func newEventsGetCmd() *cobra.Command {
 
 			name := args[0]
 
-			raw, err := svc.Get(cmd.Context(), name)
+			event, err := svc.Get(cmd.Context(), name)
 			if err != nil {
 				return fmt.Errorf("getting event: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			var event struct {
-				Name      string          `json:"name"`
-				EventType string          `json:"eventType"`
-				EventTime string          `json:"eventTime"`
-				Payload   json.RawMessage `json:"-"`
-			}
-			if err := json.Unmarshal(raw, &event); err != nil {
-				return fmt.Errorf("parsing response: %w", err)
+				return formatter.Print(event)
 			}
 
 			// Build a payload summary from any known payload fields.
-			payloadSummary := summarizeEventPayload(raw)
+			payloadSummary := summarizeEventPayload(event)
 
 			fmt.Printf("Name:        %s\n", event.Name)
 			fmt.Printf("Event Type:  %s\n", event.EventType)
@@ -178,7 +145,12 @@ func newEventsGetCmd() *cobra.Command {
 // summarizeEventPayload extracts a short summary from the event payload.
 // Google Chat events embed their payload under a field keyed by the event
 // category (e.g. "messageCreatedEventData", "membershipCreatedEventData").
-func summarizeEventPayload(raw json.RawMessage) string {
+func summarizeEventPayload(event *api.SpaceEvent) string {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return ""
+	}
+
 	var fields map[string]json.RawMessage
 	if err := json.Unmarshal(raw, &fields); err != nil {
 		return ""
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+	"github.com/cipher-shad0w/gogchat/internal/chattest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var update = flag.Bool("update", false, "re-record the cassettes in testdata against chattest and rewrite the golden files")
+
+// goldenCases are commands whose human-readable output is checked against
+// testdata/NAME.golden, replaying the API traffic in testdata/NAME.json.
+var goldenCases = []struct {
+	name string
+	args []string
+}{
+	{"spaces_list", []string{"spaces", "list"}},
+	{"spaces_list_fields", []string{"spaces", "list", "--fields", "spaces(name,displayName)"}},
+	{"spaces_get", []string{"spaces", "get", "spaces/AAAA0000001"}},
+	{"messages_list", []string{"messages", "list", "spaces/AAAA0000001"}},
+	{"members_list", []string{"members", "list", "spaces/AAAA0000001"}},
+}
+
+func TestGolden(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	defer func(loc *time.Location) { time.Local = loc }(time.Local)
+	time.Local = time.UTC
+
+	var srv *chattest.Server
+	if *update {
+		srv = chattest.NewServer()
+		defer srv.Close()
+		seedGolden(srv)
+	}
+
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cassettePath := filepath.Join("testdata", tc.name+".json")
+			goldenPath := filepath.Join("testdata", tc.name+".golden")
+
+			if *update {
+				t.Setenv("GOGCHAT_BASE_URL", srv.BaseURL())
+				t.Setenv("GOGCHAT_ACCESS_TOKEN", "golden-test-token")
+				if _, err := runCommand(t, append(tc.args, "--record", cassettePath)...); err != nil {
+					t.Fatalf("recording: %v", err)
+				}
+				t.Setenv("GOGCHAT_BASE_URL", "")
+				t.Setenv("GOGCHAT_ACCESS_TOKEN", "")
+			}
+
+			got, err := runCommand(t, append(tc.args, "--replay", cassettePath)...)
+			if err != nil {
+				t.Fatalf("replaying: %v", err)
+			}
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("output differs from %s (run with -update if the change is intended)\ngot:\n%s\nwant:\n%s", goldenPath, got, want)
+			}
+		})
+	}
+}
+
+// seedGolden fills srv with the data behind the golden files. Times are
+// fixed, and in the past, so that rendered dates do not change.
+func seedGolden(srv *chattest.Server) {
+	alice := api.User{Name: "users/100000000000000000002", DisplayName: "Alice", Type: "HUMAN"}
+	srv.AddUser(alice)
+
+	sp := srv.AddSpace(api.Space{
+		DisplayName:         "Team",
+		SpaceType:           "SPACE",
+		SpaceThreadingState: "THREADED_MESSAGES",
+		CreateTime:          "2024-03-01T09:00:00Z",
+	})
+	srv.AddSpace(api.Space{DisplayName: "Announcements", SpaceType: "SPACE", CreateTime: "2024-03-02T09:00:00Z"})
+	srv.AddMember(sp.Name, alice.Name, "ROLE_MANAGER", "JOINED")
+
+	srv.AddMessage(sp.Name, api.Message{Text: "Welcome to the team space!", CreateTime: "2024-03-01T09:05:00Z"})
+	srv.AddMessage(sp.Name, api.Message{
+		Text:       "Hi everyone",
+		Sender:     &alice,
+		Thread:     &api.Thread{ThreadKey: "intro"},
+		CreateTime: "2024-03-01T09:10:00Z",
+	})
+}
+
+// runCommand runs gogchat with args and returns what it printed to stdout.
+func runCommand(t *testing.T, args ...string) ([]byte, error) {
+	t.Helper()
+	defer resetCommands(rootCmd)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	out := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- data
+	}()
+
+	rootCmd.SetArgs(args)
+	err = rootCmd.ExecuteContext(context.Background())
+	saveRecording()
+	recording, tracing = nil, nil
+
+	os.Stdout = stdout
+	w.Close()
+	return <-out, err
+}
+
+// resetCommands restores every flag of cmd and its sub-commands to its
+// default and drops their contexts, since cobra keeps both from one Execute
+// to the next.
+func resetCommands(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if s, ok := f.Value.(pflag.SliceValue); ok && f.DefValue == "[]" {
+			_ = s.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	cmd.SetContext(nil)
+	for _, c := range cmd.Commands() {
+		resetCommands(c)
+	}
+}
+
+func TestSpansToStderrKeepJSONValid(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	stderr := os.Stderr
+	defer func() { os.Stderr = stderr }()
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	os.Stderr = devNull
+
+	out, err := runCommand(t, "spaces", "list", "--json", "--spans", "-", "--replay", filepath.Join("testdata", "spaces_list.json"))
+	tracer, commandSpan = nil, nil
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(out) {
+		t.Errorf("--json --spans - printed invalid JSON:\n%s", out)
+	}
+}
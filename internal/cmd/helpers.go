@@ -1,17 +1,111 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
 
 	"github.com/cipher-shad0w/gogchat/internal/api"
 	"github.com/cipher-shad0w/gogchat/internal/auth"
+	"github.com/cipher-shad0w/gogchat/internal/cassette"
+	"github.com/cipher-shad0w/gogchat/internal/har"
 	"github.com/cipher-shad0w/gogchat/internal/output"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
 )
 
 // newAPIClient creates a new API client using the loaded configuration and
 // stored OAuth2 token. It is shared by all command files in the cmd package.
 func newAPIClient() (*api.Client, error) {
+	// A replayed session needs no credentials: every response comes from
+	// the cassette.
+	if path := viper.GetString("replay"); path != "" {
+		c, err := cassette.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		client := api.NewClient(&http.Client{Transport: traceTransport(cassette.NewReplayer(c))})
+		configureClient(client)
+		client.Limiter = nil
+		return client, nil
+	}
+
+	base, err := newBaseHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx := auth.WithHTTPClient(context.Background(), base)
+
+	var httpClient *http.Client
+	mode, identity := api.AuthUser, ""
+	var credentials []any
+	switch {
+	case Cfg.Impersonate != "":
+		sa, err := serviceAccount()
+		if err != nil {
+			return nil, err
+		}
+		sa = sa.Impersonate(Cfg.Impersonate, requestScopes)
+		httpClient = sa.HTTPClient(ctx)
+		identity = sa.Subject
+		credentials = []any{"identity", sa.Subject, "impersonatedBy", sa.Email, "key", sa.Path, "scopes", requestScopes}
+	case externalToken():
+		source, from := externalTokenSource()
+		httpClient = oauth2.NewClient(ctx, source)
+		credentials = []any{"token", from}
+	case Cfg.AuthMode == api.AuthApp:
+		sa, err := serviceAccount()
+		if err != nil {
+			return nil, err
+		}
+		httpClient = sa.HTTPClient(ctx)
+		mode, identity = api.AuthApp, sa.Email
+		credentials = []any{"identity", sa.Email, "key", sa.Path}
+	case Cfg.AuthMode == "" || Cfg.AuthMode == api.AuthUser:
+		store, err := tokenStore()
+		if err != nil {
+			return nil, err
+		}
+		var token *auth.StoredToken
+		httpClient, token, err = newUserHTTPClient(ctx, store)
+		if err != nil {
+			return nil, err
+		}
+		// The identity is known once 'auth whoami' has resolved it.
+		identity = token.Email
+		if identity == "" {
+			identity = token.User
+		}
+		credentials = []any{"identity", identity, "token", store.String()}
+	default:
+		return nil, fmt.Errorf("unknown auth_mode %q (want %s or %s)", Cfg.AuthMode, api.AuthUser, api.AuthApp)
+	}
+
+	if path := viper.GetString("record"); path != "" {
+		recording = cassette.NewRecorder(path, httpClient.Transport)
+		httpClient.Transport = recording
+	}
+	client := api.NewClient(httpClient)
+	configureClient(client)
+	client.AuthMode = mode
+	client.Identity = identity
+	if client.Logger != nil {
+		client.Logger.Debug("authenticated", append([]any{"mode", client.AuthMode, "profile", activeProfile()}, credentials...)...)
+	}
+	return client, nil
+}
+
+// newUserHTTPClient returns an HTTP client that makes requests as the user
+// whose OAuth2 token is in store, and the token as loaded. Token refreshes
+// go through the client on ctx.
+func newUserHTTPClient(ctx context.Context, store auth.TokenStore) (*http.Client, *auth.StoredToken, error) {
 	clientID := Cfg.ClientID
 	clientSecret := Cfg.ClientSecret
 
@@ -24,26 +118,284 @@ func newAPIClient() (*api.Client, error) {
 	}
 
 	if err := auth.ValidateCredentials(clientID, clientSecret); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	tokenPath := Cfg.TokenFile
-	if tokenPath == "" {
-		tokenPath = auth.DefaultTokenPath()
+	token, err := store.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading token (run '%s' first): %w", loginCommand(), err)
 	}
+	return auth.HTTPClient(ctx, clientID, clientSecret, store, token), token, nil
+}
 
-	token, err := auth.LoadToken(tokenPath)
-	if err != nil {
-		return nil, fmt.Errorf("loading token (run 'gogchat auth login' first): %w", err)
+// externalToken reports whether the access token comes from
+// GOGCHAT_ACCESS_TOKEN or token_command rather than from a login. Like
+// authMode, it only reads settings.
+func externalToken() bool {
+	return viper.GetString("impersonate") == "" &&
+		(viper.GetString("access_token") != "" || viper.GetString("token_command") != "")
+}
+
+// externalTokenSource returns the source of the external access token and
+// a description of where it comes from. GOGCHAT_ACCESS_TOKEN wins over
+// token_command.
+func externalTokenSource() (oauth2.TokenSource, string) {
+	if token := Cfg.AccessToken; token != "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token, TokenType: "Bearer"}), "GOGCHAT_ACCESS_TOKEN"
 	}
+	return auth.CommandTokenSource(Cfg.TokenCommand), fmt.Sprintf("token_command %q", Cfg.TokenCommand)
+}
 
-	httpClient := auth.HTTPClient(clientID, clientSecret, token)
-	client := api.NewClient(httpClient)
-	client.Verbose = viper.GetBool("verbose")
+// serviceAccount loads the service account key of the active profile, for
+// auth_mode "app" and --impersonate.
+func serviceAccount() (*auth.ServiceAccount, error) {
+	if Cfg.ServiceAccountKey == "" {
+		return nil, fmt.Errorf("no service account key is set (run '%s --service-account KEY.json', or set service_account_key)", loginCommand())
+	}
+	return auth.LoadServiceAccount(Cfg.ServiceAccountKey)
+}
+
+// authMode returns how the running command authenticates: as a user, which
+// includes a user impersonated with --impersonate, or as a Chat app. It
+// only reads settings, so it also works before Cfg is loaded.
+func authMode() string {
+	if viper.GetString("impersonate") != "" || viper.GetString("auth_mode") != api.AuthApp {
+		return api.AuthUser
+	}
+	return api.AuthApp
+}
+
+// newBaseHTTPClient returns the HTTP client, without credentials, that
+// carries both OAuth2 token requests and API calls. It applies the proxy, CA
+// and client certificate settings from the loaded configuration.
+//
+// With --trace, every request made through the client is written to a HAR
+// file.
+func newBaseHTTPClient() (*http.Client, error) {
+	client, err := auth.NewHTTPClient(auth.TransportOptions{
+		ProxyURL:   Cfg.Proxy,
+		CAFiles:    Cfg.CAFiles,
+		ClientCert: Cfg.ClientCert,
+		ClientKey:  Cfg.ClientKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = traceTransport(client.Transport)
 	return client, nil
 }
 
+// traceTransport wraps next in a HAR recorder when --trace is set, and
+// returns it unchanged otherwise. All clients of a command record into the
+// same trace.
+func traceTransport(next http.RoundTripper) http.RoundTripper {
+	path := viper.GetString("trace")
+	if path == "" {
+		return next
+	}
+	if tracing == nil {
+		tracing = har.NewRecorder(path, Version, next)
+		return tracing
+	}
+	return tracing.Wrap(next)
+}
+
+// newLogger returns the logger for --verbose diagnostics on stderr: JSON
+// records in --json mode, text otherwise. It returns nil without --verbose.
+func newLogger() *slog.Logger {
+	if !viper.GetBool("verbose") {
+		return nil
+	}
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if viper.GetBool("json") {
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, opts))
+}
+
+// configureClient applies the endpoint, logging, timeout, quota project,
+// retry and rate-limit settings from the loaded configuration to client.
+func configureClient(client *api.Client) {
+	if Cfg.BaseURL != "" {
+		client.BaseURL = strings.TrimRight(Cfg.BaseURL, "/")
+	}
+	client.Logger = newLogger()
+	client.RequestTimeout = Cfg.RequestTimeout
+	client.QuotaProject = Cfg.QuotaProject
+	client.Retry.MaxAttempts = Cfg.RetryAttempts
+	client.Retry.BaseDelay = Cfg.RetryDelay
+	client.Retry.Jitter = Cfg.RetryJitter
+	client.Limiter = api.NewRateLimiter(Cfg.RateLimitRead, Cfg.RateLimitWrite, Cfg.RateLimitSpaceWrite)
+}
+
 // getFormatter returns a Formatter configured from the current CLI flags.
 func getFormatter() *output.Formatter {
 	return output.NewFormatter(viper.GetBool("json"), viper.GetBool("quiet"))
 }
+
+// newProgress returns the progress reporter for a file transfer: JSON events
+// on stderr in --json mode, a progress bar when stderr is a terminal, and nil
+// otherwise or in --quiet mode.
+func newProgress(f *output.Formatter, label string) output.Progress {
+	switch {
+	case f.Quiet:
+		return nil
+	case f.IsJSON():
+		return output.NewProgressEvents(os.Stderr)
+	case !output.IsTerminal(os.Stderr):
+		return nil
+	}
+	return output.NewProgressBar(os.Stderr, label)
+}
+
+// stdin buffers standard input for readLine. It is shared so that input
+// read ahead for one prompt is not lost to the next.
+var stdin = bufio.NewReader(os.Stdin)
+
+// readLine reads a line of user input from stdin, without its trailing
+// newline. It returns early with ctx's error when ctx is done, so that Ctrl-C
+// at a confirmation prompt cancels the command instead of hanging.
+func readLine(ctx context.Context) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		line, err := stdin.ReadString('\n')
+		ch <- result{strings.TrimSpace(line), err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.line, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// displayUser returns the user's display name, falling back to the resource
+// name when the display name is not populated. It is nil-safe.
+func displayUser(u *api.User) string {
+	if u == nil {
+		return ""
+	}
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Name
+}
+
+// addPaginationFlags registers the --page-size, --page-token, --all and
+// --max-items flags shared by every list command.
+func addPaginationFlags(cmd *cobra.Command, defaultPageSize int, noun string) {
+	flags := cmd.Flags()
+	flags.Int("page-size", defaultPageSize, fmt.Sprintf("Maximum number of %s to return per page", noun))
+	flags.String("page-token", "", "Token for retrieving the next page of results")
+	flags.Bool("all", false, "Auto-paginate through all results")
+	flags.Int("max-items", 0, "With --all, stop after this many results (0 = no limit)")
+}
+
+// pageOptions builds api.PageOptions from the flags registered by
+// addPaginationFlags.
+func pageOptions(cmd *cobra.Command) api.PageOptions {
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+	pageToken, _ := cmd.Flags().GetString("page-token")
+	maxItems, _ := cmd.Flags().GetInt("max-items")
+	return api.PageOptions{PageSize: pageSize, PageToken: pageToken, MaxItems: maxItems}
+}
+
+// streamList drains a listing iterator. In JSON mode every item is written to
+// stdout as soon as it arrives, wrapped as {"key": [...]}. In human mode the
+// items are collected and handed to render, since tables need every row to
+// size their columns. Items received before an error are still output, so an
+// interrupted listing produces valid partial results before the error is
+// returned.
+func streamList[T any](f *output.Formatter, key string, seq iter.Seq2[T, error], render func([]T)) error {
+	var iterErr error
+
+	if f.IsJSON() {
+		stream := output.NewJSONStream(key)
+		for item, err := range seq {
+			if err != nil {
+				iterErr = err
+				break
+			}
+			if err := stream.Write(item); err != nil {
+				return err
+			}
+		}
+		if err := stream.Close(); err != nil && iterErr == nil {
+			return err
+		}
+		return iterErr
+	}
+
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		items = append(items, item)
+	}
+	if iterErr == nil || len(items) > 0 {
+		render(items)
+	}
+	return iterErr
+}
+
+// batchOutcome is the JSON form of one item of a bulk command.
+type batchOutcome struct {
+	Target string `json:"target"`
+	Result any    `json:"result,omitempty"`
+	Error  any    `json:"error,omitempty"`
+}
+
+// newTable returns a table for API resources. With --fields, the columns
+// for fields that were left out of the response are dropped.
+func newTable(headers ...string) *output.Table {
+	table := output.NewTable(headers...)
+	table.OmitEmptyColumns = viper.GetString("fields") != ""
+	return table
+}
+
+// printBatchResults reports the outcome of a bulk command run against
+// targets. In JSON mode it prints {"results": [...]} with one result or
+// error per target. In human mode render is called for each success and
+// failures are printed to stderr. It returns an error counting the failed
+// items, or nil when every item succeeded.
+func printBatchResults[T any](f *output.Formatter, targets []string, results []api.BatchResult[T], noun string, render func(target string, v *T)) error {
+	failed := 0
+	outcomes := make([]batchOutcome, len(results))
+	for i, r := range results {
+		outcomes[i].Target = targets[i]
+		if r.Err != nil {
+			failed++
+			var apiErr *api.APIError
+			if errors.As(r.Err, &apiErr) {
+				outcomes[i].Error = apiErr
+			} else {
+				outcomes[i].Error = map[string]string{"message": r.Err.Error()}
+			}
+			if !f.IsJSON() {
+				f.PrintError(fmt.Sprintf("✗ %s: %v", targets[i], r.Err))
+			}
+			continue
+		}
+		outcomes[i].Result = output.JSONValue(r.Value)
+		if !f.IsJSON() {
+			render(targets[i], r.Value)
+		}
+	}
+
+	if f.IsJSON() {
+		if err := f.Print(map[string]any{"results": outcomes}); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d %s failed", failed, len(results), noun)
+	}
+	return nil
+}
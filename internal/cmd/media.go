@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -35,8 +37,14 @@ func newMediaUploadCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "upload SPACE",
 		Short: "Upload a file to a space",
-		Long:  "Upload a file as an attachment to the specified Google Chat space. SPACE is the space resource name (spaces/{space}) or just the space ID.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Upload a file as an attachment to the specified Google Chat space. SPACE is the space resource name (spaces/{space}) or just the space ID.
+
+The file is streamed from disk. Files larger than 5 MiB (or any file with
+--resumable) use the resumable upload protocol, so an interrupted transfer
+continues from the last byte the server committed. A progress bar is shown
+on stderr when it is a terminal; with --json, progress is reported on stderr
+as newline-delimited JSON events.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := newAPIClient()
 			if err != nil {
@@ -60,29 +68,33 @@ func newMediaUploadCmd() *cobra.Command {
 				return fmt.Errorf("%s is a directory, not a file", filePath)
 			}
 
-			raw, err := svc.Upload(cmd.Context(), parent, filePath)
+			resumable, _ := cmd.Flags().GetBool("resumable")
+			chunkSize, _ := cmd.Flags().GetInt64("chunk-size")
+			opts := &api.UploadOptions{Resumable: resumable, ChunkSize: chunkSize}
+
+			progress := newProgress(formatter, filepath.Base(filePath))
+			if progress != nil {
+				opts.Progress = progress.Set
+			}
+			result, err := svc.Upload(cmd.Context(), parent, filePath, opts)
+			if progress != nil {
+				progress.Done()
+			}
 			if err != nil {
 				return fmt.Errorf("uploading media: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
+				return formatter.Print(result)
 			}
 
-			// Parse and display the upload result.
-			var result struct {
-				AttachmentDataRef struct {
-					ResourceName string `json:"resourceName"`
-				} `json:"attachmentDataRef"`
-			}
-			if err := json.Unmarshal(raw, &result); err != nil {
-				// If the response doesn't match expected structure, show raw.
-				formatter.PrintSuccess("File uploaded successfully!")
-				return formatter.PrintRaw(raw)
+			resourceName := ""
+			if result.AttachmentDataRef != nil {
+				resourceName = result.AttachmentDataRef.ResourceName
 			}
 
 			formatter.PrintSuccess("File uploaded successfully!")
-			fmt.Printf("Resource Name: %s\n", result.AttachmentDataRef.ResourceName)
+			fmt.Printf("Resource Name: %s\n", resourceName)
 			fmt.Printf("Source File:   %s\n", filePath)
 			fmt.Printf("File Size:     %d bytes\n", info.Size())
 
@@ -92,6 +104,8 @@ func newMediaUploadCmd() *cobra.Command {
 
 	cmd.Flags().String("file", "", "Path to the file to upload (required)")
 	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().Bool("resumable", false, "Use the resumable upload protocol regardless of file size")
+	cmd.Flags().Int64("chunk-size", api.DefaultChunkSize, "Bytes sent per resumable upload request (rounded up to a multiple of 256 KiB)")
 
 	return cmd
 }
@@ -101,8 +115,15 @@ func newMediaDownloadCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "download RESOURCE",
 		Short: "Download a media resource",
-		Long:  "Download media content by resource name and save it to a local file. RESOURCE is the full media resource name.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Download media content by resource name and save it to a local file. RESOURCE is the full media resource name, or an attachment name (spaces/{space}/messages/{message}/attachments/{attachment}), which is resolved to its media first.
+
+Without --output, the file name is the attachment's original name, the name
+sent by the server in Content-Disposition, or the last segment of RESOURCE.
+Use --output - to write the content to stdout; the summary then goes to
+stderr. With --resume, an existing partial file is completed with a ranged
+request instead of being downloaded again. The SHA-256 of the content is
+printed once the download finishes.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := newAPIClient()
 			if err != nil {
@@ -113,50 +134,175 @@ func newMediaDownloadCmd() *cobra.Command {
 
 			resourceName := args[0]
 			outputPath, _ := cmd.Flags().GetString("output")
+			resume, _ := cmd.Flags().GetBool("resume")
+			toStdout := outputPath == "-"
+			if toStdout && resume {
+				return fmt.Errorf("--resume cannot be used with --output -")
+			}
 
-			// Derive the output file name if not specified.
-			if outputPath == "" {
-				outputPath = deriveOutputFilename(resourceName)
+			// An attachment is resolved to its media resource, which also
+			// tells us the original file name. The lookup needs the full
+			// attachment regardless of --fields.
+			if isAttachmentName(resourceName) {
+				att, err := api.NewAttachmentsService(client).Get(api.WithFields(cmd.Context(), ""), resourceName)
+				if err != nil {
+					return fmt.Errorf("getting attachment: %w", err)
+				}
+				if att.AttachmentDataRef == nil || att.AttachmentDataRef.ResourceName == "" {
+					return fmt.Errorf("attachment %s has no downloadable content (source: %s)", resourceName, att.Source)
+				}
+				resourceName = att.AttachmentDataRef.ResourceName
+				if outputPath == "" {
+					outputPath = safeFilename(att.ContentName)
+				}
 			}
 
-			body, contentType, err := svc.Download(cmd.Context(), resourceName)
+			dl, err := svc.Download(cmd.Context(), resourceName, partialSize(outputPath, resume))
 			if err != nil {
 				return fmt.Errorf("downloading media: %w", err)
 			}
-			defer body.Close()
+			defer dl.Body.Close()
 
-			// Create the output file.
-			outFile, err := os.Create(outputPath)
-			if err != nil {
-				return fmt.Errorf("creating output file %s: %w", outputPath, err)
+			if outputPath == "" {
+				outputPath = dl.Filename
+				if outputPath == "" {
+					outputPath = deriveOutputFilename(resourceName)
+				}
+				// The name only became known from the response, so a
+				// partial file needs a second, ranged request.
+				if offset := partialSize(outputPath, resume); offset > 0 {
+					dl.Body.Close()
+					if dl, err = svc.Download(cmd.Context(), resourceName, offset); err != nil {
+						return fmt.Errorf("downloading media: %w", err)
+					}
+				}
 			}
-			defer outFile.Close()
 
-			written, err := io.Copy(outFile, body)
+			hash := sha256.New()
+			dest := io.Writer(os.Stdout)
+			label := deriveOutputFilename(resourceName)
+			if !toStdout {
+				f, err := openDownloadFile(outputPath, dl.Offset, hash)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				dest, label = f, filepath.Base(outputPath)
+			}
+
+			progress := newProgress(formatter, label)
+			if progress != nil {
+				dl.Progress = progress.Set
+			}
+			written, err := dl.WriteTo(io.MultiWriter(dest, hash))
+			if progress != nil {
+				progress.Done()
+			}
 			if err != nil {
-				return fmt.Errorf("writing to file %s: %w", outputPath, err)
+				return fmt.Errorf("downloading media: %w", err)
 			}
 
+			size := dl.Offset + written
+			sum := hex.EncodeToString(hash.Sum(nil))
+
 			if formatter.IsJSON() {
 				result := map[string]interface{}{
 					"outputFile":  outputPath,
-					"size":        written,
-					"contentType": contentType,
+					"size":        size,
+					"contentType": dl.ContentType,
+					"sha256":      sum,
+				}
+				if dl.Offset > 0 {
+					result["resumedAt"] = dl.Offset
+				}
+				if toStdout {
+					data, err := json.MarshalIndent(result, "", "  ")
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(os.Stderr, string(data))
+					return nil
 				}
 				return formatter.Print(result)
 			}
 
-			formatter.PrintSuccess(fmt.Sprintf("Downloaded to %s (%d bytes, %s)", outputPath, written, contentType))
+			summary := fmt.Sprintf("Downloaded to %s (%d bytes, %s)", outputPath, size, dl.ContentType)
+			if toStdout {
+				summary = fmt.Sprintf("Downloaded %d bytes (%s)", size, dl.ContentType)
+			}
+			if dl.Offset > 0 {
+				summary += fmt.Sprintf(", resumed at byte %d", dl.Offset)
+			}
+			if toStdout {
+				if !formatter.Quiet {
+					fmt.Fprintf(os.Stderr, "✓ %s\nSHA-256: %s\n", summary, sum)
+				}
+				return nil
+			}
+			formatter.PrintSuccess(summary)
+			formatter.PrintMessage("SHA-256: " + sum)
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringP("output", "o", "", "Output file path (defaults to derived name from resource)")
+	cmd.Flags().StringP("output", "o", "", "Output file path, or - for stdout (defaults to the attachment's file name)")
+	cmd.Flags().Bool("resume", false, "Continue a partial download in the output file instead of starting over")
 
 	return cmd
 }
 
+// isAttachmentName reports whether name is a message attachment name
+// (spaces/{space}/messages/{message}/attachments/{attachment}) rather than
+// a media resource name.
+func isAttachmentName(name string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) == 6 && parts[0] == "spaces" && parts[2] == "messages" && parts[4] == "attachments"
+}
+
+// safeFilename reduces a server-supplied file name to its base name, or ""
+// if nothing usable is left.
+func safeFilename(name string) string {
+	name = filepath.Base(filepath.FromSlash(name))
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		return ""
+	}
+	return name
+}
+
+// partialSize returns the size of an existing partial download at path when
+// resuming, or 0 to download from the start.
+func partialSize(path string, resume bool) int64 {
+	if !resume || path == "" {
+		return 0
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return 0
+	}
+	return info.Size()
+}
+
+// openDownloadFile opens the output file for a download starting at byte
+// offset. The first offset bytes already in the file are fed to hash and
+// kept; anything after them is discarded. With offset 0 the file is
+// truncated.
+func openDownloadFile(path string, offset int64, hash io.Writer) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("creating output file %s: %w", path, err)
+	}
+	if _, err := io.CopyN(hash, f, offset); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("reading partial file %s: %w", path, err)
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("truncating %s: %w", path, err)
+	}
+	return f, nil
+}
+
 // deriveOutputFilename attempts to extract a reasonable filename from a
 // resource name. If no meaningful name can be derived, it falls back to
 // "download".
@@ -1,11 +1,8 @@
 package cmd
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/cipher-shad0w/gogchat/internal/api"
 	"github.com/cipher-shad0w/gogchat/internal/output"
@@ -49,120 +46,64 @@ func newMembersListCmd() *cobra.Command {
 			svc := api.NewMembersService(client)
 
 			space := args[0]
-			pageSize, _ := cmd.Flags().GetInt("page-size")
-			pageToken, _ := cmd.Flags().GetString("page-token")
 			filter, _ := cmd.Flags().GetString("filter")
 			showInvited, _ := cmd.Flags().GetBool("show-invited")
 			showGroups, _ := cmd.Flags().GetBool("show-groups")
 			admin, _ := cmd.Flags().GetBool("admin")
 			all, _ := cmd.Flags().GetBool("all")
+			opts := pageOptions(cmd)
 
 			if all {
-				return membersListAll(cmd, svc, f, space, pageSize, filter, showInvited, showGroups, admin)
+				seq := svc.All(cmd.Context(), space, filter, showInvited, showGroups, admin, opts)
+				if err := streamList(f, "memberships", seq, func(ms []*api.Membership) {
+					_ = printMembersList(f, &api.ListMembershipsResponse{Memberships: ms})
+				}); err != nil {
+					return fmt.Errorf("listing members: %w", err)
+				}
+				return nil
 			}
 
-			result, err := svc.List(cmd.Context(), space, pageSize, pageToken, filter, showInvited, showGroups, admin)
+			result, err := svc.List(cmd.Context(), space, opts.PageSize, opts.PageToken, filter, showInvited, showGroups, admin)
 			if err != nil {
 				return fmt.Errorf("listing members: %w", err)
 			}
 
 			if f.IsJSON() {
-				return f.PrintRaw(result)
+				return f.Print(result)
 			}
 
 			return printMembersList(f, result)
 		},
 	}
 
-	cmd.Flags().Int("page-size", 100, "Maximum number of members to return")
-	cmd.Flags().String("page-token", "", "Page token for pagination")
 	cmd.Flags().String("filter", "", "Filter query for members")
 	cmd.Flags().Bool("show-invited", false, "Include invited members")
 	cmd.Flags().Bool("show-groups", false, "Include Google Groups members")
-	cmd.Flags().Bool("all", false, "Fetch all pages of results")
+	addPaginationFlags(cmd, 100, "members")
 
 	return cmd
 }
 
-// membersListAll fetches all pages of members and prints them.
-func membersListAll(cmd *cobra.Command, svc *api.MembersService, f *output.Formatter, space string, pageSize int, filter string, showInvited, showGroups, admin bool) error {
-	var allMemberships []json.RawMessage
-	pageToken := ""
-
-	for {
-		result, err := svc.List(cmd.Context(), space, pageSize, pageToken, filter, showInvited, showGroups, admin)
-		if err != nil {
-			return fmt.Errorf("listing members: %w", err)
-		}
-
-		var page struct {
-			Memberships   []json.RawMessage `json:"memberships"`
-			NextPageToken string            `json:"nextPageToken"`
-		}
-		if err := json.Unmarshal(result, &page); err != nil {
-			return fmt.Errorf("parsing response: %w", err)
-		}
-
-		allMemberships = append(allMemberships, page.Memberships...)
-
-		if page.NextPageToken == "" {
-			break
-		}
-		pageToken = page.NextPageToken
-	}
-
-	if f.IsJSON() {
-		combined := map[string]interface{}{
-			"memberships": allMemberships,
-		}
-		return f.Print(combined)
-	}
-
-	// Build a synthetic response for the human-readable printer.
-	combined, err := json.Marshal(map[string]interface{}{
-		"memberships": allMemberships,
-	})
-	if err != nil {
-		return fmt.Errorf("marshaling combined results: %w", err)
-	}
-
-	return printMembersList(f, json.RawMessage(combined))
-}
-
 // printMembersList renders the memberships list as a human-readable table.
-func printMembersList(f *output.Formatter, raw json.RawMessage) error {
-	var data struct {
-		Memberships []struct {
-			Name   string `json:"name"`
-			Member struct {
-				Name        string `json:"name"`
-				DisplayName string `json:"displayName"`
-				Type        string `json:"type"`
-			} `json:"member"`
-			Role  string      `json:"role"`
-			State interface{} `json:"state"`
-		} `json:"memberships"`
-		NextPageToken string `json:"nextPageToken"`
-	}
-	if err := json.Unmarshal(raw, &data); err != nil {
-		return fmt.Errorf("parsing memberships: %w", err)
-	}
-
+func printMembersList(f *output.Formatter, data *api.ListMembershipsResponse) error {
 	if len(data.Memberships) == 0 {
 		f.PrintMessage("No members found.")
 		return nil
 	}
 
-	table := output.NewTable("NAME", "MEMBER_NAME", "DISPLAY_NAME", "ROLE", "TYPE", "STATE")
+	table := newTable("NAME", "MEMBER_NAME", "DISPLAY_NAME", "ROLE", "TYPE", "STATE")
 	for _, m := range data.Memberships {
-		state := formatMemberState(m.State)
+		member := m.Member
+		if member == nil {
+			member = &api.User{}
+		}
 		table.AddRow(
 			m.Name,
-			m.Member.Name,
-			m.Member.DisplayName,
+			member.Name,
+			member.DisplayName,
 			m.Role,
-			m.Member.Type,
-			state,
+			member.Type,
+			m.State,
 		)
 	}
 
@@ -175,34 +116,6 @@ func printMembersList(f *output.Formatter, raw json.RawMessage) error {
 	return nil
 }
 
-// formatMemberState converts a membership state value to a string.
-// The state may be a string or an enum integer from the API.
-func formatMemberState(state interface{}) string {
-	if state == nil {
-		return ""
-	}
-	switch v := state.(type) {
-	case string:
-		return v
-	case float64:
-		// Google API sometimes returns enum values as integers.
-		switch int(v) {
-		case 0:
-			return "MEMBER_STATE_UNSPECIFIED"
-		case 1:
-			return "JOINED"
-		case 2:
-			return "INVITED"
-		case 3:
-			return "NOT_A_MEMBER"
-		default:
-			return fmt.Sprintf("%d", int(v))
-		}
-	default:
-		return fmt.Sprintf("%v", v)
-	}
-}
-
 // newMembersGetCmd creates the "members get" subcommand.
 func newMembersGetCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -227,10 +140,11 @@ func newMembersGetCmd() *cobra.Command {
 			}
 
 			if f.IsJSON() {
-				return f.PrintRaw(result)
+				return f.Print(result)
 			}
 
-			return printMemberDetail(result)
+			printMemberDetail(result)
+			return nil
 		},
 	}
 
@@ -238,32 +152,12 @@ func newMembersGetCmd() *cobra.Command {
 }
 
 // printMemberDetail renders a single membership as a detailed key-value display.
-func printMemberDetail(raw json.RawMessage) error {
-	var data struct {
-		Name       string `json:"name"`
-		CreateTime string `json:"createTime"`
-		DeleteTime string `json:"deleteTime"`
-		Member     struct {
-			Name        string `json:"name"`
-			DisplayName string `json:"displayName"`
-			Type        string `json:"type"`
-			DomainID    string `json:"domainId"`
-		} `json:"member"`
-		GroupMember struct {
-			Name string `json:"name"`
-		} `json:"groupMember"`
-		Role  string      `json:"role"`
-		State interface{} `json:"state"`
-	}
-	if err := json.Unmarshal(raw, &data); err != nil {
-		return fmt.Errorf("parsing membership: %w", err)
-	}
-
+func printMemberDetail(data *api.Membership) {
 	fmt.Printf("Name:          %s\n", data.Name)
 	fmt.Printf("Role:          %s\n", data.Role)
-	fmt.Printf("State:         %s\n", formatMemberState(data.State))
+	fmt.Printf("State:         %s\n", data.State)
 
-	if data.Member.Name != "" {
+	if data.Member != nil && data.Member.Name != "" {
 		fmt.Printf("Member Name:   %s\n", data.Member.Name)
 		fmt.Printf("Display Name:  %s\n", data.Member.DisplayName)
 		fmt.Printf("Type:          %s\n", data.Member.Type)
@@ -272,7 +166,7 @@ func printMemberDetail(raw json.RawMessage) error {
 		}
 	}
 
-	if data.GroupMember.Name != "" {
+	if data.GroupMember != nil && data.GroupMember.Name != "" {
 		fmt.Printf("Group Member:  %s\n", data.GroupMember.Name)
 	}
 
@@ -282,8 +176,6 @@ func printMemberDetail(raw json.RawMessage) error {
 	if data.DeleteTime != "" {
 		fmt.Printf("Deleted:       %s\n", output.FormatTime(data.DeleteTime))
 	}
-
-	return nil
 }
 
 // newMembersAddCmd creates the "members add" subcommand.
@@ -306,12 +198,12 @@ func newMembersAddCmd() *cobra.Command {
 			role, _ := cmd.Flags().GetString("role")
 			admin, _ := cmd.Flags().GetBool("admin")
 
-			membership := map[string]interface{}{
-				"member": map[string]interface{}{
-					"name": user,
-					"type": "HUMAN",
+			membership := &api.Membership{
+				Member: &api.User{
+					Name: user,
+					Type: "HUMAN",
 				},
-				"role": role,
+				Role: role,
 			}
 
 			result, err := svc.Create(cmd.Context(), space, membership, admin)
@@ -320,11 +212,12 @@ func newMembersAddCmd() *cobra.Command {
 			}
 
 			if f.IsJSON() {
-				return f.PrintRaw(result)
+				return f.Print(result)
 			}
 
 			f.PrintSuccess(fmt.Sprintf("Member added to space %s", space))
-			return printMemberDetail(result)
+			printMemberDetail(result)
+			return nil
 		},
 	}
 
@@ -355,8 +248,8 @@ func newMembersUpdateCmd() *cobra.Command {
 			updateMask, _ := cmd.Flags().GetString("update-mask")
 			admin, _ := cmd.Flags().GetBool("admin")
 
-			membership := map[string]interface{}{
-				"role": role,
+			membership := &api.Membership{
+				Role: role,
 			}
 
 			result, err := svc.Patch(cmd.Context(), name, membership, updateMask, admin)
@@ -365,11 +258,12 @@ func newMembersUpdateCmd() *cobra.Command {
 			}
 
 			if f.IsJSON() {
-				return f.PrintRaw(result)
+				return f.Print(result)
 			}
 
 			f.PrintSuccess(fmt.Sprintf("Member %s updated", name))
-			return printMemberDetail(result)
+			printMemberDetail(result)
+			return nil
 		},
 	}
 
@@ -401,12 +295,10 @@ func newMembersRemoveCmd() *cobra.Command {
 
 			if !force {
 				fmt.Fprintf(os.Stderr, "Remove member %s? [y/N]: ", name)
-				reader := bufio.NewReader(os.Stdin)
-				answer, err := reader.ReadString('\n')
+				answer, err := readLine(cmd.Context())
 				if err != nil {
 					return fmt.Errorf("reading confirmation: %w", err)
 				}
-				answer = strings.TrimSpace(answer)
 				if answer != "y" && answer != "Y" {
 					fmt.Fprintln(os.Stderr, "Cancelled.")
 					return nil
@@ -419,7 +311,7 @@ func newMembersRemoveCmd() *cobra.Command {
 			}
 
 			if f.IsJSON() {
-				return f.PrintRaw(result)
+				return f.Print(result)
 			}
 
 			f.PrintSuccess(fmt.Sprintf("Member %s removed", name))
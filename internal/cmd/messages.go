@@ -1,10 +1,9 @@
 package cmd
 
 import (
-	"bufio"
-	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -48,12 +47,10 @@ func newMessagesListCmd() *cobra.Command {
 	}
 
 	flags := cmd.Flags()
-	flags.Int("page-size", 25, "Maximum number of messages to return per page")
-	flags.String("page-token", "", "Token for retrieving the next page of results")
 	flags.String("filter", "", "Filter expression for messages")
 	flags.String("order-by", "", "Order results (e.g. 'createTime desc')")
 	flags.Bool("show-deleted", false, "Include deleted messages in results")
-	flags.Bool("all", false, "Auto-paginate through all results")
+	addPaginationFlags(cmd, 25, "messages")
 
 	return cmd
 }
@@ -65,89 +62,62 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 	}
 	f := getFormatter()
 	svc := api.NewMessagesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	parent := args[0]
-	pageSize, _ := cmd.Flags().GetInt("page-size")
-	pageToken, _ := cmd.Flags().GetString("page-token")
 	filter, _ := cmd.Flags().GetString("filter")
 	orderBy, _ := cmd.Flags().GetString("order-by")
 	showDeleted, _ := cmd.Flags().GetBool("show-deleted")
 	all, _ := cmd.Flags().GetBool("all")
+	opts := pageOptions(cmd)
 
-	// Collect all pages when --all is set, otherwise fetch a single page.
-	var allMessages []json.RawMessage
-
-	for {
-		raw, err := svc.List(ctx, parent, pageSize, pageToken, filter, orderBy, showDeleted)
-		if err != nil {
+	if all {
+		seq := svc.All(ctx, parent, filter, orderBy, showDeleted, opts)
+		if err := streamList(f, "messages", seq, func(msgs []*api.Message) {
+			printMessagesTable(f, msgs)
+		}); err != nil {
 			return fmt.Errorf("listing messages: %w", err)
 		}
+		return nil
+	}
 
-		if f.IsJSON() && !all {
-			return f.PrintRaw(raw)
-		}
-
-		var resp struct {
-			Messages      []json.RawMessage `json:"messages"`
-			NextPageToken string            `json:"nextPageToken"`
-		}
-		if err := json.Unmarshal(raw, &resp); err != nil {
-			return fmt.Errorf("parsing response: %w", err)
-		}
-
-		allMessages = append(allMessages, resp.Messages...)
-
-		if !all || resp.NextPageToken == "" {
-			pageToken = resp.NextPageToken
-			break
-		}
-		pageToken = resp.NextPageToken
+	resp, err := svc.List(ctx, parent, opts.PageSize, opts.PageToken, filter, orderBy, showDeleted)
+	if err != nil {
+		return fmt.Errorf("listing messages: %w", err)
 	}
 
-	// JSON mode with --all: emit aggregated result.
 	if f.IsJSON() {
-		return f.Print(map[string]interface{}{
-			"messages": allMessages,
-		})
+		return f.Print(resp)
 	}
 
-	if len(allMessages) == 0 {
-		f.PrintMessage("No messages found.")
-		return nil
+	printMessagesTable(f, resp.Messages)
+
+	if resp.NextPageToken != "" {
+		f.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", resp.NextPageToken))
 	}
 
-	table := output.NewTable("NAME", "SENDER", "TEXT", "CREATE_TIME")
+	return nil
+}
 
-	for _, raw := range allMessages {
-		var msg struct {
-			Name       string `json:"name"`
-			Text       string `json:"text"`
-			CreateTime string `json:"createTime"`
-			Sender     struct {
-				DisplayName string `json:"displayName"`
-				Name        string `json:"name"`
-			} `json:"sender"`
-		}
-		if err := json.Unmarshal(raw, &msg); err != nil {
-			continue
-		}
+// printMessagesTable renders messages as a table.
+func printMessagesTable(f *output.Formatter, msgs []*api.Message) {
+	if len(msgs) == 0 {
+		f.PrintMessage("No messages found.")
+		return
+	}
 
-		sender := msg.Sender.DisplayName
-		if sender == "" {
-			sender = msg.Sender.Name
-		}
+	table := newTable("NAME", "SENDER", "TEXT", "CREATE_TIME")
 
+	for _, msg := range msgs {
 		table.AddRow(
 			msg.Name,
-			sender,
+			displayUser(msg.Sender),
 			output.Truncate(msg.Text, 60),
 			output.FormatTime(msg.CreateTime),
 		)
 	}
 
 	f.PrintMessage(table.Render())
-	return nil
 }
 
 // ---------------------------------------------------------------------------
@@ -174,43 +144,26 @@ func runMessagesGet(cmd *cobra.Command, args []string) error {
 	f := getFormatter()
 	svc := api.NewMessagesService(client)
 
-	raw, err := svc.Get(context.Background(), args[0])
+	msg, err := svc.Get(cmd.Context(), args[0])
 	if err != nil {
 		return fmt.Errorf("getting message: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
+		return f.Print(msg)
 	}
 
-	var msg struct {
-		Name           string `json:"name"`
-		Text           string `json:"text"`
-		CreateTime     string `json:"createTime"`
-		LastUpdateTime string `json:"lastUpdateTime"`
-		Sender         struct {
-			DisplayName string `json:"displayName"`
-			Name        string `json:"name"`
-		} `json:"sender"`
-		Thread struct {
-			Name string `json:"name"`
-		} `json:"thread"`
-	}
-	if err := json.Unmarshal(raw, &msg); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
-	}
-
-	sender := msg.Sender.DisplayName
-	if sender == "" {
-		sender = msg.Sender.Name
+	threadName := ""
+	if msg.Thread != nil {
+		threadName = msg.Thread.Name
 	}
 
 	f.PrintMessage(fmt.Sprintf("Name:             %s", msg.Name))
-	f.PrintMessage(fmt.Sprintf("Sender:           %s", sender))
+	f.PrintMessage(fmt.Sprintf("Sender:           %s", displayUser(msg.Sender)))
 	f.PrintMessage(fmt.Sprintf("Text:             %s", msg.Text))
 	f.PrintMessage(fmt.Sprintf("Create Time:      %s", output.FormatTime(msg.CreateTime)))
 	f.PrintMessage(fmt.Sprintf("Last Update Time: %s", output.FormatTime(msg.LastUpdateTime)))
-	f.PrintMessage(fmt.Sprintf("Thread Name:      %s", msg.Thread.Name))
+	f.PrintMessage(fmt.Sprintf("Thread Name:      %s", threadName))
 
 	return nil
 }
@@ -253,46 +206,25 @@ func runMessagesSend(cmd *cobra.Command, args []string) error {
 	messageID, _ := cmd.Flags().GetString("message-id")
 	replyOption, _ := cmd.Flags().GetString("reply-option")
 
-	body := map[string]interface{}{
-		"text": text,
+	body := &api.Message{
+		Text: text,
 	}
 
-	raw, err := svc.Create(context.Background(), args[0], body, threadKey, requestID, messageID, replyOption)
+	msg, err := svc.Create(cmd.Context(), args[0], body, threadKey, requestID, messageID, replyOption)
 	if err != nil {
 		return fmt.Errorf("sending message: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
-	}
-
-	var msg struct {
-		Name       string `json:"name"`
-		Text       string `json:"text"`
-		CreateTime string `json:"createTime"`
-		Sender     struct {
-			DisplayName string `json:"displayName"`
-			Name        string `json:"name"`
-		} `json:"sender"`
-		Thread struct {
-			Name string `json:"name"`
-		} `json:"thread"`
-	}
-	if err := json.Unmarshal(raw, &msg); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
-	}
-
-	sender := msg.Sender.DisplayName
-	if sender == "" {
-		sender = msg.Sender.Name
+		return f.Print(msg)
 	}
 
 	f.PrintSuccess("Message sent")
 	f.PrintMessage(fmt.Sprintf("Name:        %s", msg.Name))
-	f.PrintMessage(fmt.Sprintf("Sender:      %s", sender))
+	f.PrintMessage(fmt.Sprintf("Sender:      %s", displayUser(msg.Sender)))
 	f.PrintMessage(fmt.Sprintf("Text:        %s", output.Truncate(msg.Text, 80)))
 	f.PrintMessage(fmt.Sprintf("Create Time: %s", output.FormatTime(msg.CreateTime)))
-	if msg.Thread.Name != "" {
+	if msg.Thread != nil && msg.Thread.Name != "" {
 		f.PrintMessage(fmt.Sprintf("Thread:      %s", msg.Thread.Name))
 	}
 
@@ -333,26 +265,17 @@ func runMessagesUpdate(cmd *cobra.Command, args []string) error {
 	updateMask, _ := cmd.Flags().GetString("update-mask")
 	allowMissing, _ := cmd.Flags().GetBool("allow-missing")
 
-	body := map[string]interface{}{
-		"text": text,
+	body := &api.Message{
+		Text: text,
 	}
 
-	raw, err := svc.Patch(context.Background(), args[0], body, updateMask, allowMissing)
+	msg, err := svc.Patch(cmd.Context(), args[0], body, updateMask, allowMissing)
 	if err != nil {
 		return fmt.Errorf("updating message: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
-	}
-
-	var msg struct {
-		Name           string `json:"name"`
-		Text           string `json:"text"`
-		LastUpdateTime string `json:"lastUpdateTime"`
-	}
-	if err := json.Unmarshal(raw, &msg); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
+		return f.Print(msg)
 	}
 
 	f.PrintSuccess("Message updated")
@@ -398,22 +321,24 @@ func runMessagesDelete(cmd *cobra.Command, args []string) error {
 	// Confirmation prompt unless --force is set.
 	if !force {
 		fmt.Fprintf(os.Stderr, "Delete message %s? [y/N] ", name)
-		reader := bufio.NewReader(os.Stdin)
-		answer, _ := reader.ReadString('\n')
-		answer = strings.TrimSpace(strings.ToLower(answer))
+		answer, err := readLine(cmd.Context())
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("reading confirmation: %w", err)
+		}
+		answer = strings.ToLower(answer)
 		if answer != "y" && answer != "yes" {
 			f.PrintMessage("Cancelled.")
 			return nil
 		}
 	}
 
-	raw, err := svc.Delete(context.Background(), name, forceThreads)
+	resp, err := svc.Delete(cmd.Context(), name, forceThreads)
 	if err != nil {
 		return fmt.Errorf("deleting message: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
+		return f.Print(resp)
 	}
 
 	f.PrintSuccess(fmt.Sprintf("Message %s deleted.", name))
@@ -454,26 +379,17 @@ func runMessagesReplace(cmd *cobra.Command, args []string) error {
 	updateMask, _ := cmd.Flags().GetString("update-mask")
 	allowMissing, _ := cmd.Flags().GetBool("allow-missing")
 
-	body := map[string]interface{}{
-		"text": text,
+	body := &api.Message{
+		Text: text,
 	}
 
-	raw, err := svc.Update(context.Background(), args[0], body, updateMask, allowMissing)
+	msg, err := svc.Update(cmd.Context(), args[0], body, updateMask, allowMissing)
 	if err != nil {
 		return fmt.Errorf("replacing message: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
-	}
-
-	var msg struct {
-		Name           string `json:"name"`
-		Text           string `json:"text"`
-		LastUpdateTime string `json:"lastUpdateTime"`
-	}
-	if err := json.Unmarshal(raw, &msg); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
+		return f.Print(msg)
 	}
 
 	f.PrintSuccess("Message replaced")
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -44,22 +43,13 @@ func newNotificationsGetCmd() *cobra.Command {
 
 			name := args[0]
 
-			raw, err := svc.Get(cmd.Context(), name)
+			setting, err := svc.Get(cmd.Context(), name)
 			if err != nil {
 				return fmt.Errorf("getting notification settings: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			var setting struct {
-				Name                string `json:"name"`
-				NotificationSetting string `json:"notificationSetting"`
-				MuteSetting         string `json:"muteSetting"`
-			}
-			if err := json.Unmarshal(raw, &setting); err != nil {
-				return fmt.Errorf("parsing response: %w", err)
+				return formatter.Print(setting)
 			}
 
 			fmt.Printf("Name:                  %s\n", setting.Name)
@@ -98,19 +88,19 @@ is explicitly provided.`,
 			muteSetting, _ := cmd.Flags().GetString("mute-setting")
 			updateMask, _ := cmd.Flags().GetString("update-mask")
 
-			body := map[string]interface{}{}
+			body := &api.SpaceNotificationSetting{}
 			var maskParts []string
 
 			if notificationSetting != "" {
-				body["notificationSetting"] = notificationSetting
+				body.NotificationSetting = notificationSetting
 				maskParts = append(maskParts, "notificationSetting")
 			}
 			if muteSetting != "" {
-				body["muteSetting"] = muteSetting
+				body.MuteSetting = muteSetting
 				maskParts = append(maskParts, "muteSetting")
 			}
 
-			if len(body) == 0 {
+			if len(maskParts) == 0 {
 				return fmt.Errorf("at least one of --notification-setting or --mute-setting must be provided")
 			}
 
@@ -119,22 +109,13 @@ is explicitly provided.`,
 				updateMask = strings.Join(maskParts, ",")
 			}
 
-			raw, err := svc.Patch(cmd.Context(), name, body, updateMask)
+			setting, err := svc.Patch(cmd.Context(), name, body, updateMask)
 			if err != nil {
 				return fmt.Errorf("updating notification settings: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			var setting struct {
-				Name                string `json:"name"`
-				NotificationSetting string `json:"notificationSetting"`
-				MuteSetting         string `json:"muteSetting"`
-			}
-			if err := json.Unmarshal(raw, &setting); err != nil {
-				return fmt.Errorf("parsing response: %w", err)
+				return formatter.Print(setting)
 			}
 
 			formatter.PrintSuccess("Notification setting updated.")
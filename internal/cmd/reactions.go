@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"unicode"
 
@@ -45,103 +44,61 @@ func newReactionsListCmd() *cobra.Command {
 			svc := api.NewReactionsService(client)
 
 			parent := args[0]
-			pageSize, _ := cmd.Flags().GetInt("page-size")
-			pageToken, _ := cmd.Flags().GetString("page-token")
 			filter, _ := cmd.Flags().GetString("filter")
 			all, _ := cmd.Flags().GetBool("all")
+			opts := pageOptions(cmd)
 
 			ctx := cmd.Context()
 
-			// Collect all pages if --all is set; otherwise fetch a single page.
-			var allReactions []json.RawMessage
-
-			for {
-				raw, err := svc.List(ctx, parent, pageSize, pageToken, filter)
-				if err != nil {
+			if all {
+				if err := streamList(formatter, "reactions", svc.All(ctx, parent, filter, opts), func(reactions []*api.Reaction) {
+					printReactionsTable(formatter, reactions)
+				}); err != nil {
 					return fmt.Errorf("listing reactions: %w", err)
 				}
-
-				if formatter.IsJSON() && !all {
-					return formatter.PrintRaw(raw)
-				}
-
-				var resp struct {
-					Reactions []json.RawMessage `json:"reactions"`
-					NextPage  string            `json:"nextPageToken"`
-				}
-				if err := json.Unmarshal(raw, &resp); err != nil {
-					return fmt.Errorf("parsing response: %w", err)
-				}
-
-				allReactions = append(allReactions, resp.Reactions...)
-
-				if !all || resp.NextPage == "" {
-					pageToken = resp.NextPage
-					break
-				}
-				pageToken = resp.NextPage
-			}
-
-			if formatter.IsJSON() {
-				// --all + --json: emit collected reactions as a JSON array.
-				return formatter.Print(allReactions)
-			}
-
-			if len(allReactions) == 0 {
-				formatter.PrintMessage("No reactions found.")
 				return nil
 			}
 
-			table := output.NewTable("REACTION_NAME", "EMOJI", "USER")
-			for _, r := range allReactions {
-				var reaction struct {
-					Name  string `json:"name"`
-					Emoji struct {
-						Unicode     string `json:"unicode"`
-						CustomEmoji struct {
-							UID string `json:"uid"`
-						} `json:"customEmoji"`
-					} `json:"emoji"`
-					User struct {
-						Name        string `json:"name"`
-						DisplayName string `json:"displayName"`
-					} `json:"user"`
-				}
-				if err := json.Unmarshal(r, &reaction); err != nil {
-					continue
-				}
-
-				emoji := reaction.Emoji.Unicode
-				if emoji == "" {
-					emoji = reaction.Emoji.CustomEmoji.UID
-				}
-
-				user := reaction.User.DisplayName
-				if user == "" {
-					user = reaction.User.Name
-				}
+			resp, err := svc.List(ctx, parent, opts.PageSize, opts.PageToken, filter)
+			if err != nil {
+				return fmt.Errorf("listing reactions: %w", err)
+			}
 
-				table.AddRow(reaction.Name, emoji, user)
+			if formatter.IsJSON() {
+				return formatter.Print(resp)
 			}
 
-			fmt.Print(table.Render())
+			printReactionsTable(formatter, resp.Reactions)
 
-			if !all && pageToken != "" {
-				formatter.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", pageToken))
+			if resp.NextPageToken != "" {
+				formatter.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", resp.NextPageToken))
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().Int("page-size", 25, "Maximum number of reactions to return per page")
-	cmd.Flags().String("page-token", "", "Page token for pagination")
 	cmd.Flags().String("filter", "", "Filter reactions (e.g. by emoji or user)")
-	cmd.Flags().Bool("all", false, "Fetch all pages of results")
+	addPaginationFlags(cmd, 25, "reactions")
 
 	return cmd
 }
 
+// printReactionsTable renders reactions as a table.
+func printReactionsTable(f *output.Formatter, reactions []*api.Reaction) {
+	if len(reactions) == 0 {
+		f.PrintMessage("No reactions found.")
+		return
+	}
+
+	table := newTable("REACTION_NAME", "EMOJI", "USER")
+	for _, reaction := range reactions {
+		table.AddRow(reaction.Name, formatEmoji(reaction.Emoji), displayUser(reaction.User))
+	}
+
+	fmt.Print(table.Render())
+}
+
 // isUnicodeEmoji returns true if the string starts with a non-ASCII character,
 // indicating it is likely a unicode emoji rather than a custom emoji UID.
 func isUnicodeEmoji(s string) bool {
@@ -160,13 +117,30 @@ func isUnicodeEmoji(s string) bool {
 	return false
 }
 
+// formatEmoji returns the unicode character of an emoji, or the UID of a
+// custom emoji.
+func formatEmoji(e *api.Emoji) string {
+	switch {
+	case e == nil:
+		return ""
+	case e.Unicode != "":
+		return e.Unicode
+	case e.CustomEmoji != nil:
+		return e.CustomEmoji.UID
+	}
+	return ""
+}
+
 // newReactionsAddCmd creates the "reactions add" subcommand.
 func newReactionsAddCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "add MESSAGE",
-		Short: "Add a reaction to a message",
-		Long:  "Add an emoji reaction to the specified message. MESSAGE is the full message resource name (spaces/{space}/messages/{message}).",
-		Args:  cobra.ExactArgs(1),
+		Use:   "add MESSAGE...",
+		Short: "Add a reaction to one or more messages",
+		Long: `Add an emoji reaction to the specified messages. MESSAGE is the full message resource name (spaces/{space}/messages/{message}).
+
+When several messages are given, the reactions are sent in batch requests
+(up to 100 per round trip). A failure on one message does not stop the others.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := newAPIClient()
 			if err != nil {
@@ -175,36 +149,36 @@ func newReactionsAddCmd() *cobra.Command {
 			formatter := getFormatter()
 			svc := api.NewReactionsService(client)
 
-			parent := args[0]
 			emoji, _ := cmd.Flags().GetString("emoji")
 
 			// Build the reaction body. If the emoji looks like unicode (starts
 			// with a non-ASCII character), use the unicode field; otherwise treat
 			// it as a custom emoji UID.
-			var body map[string]interface{}
+			body := &api.Reaction{Emoji: &api.Emoji{}}
 			if isUnicodeEmoji(emoji) {
-				body = map[string]interface{}{
-					"emoji": map[string]interface{}{
-						"unicode": emoji,
-					},
-				}
+				body.Emoji.Unicode = emoji
 			} else {
-				body = map[string]interface{}{
-					"emoji": map[string]interface{}{
-						"customEmoji": map[string]interface{}{
-							"uid": emoji,
-						},
-					},
+				body.Emoji.CustomEmoji = &api.CustomEmoji{UID: emoji}
+			}
+
+			if len(args) > 1 {
+				results, err := svc.CreateMany(cmd.Context(), args, body)
+				if err != nil {
+					return fmt.Errorf("adding reactions: %w", err)
 				}
+				return printBatchResults(formatter, args, results, "reactions", func(parent string, _ *api.Reaction) {
+					formatter.PrintSuccess(fmt.Sprintf("Reaction %s added to %s", emoji, parent))
+				})
 			}
 
-			raw, err := svc.Create(cmd.Context(), parent, body)
+			parent := args[0]
+			reaction, err := svc.Create(cmd.Context(), parent, body)
 			if err != nil {
 				return fmt.Errorf("adding reaction: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
+				return formatter.Print(reaction)
 			}
 
 			formatter.PrintSuccess(fmt.Sprintf("Reaction %s added to %s", emoji, parent))
@@ -246,13 +220,13 @@ func newReactionsRemoveCmd() *cobra.Command {
 				}
 			}
 
-			raw, err := svc.Delete(cmd.Context(), name)
+			resp, err := svc.Delete(cmd.Context(), name)
 			if err != nil {
 				return fmt.Errorf("removing reaction: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
+				return formatter.Print(resp)
 			}
 
 			formatter.PrintSuccess(fmt.Sprintf("Reaction %s removed.", name))
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -31,10 +30,13 @@ func NewReadStateCmd() *cobra.Command {
 // newReadStateGetSpaceCmd creates the "readstate get-space" subcommand.
 func newReadStateGetSpaceCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "get-space READSTATE",
-		Short: "Get the read state of a space",
-		Long:  "Retrieve the read state of a space for the calling user. READSTATE is the full resource name (users/{user}/spaces/{space}/spaceReadState).",
-		Args:  cobra.ExactArgs(1),
+		Use:   "get-space READSTATE...",
+		Short: "Get the read state of one or more spaces",
+		Long: `Retrieve the read state of a space for the calling user. READSTATE is the full resource name (users/{user}/spaces/{space}/spaceReadState).
+
+When several read states are given, they are fetched in batch requests (up
+to 100 per round trip) and shown as a table.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := newAPIClient()
 			if err != nil {
@@ -43,23 +45,30 @@ func newReadStateGetSpaceCmd() *cobra.Command {
 			formatter := getFormatter()
 			svc := api.NewReadStateService(client)
 
+			if len(args) > 1 {
+				results, err := svc.GetSpaceReadStates(cmd.Context(), args)
+				if err != nil {
+					return fmt.Errorf("getting space read states: %w", err)
+				}
+				table := newTable("NAME", "LAST_READ_TIME")
+				err = printBatchResults(formatter, args, results, "read states", func(_ string, state *api.SpaceReadState) {
+					table.AddRow(state.Name, output.FormatTime(state.LastReadTime))
+				})
+				if len(table.Rows) > 0 {
+					fmt.Print(table.Render())
+				}
+				return err
+			}
+
 			name := args[0]
 
-			raw, err := svc.GetSpaceReadState(cmd.Context(), name)
+			state, err := svc.GetSpaceReadState(cmd.Context(), name)
 			if err != nil {
 				return fmt.Errorf("getting space read state: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			var state struct {
-				Name         string `json:"name"`
-				LastReadTime string `json:"lastReadTime"`
-			}
-			if err := json.Unmarshal(raw, &state); err != nil {
-				return fmt.Errorf("parsing response: %w", err)
+				return formatter.Print(state)
 			}
 
 			fmt.Printf("Name:           %s\n", state.Name)
@@ -91,25 +100,17 @@ func newReadStateUpdateSpaceCmd() *cobra.Command {
 			lastReadTime, _ := cmd.Flags().GetString("last-read-time")
 			updateMask, _ := cmd.Flags().GetString("update-mask")
 
-			body := map[string]interface{}{
-				"lastReadTime": lastReadTime,
+			body := &api.SpaceReadState{
+				LastReadTime: lastReadTime,
 			}
 
-			raw, err := svc.UpdateSpaceReadState(cmd.Context(), name, body, updateMask)
+			state, err := svc.UpdateSpaceReadState(cmd.Context(), name, body, updateMask)
 			if err != nil {
 				return fmt.Errorf("updating space read state: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			var state struct {
-				Name         string `json:"name"`
-				LastReadTime string `json:"lastReadTime"`
-			}
-			if err := json.Unmarshal(raw, &state); err != nil {
-				return fmt.Errorf("parsing response: %w", err)
+				return formatter.Print(state)
 			}
 
 			formatter.PrintSuccess("Space read state updated.")
@@ -144,21 +145,13 @@ func newReadStateGetThreadCmd() *cobra.Command {
 
 			name := args[0]
 
-			raw, err := svc.GetThreadReadState(cmd.Context(), name)
+			state, err := svc.GetThreadReadState(cmd.Context(), name)
 			if err != nil {
 				return fmt.Errorf("getting thread read state: %w", err)
 			}
 
 			if formatter.IsJSON() {
-				return formatter.PrintRaw(raw)
-			}
-
-			var state struct {
-				Name         string `json:"name"`
-				LastReadTime string `json:"lastReadTime"`
-			}
-			if err := json.Unmarshal(raw, &state); err != nil {
-				return fmt.Errorf("parsing response: %w", err)
+				return formatter.Print(state)
 			}
 
 			fmt.Printf("Name:           %s\n", state.Name)
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+	"github.com/cipher-shad0w/gogchat/internal/auth"
+	"github.com/cipher-shad0w/gogchat/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// commandScopes lists the OAuth2 scopes, without auth.ScopePrefix, that
+// each command needs. Keys are command paths without the leading
+// "gogchat". admin, if set, replaces user when the command runs with
+// --admin.
+//
+// A user login requests auth.Scopes up front, but a service account
+// impersonating a user (--impersonate) asks for exactly the scopes of the
+// command being run, so that a Workspace admin only has to authorize the
+// scopes a script actually uses. When a user's login lacks a scope,
+// offerScopes asks for the command's scopes that are missing.
+var commandScopes = map[string]struct{ user, admin []string }{
+	"spaces complete-import": {user: []string{"chat.import"}},
+	"spaces create":          {user: []string{"chat.spaces.create"}},
+	"spaces delete":          {user: []string{"chat.delete"}, admin: []string{"chat.admin.delete"}},
+	"spaces find-dm":         {user: []string{"chat.spaces.readonly"}},
+	"spaces get":             {user: []string{"chat.spaces.readonly"}, admin: []string{"chat.admin.spaces.readonly"}},
+	"spaces list":            {user: []string{"chat.spaces.readonly"}},
+	"spaces search":          {user: []string{"chat.admin.spaces.readonly"}},
+	"spaces setup":           {user: []string{"chat.spaces.create"}},
+	"spaces update":          {user: []string{"chat.spaces"}, admin: []string{"chat.admin.spaces"}},
+
+	"messages delete":  {user: []string{"chat.messages"}},
+	"messages get":     {user: []string{"chat.messages.readonly"}},
+	"messages list":    {user: []string{"chat.messages.readonly"}},
+	"messages replace": {user: []string{"chat.messages"}},
+	"messages send":    {user: []string{"chat.messages.create"}},
+	"messages update":  {user: []string{"chat.messages"}},
+
+	"members add":    {user: []string{"chat.memberships"}, admin: []string{"chat.admin.memberships"}},
+	"members get":    {user: []string{"chat.memberships.readonly"}, admin: []string{"chat.admin.memberships.readonly"}},
+	"members list":   {user: []string{"chat.memberships.readonly"}, admin: []string{"chat.admin.memberships.readonly"}},
+	"members remove": {user: []string{"chat.memberships"}, admin: []string{"chat.admin.memberships"}},
+	"members update": {user: []string{"chat.memberships"}, admin: []string{"chat.admin.memberships"}},
+
+	"reactions add":    {user: []string{"chat.messages.reactions.create"}},
+	"reactions list":   {user: []string{"chat.messages.reactions.readonly"}},
+	"reactions remove": {user: []string{"chat.messages.reactions"}},
+
+	"emoji create": {user: []string{"chat.customemojis"}},
+	"emoji delete": {user: []string{"chat.customemojis"}},
+	"emoji get":    {user: []string{"chat.customemojis.readonly"}},
+	"emoji list":   {user: []string{"chat.customemojis.readonly"}},
+
+	"media download": {user: []string{"chat.messages.readonly"}},
+	"media upload":   {user: []string{"chat.messages.create"}},
+
+	"attachments get": {user: []string{"chat.messages.readonly"}},
+
+	"readstate get-space":    {user: []string{"chat.users.readstate.readonly"}},
+	"readstate get-thread":   {user: []string{"chat.users.readstate.readonly"}},
+	"readstate update-space": {user: []string{"chat.users.readstate"}},
+
+	// Reading an event needs read access to the kind of resource it is
+	// about.
+	"events get":  {user: eventScopes},
+	"events list": {user: eventScopes},
+
+	"notifications get":    {user: []string{"chat.users.spacesettings"}},
+	"notifications update": {user: []string{"chat.users.spacesettings"}},
+}
+
+// eventScopes are the scopes of the events commands.
+var eventScopes = []string{
+	"chat.spaces.readonly",
+	"chat.messages.readonly",
+	"chat.memberships.readonly",
+	"chat.messages.reactions.readonly",
+}
+
+// scopesFor returns the full OAuth2 scopes that cmd needs, following
+// commandScopes. A command missing from the table gets all of
+// auth.Scopes.
+func scopesFor(cmd *cobra.Command) []string {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	entry, ok := commandScopes[path]
+	if !ok {
+		return auth.Scopes
+	}
+	names := entry.user
+	if admin, _ := cmd.Flags().GetBool("admin"); admin && entry.admin != nil {
+		names = entry.admin
+	}
+
+	scopes := make([]string, len(names))
+	for i, name := range names {
+		scopes[i] = auth.ScopePrefix + name
+	}
+	return scopes
+}
+
+// offerScopes follows up an API error about insufficient scopes under a
+// user login. It names the scopes of the command that the stored token
+// lacks, or says that none are missing, and, on a terminal, offers to
+// grant just those right away through incremental authorization, keeping
+// everything granted before. Otherwise it prints the login command that
+// grants them.
+func offerScopes(err error) {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 403 ||
+		!strings.Contains(strings.ToLower(apiErr.Message), "insufficient authentication scopes") {
+		return
+	}
+	if Cfg == nil || authMode() != api.AuthUser || viper.GetString("impersonate") != "" || viper.GetString("replay") != "" || externalToken() {
+		return
+	}
+	store, err := tokenStore()
+	if err != nil {
+		return
+	}
+	stored, err := store.Load()
+	if err != nil {
+		return
+	}
+
+	// Without a record of what the login granted, all of the command's
+	// scopes are offered.
+	missing := requestScopes
+	if granted := grantedScopes(store, stored); granted != nil {
+		missing = auth.MissingScopes(requestScopes, granted)
+		if len(missing) == 0 {
+			fmt.Fprintf(os.Stderr, "  Your login already grants the scopes this command needs (%s), so there are none to add.\n\n", shortScopes(requestScopes))
+			return
+		}
+	}
+	list := shortScopes(missing)
+
+	if !output.IsTerminal(os.Stdin) || !output.IsTerminal(os.Stderr) {
+		fmt.Fprintf(os.Stderr, "  To grant the missing scopes, run: %s --scopes %s\n\n", loginCommand(), list)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "This command needs %s, which your login has not granted.\n", list)
+	fmt.Fprint(os.Stderr, "Grant access now? Everything granted before is kept. [y/N]: ")
+	// The command's context is done by now.
+	ctx := context.Background()
+	answer, err := readLine(ctx)
+	if err != nil || (answer != "y" && answer != "Y") {
+		return
+	}
+
+	clientID, clientSecret, err := resolveCredentials(rootCmd)
+	if err != nil {
+		printRichError(err)
+		return
+	}
+	scopes := missing
+	if stored.Flow == auth.FlowDevice {
+		// The device flow cannot add to an earlier grant.
+		scopes = slices.Concat(stored.Scopes, missing)
+		if len(stored.Scopes) == 0 {
+			scopes = slices.Concat(auth.Scopes, missing)
+		}
+	}
+	fresh, err := userLogin(ctx, stored.Flow, clientID, clientSecret, scopes)
+	if err != nil {
+		printRichError(err)
+		return
+	}
+	keepRefreshToken(store, fresh)
+	if fresh.Scopes == nil {
+		fresh.Scopes = slices.Concat(stored.Scopes, missing)
+	}
+	if err := auth.SaveToken(store, fresh); err != nil {
+		printRichError(fmt.Errorf("saving token: %w", err))
+		return
+	}
+	fmt.Fprintf(os.Stderr, "✓ Granted %s. Run the command again.\n", list)
+}
+
+// grantedScopes returns the scopes granted to the stored login, or nil if
+// they are unknown. Logins from before scopes were recorded are
+// introspected, and the answer is saved with the token.
+func grantedScopes(store auth.TokenStore, stored *auth.StoredToken) []string {
+	if stored.Scopes != nil {
+		return stored.Scopes
+	}
+	base, err := newBaseHTTPClient()
+	if err != nil {
+		return nil
+	}
+	// The request that failed has just refreshed the access token if it
+	// had expired.
+	info, err := auth.Introspect(auth.WithHTTPClient(context.Background(), base), stored.AccessToken)
+	if err != nil {
+		return nil
+	}
+	scopes := info.Scopes()
+	if len(scopes) == 0 {
+		return nil
+	}
+	stored.Scopes = scopes
+	_ = auth.SaveToken(store, stored)
+	return scopes
+}
+
+// shortScopes joins the short names of scopes with commas, as accepted by
+// 'auth login --scopes'.
+func shortScopes(scopes []string) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = auth.ShortScope(scope)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+	"github.com/cipher-shad0w/gogchat/internal/auth"
+	"github.com/cipher-shad0w/gogchat/internal/config"
+	"golang.org/x/oauth2"
+)
+
+// scopeError is the API's answer to a token without the required scopes.
+var scopeError = &api.APIError{Code: 403, Message: "Request had insufficient authentication scopes."}
+
+// setupLogin loads the configuration from an empty home directory and
+// stores a user login that granted scopes.
+func setupLogin(t *testing.T, scopes []string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	Cfg = cfg
+	t.Cleanup(func() { Cfg = nil })
+
+	store, err := tokenStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &auth.StoredToken{Token: oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}, Scopes: scopes}
+	if err := auth.SaveToken(store, token); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// captureStderr returns what fn writes to stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	fn()
+	os.Stderr = stderr
+	w.Close()
+	return <-out
+}
+
+func TestOfferScopesMissing(t *testing.T) {
+	setupLogin(t, []string{auth.ScopePrefix + "chat.messages.readonly"})
+	requestScopes = []string{auth.ScopePrefix + "chat.spaces.readonly", auth.ScopePrefix + "chat.messages.readonly"}
+	defer func() { requestScopes = nil }()
+
+	got := captureStderr(t, func() { offerScopes(scopeError) })
+	if !strings.Contains(got, "--scopes chat.spaces.readonly\n") {
+		t.Errorf("offerScopes printed %q, want the login command for the missing scope only", got)
+	}
+}
+
+func TestOfferScopesAlreadyGranted(t *testing.T) {
+	setupLogin(t, auth.Scopes)
+	requestScopes = []string{auth.ScopePrefix + "chat.spaces.readonly"}
+	defer func() { requestScopes = nil }()
+
+	got := captureStderr(t, func() { offerScopes(scopeError) })
+	if !strings.Contains(got, "already grants") || strings.Contains(got, "--scopes") {
+		t.Errorf("offerScopes printed %q, want a note that nothing is missing", got)
+	}
+}
@@ -2,8 +2,6 @@ package cmd
 
 import (
 	"bufio"
-	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -51,9 +49,7 @@ func newSpacesListCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String("filter", "", "Filter spaces (e.g. spaceType = \"SPACE\")")
-	cmd.Flags().Int("page-size", 100, "Maximum number of spaces to return per page")
-	cmd.Flags().String("page-token", "", "Page token for pagination")
-	cmd.Flags().Bool("all", false, "Automatically paginate through all results")
+	addPaginationFlags(cmd, 100, "spaces")
 
 	return cmd
 }
@@ -66,81 +62,46 @@ func runSpacesList(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	filter, _ := cmd.Flags().GetString("filter")
-	pageSize, _ := cmd.Flags().GetInt("page-size")
-	pageToken, _ := cmd.Flags().GetString("page-token")
 	all, _ := cmd.Flags().GetBool("all")
+	opts := pageOptions(cmd)
 
-	// When --all is set we collect every page into a single slice.
-	var allSpaces []json.RawMessage
-
-	for {
-		raw, err := svc.List(ctx, filter, pageSize, pageToken)
-		if err != nil {
+	if all {
+		if err := streamList(f, "spaces", svc.All(ctx, filter, opts), func(spaces []*api.Space) {
+			printSpacesTable(f, spaces)
+		}); err != nil {
 			return fmt.Errorf("listing spaces: %w", err)
 		}
-
-		if f.IsJSON() && !all {
-			return f.PrintRaw(raw)
-		}
-
-		var resp struct {
-			Spaces        []json.RawMessage `json:"spaces"`
-			NextPageToken string            `json:"nextPageToken"`
-		}
-		if err := json.Unmarshal(raw, &resp); err != nil {
-			return fmt.Errorf("parsing response: %w", err)
-		}
-
-		allSpaces = append(allSpaces, resp.Spaces...)
-
-		if !all || resp.NextPageToken == "" {
-			pageToken = resp.NextPageToken
-			break
-		}
-		pageToken = resp.NextPageToken
+		return nil
 	}
 
-	// JSON mode with --all: emit aggregated result.
-	if f.IsJSON() {
-		return f.Print(map[string]interface{}{
-			"spaces": allSpaces,
-		})
+	resp, err := svc.List(ctx, filter, opts.PageSize, opts.PageToken)
+	if err != nil {
+		return fmt.Errorf("listing spaces: %w", err)
 	}
 
-	if len(allSpaces) == 0 {
-		f.PrintMessage("No spaces found.")
-		return nil
+	if f.IsJSON() {
+		return f.Print(resp)
 	}
 
-	table := output.NewTable("NAME", "DISPLAY_NAME", "TYPE", "MEMBER_COUNT", "CREATE_TIME")
-
-	for _, raw := range allSpaces {
-		var sp map[string]interface{}
-		if err := json.Unmarshal(raw, &sp); err != nil {
-			continue
-		}
-		name := spaceMapStr(sp, "name")
-		displayName := spaceMapStr(sp, "displayName")
-		spaceType := spaceMapStr(sp, "spaceType")
-		memberCount := ""
-		if mc, ok := sp["membershipCount"]; ok {
-			memberCount = fmt.Sprintf("%v", mc)
-		}
-		createTime := output.FormatTime(spaceMapStr(sp, "createTime"))
+	printSpacesTable(f, resp.Spaces)
 
-		table.AddRow(name, displayName, spaceType, memberCount, createTime)
+	if resp.NextPageToken != "" {
+		f.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", resp.NextPageToken))
 	}
 
-	fmt.Print(table.Render())
+	return nil
+}
 
-	if !all && pageToken != "" {
-		f.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", pageToken))
+// printSpacesTable renders spaces as a table.
+func printSpacesTable(f *output.Formatter, spaces []*api.Space) {
+	if len(spaces) == 0 {
+		f.PrintMessage("No spaces found.")
+		return
 	}
-
-	return nil
+	fmt.Print(renderSpacesTable(spaces))
 }
 
 // ---------------------------------------------------------------------------
@@ -169,53 +130,50 @@ func runSpacesGet(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	admin, _ := cmd.Flags().GetBool("admin")
 
-	raw, err := svc.Get(ctx, args[0], admin)
+	sp, err := svc.Get(ctx, args[0], admin)
 	if err != nil {
 		return fmt.Errorf("getting space: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
-	}
-
-	var sp map[string]interface{}
-	if err := json.Unmarshal(raw, &sp); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
+		return f.Print(sp)
 	}
 
 	printSpaceDetail(sp)
 	return nil
 }
 
-func printSpaceDetail(sp map[string]interface{}) {
-	pairs := []struct{ label, key string }{
-		{"Name", "name"},
-		{"Display Name", "displayName"},
-		{"Type", "spaceType"},
-		{"Space Type", "type"},
-		{"Description", "spaceDetails.description"},
-		{"Guidelines", "spaceDetails.guidelines"},
-		{"Threading State", "spaceThreadingState"},
-		{"History State", "spaceHistoryState"},
-		{"External Access", "externalUserAllowed"},
-		{"Admin Installed", "adminInstalled"},
-		{"Member Count", "membershipCount"},
-		{"Create Time", "createTime"},
+func printSpaceDetail(sp *api.Space) {
+	var description, guidelines string
+	if sp.SpaceDetails != nil {
+		description = sp.SpaceDetails.Description
+		guidelines = sp.SpaceDetails.Guidelines
+	}
+
+	pairs := []struct{ label, value string }{
+		{"Name", sp.Name},
+		{"Display Name", sp.DisplayName},
+		{"Type", sp.SpaceType},
+		{"Space Type", sp.Type},
+		{"Description", description},
+		{"Guidelines", guidelines},
+		{"Threading State", sp.SpaceThreadingState},
+		{"History State", sp.SpaceHistoryState},
+		{"External Access", formatBool(sp.ExternalUserAllowed)},
+		{"Admin Installed", formatBool(sp.AdminInstalled)},
+		{"Member Count", spaceMemberCount(sp)},
+		{"Create Time", output.FormatTime(sp.CreateTime)},
 	}
 
 	for _, p := range pairs {
-		val := spaceExtractNested(sp, p.key)
-		if val == "" {
+		if p.value == "" {
 			continue
 		}
-		if p.key == "createTime" {
-			val = output.FormatTime(val)
-		}
-		fmt.Printf("%-20s %s\n", p.label+":", val)
+		fmt.Printf("%-20s %s\n", p.label+":", p.value)
 	}
 }
 
@@ -249,39 +207,34 @@ func runSpacesCreate(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	displayName, _ := cmd.Flags().GetString("display-name")
 	spaceType, _ := cmd.Flags().GetString("space-type")
 	description, _ := cmd.Flags().GetString("description")
 	requestID, _ := cmd.Flags().GetString("request-id")
 
-	space := map[string]interface{}{
-		"displayName": displayName,
-		"spaceType":   spaceType,
+	space := &api.Space{
+		DisplayName: displayName,
+		SpaceType:   spaceType,
 	}
 
 	if description != "" {
-		space["spaceDetails"] = map[string]interface{}{
-			"description": description,
+		space.SpaceDetails = &api.SpaceDetails{
+			Description: description,
 		}
 	}
 
-	raw, err := svc.Create(ctx, space, requestID)
+	sp, err := svc.Create(ctx, space, requestID)
 	if err != nil {
 		return fmt.Errorf("creating space: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
+		return f.Print(sp)
 	}
 
-	var sp map[string]interface{}
-	if err := json.Unmarshal(raw, &sp); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
-	}
-
-	f.PrintSuccess(fmt.Sprintf("Space created: %s", spaceMapStr(sp, "name")))
+	f.PrintSuccess(fmt.Sprintf("Space created: %s", sp.Name))
 	printSpaceDetail(sp)
 	return nil
 }
@@ -316,33 +269,29 @@ func runSpacesUpdate(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	admin, _ := cmd.Flags().GetBool("admin")
 	updateMask, _ := cmd.Flags().GetString("update-mask")
 
-	space := map[string]interface{}{}
+	space := &api.Space{}
 	var maskParts []string
 
 	if cmd.Flags().Changed("display-name") {
 		displayName, _ := cmd.Flags().GetString("display-name")
-		space["displayName"] = displayName
+		space.DisplayName = displayName
 		maskParts = append(maskParts, "displayName")
 	}
 
 	if cmd.Flags().Changed("description") {
 		description, _ := cmd.Flags().GetString("description")
-		if _, ok := space["spaceDetails"]; !ok {
-			space["spaceDetails"] = map[string]interface{}{}
-		}
-		details := space["spaceDetails"].(map[string]interface{})
-		details["description"] = description
+		space.SpaceDetails = &api.SpaceDetails{Description: description}
 		maskParts = append(maskParts, "spaceDetails.description")
 	}
 
 	if cmd.Flags().Changed("history-state") {
 		historyState, _ := cmd.Flags().GetString("history-state")
-		space["spaceHistoryState"] = historyState
+		space.SpaceHistoryState = historyState
 		maskParts = append(maskParts, "spaceHistoryState")
 	}
 
@@ -355,21 +304,16 @@ func runSpacesUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no fields to update; use --display-name, --description, --history-state, or --update-mask")
 	}
 
-	raw, err := svc.Patch(ctx, args[0], space, updateMask, admin)
+	sp, err := svc.Patch(ctx, args[0], space, updateMask, admin)
 	if err != nil {
 		return fmt.Errorf("updating space: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
+		return f.Print(sp)
 	}
 
-	var sp map[string]interface{}
-	if err := json.Unmarshal(raw, &sp); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
-	}
-
-	f.PrintSuccess(fmt.Sprintf("Space updated: %s", spaceMapStr(sp, "name")))
+	f.PrintSuccess(fmt.Sprintf("Space updated: %s", sp.Name))
 	printSpaceDetail(sp)
 	return nil
 }
@@ -401,7 +345,7 @@ func runSpacesDelete(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	admin, _ := cmd.Flags().GetBool("admin")
 	force, _ := cmd.Flags().GetBool("force")
@@ -419,13 +363,13 @@ func runSpacesDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	raw, err := svc.Delete(ctx, spaceName, admin)
+	resp, err := svc.Delete(ctx, spaceName, admin)
 	if err != nil {
 		return fmt.Errorf("deleting space: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
+		return f.Print(resp)
 	}
 
 	f.PrintSuccess(fmt.Sprintf("Space deleted: %s", spaceName))
@@ -445,8 +389,7 @@ func newSpacesSearchCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String("query", "", "Search query (required)")
-	cmd.Flags().Int("page-size", 100, "Maximum number of spaces per page")
-	cmd.Flags().String("page-token", "", "Page token for pagination")
+	addPaginationFlags(cmd, 100, "spaces")
 	cmd.Flags().String("order-by", "", "Order results (e.g. \"membershipCount desc\")")
 	cmd.Flags().Bool("admin", true, "Use admin access (default true for search)")
 
@@ -463,59 +406,36 @@ func runSpacesSearch(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	query, _ := cmd.Flags().GetString("query")
-	pageSize, _ := cmd.Flags().GetInt("page-size")
-	pageToken, _ := cmd.Flags().GetString("page-token")
 	orderBy, _ := cmd.Flags().GetString("order-by")
 	admin, _ := cmd.Flags().GetBool("admin")
+	all, _ := cmd.Flags().GetBool("all")
+	opts := pageOptions(cmd)
+
+	if all {
+		if err := streamList(f, "spaces", svc.SearchAll(ctx, query, orderBy, admin, opts), func(spaces []*api.Space) {
+			printSpacesTable(f, spaces)
+		}); err != nil {
+			return fmt.Errorf("searching spaces: %w", err)
+		}
+		return nil
+	}
 
-	raw, err := svc.Search(ctx, query, pageSize, pageToken, orderBy, admin)
+	resp, err := svc.Search(ctx, query, opts.PageSize, opts.PageToken, orderBy, admin)
 	if err != nil {
 		return fmt.Errorf("searching spaces: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
-	}
-
-	var resp struct {
-		Spaces        []json.RawMessage `json:"spaces"`
-		NextPageToken string            `json:"nextPageToken"`
-	}
-	if err := json.Unmarshal(raw, &resp); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
-	}
-
-	if len(resp.Spaces) == 0 {
-		f.PrintMessage("No spaces found.")
-		return nil
+		return f.Print(resp)
 	}
 
-	table := output.NewTable("NAME", "DISPLAY_NAME", "TYPE", "MEMBER_COUNT", "CREATE_TIME")
-
-	for _, raw := range resp.Spaces {
-		var sp map[string]interface{}
-		if err := json.Unmarshal(raw, &sp); err != nil {
-			continue
-		}
-		name := spaceMapStr(sp, "name")
-		displayName := spaceMapStr(sp, "displayName")
-		spaceType := spaceMapStr(sp, "spaceType")
-		memberCount := ""
-		if mc, ok := sp["membershipCount"]; ok {
-			memberCount = fmt.Sprintf("%v", mc)
-		}
-		createTime := output.FormatTime(spaceMapStr(sp, "createTime"))
-
-		table.AddRow(name, displayName, spaceType, memberCount, createTime)
-	}
-
-	fmt.Print(table.Render())
+	printSpacesTable(f, resp.Spaces)
 
 	if resp.NextPageToken != "" {
-		f.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page.", resp.NextPageToken))
+		f.PrintMessage(fmt.Sprintf("\nMore results available. Use --page-token %s to see the next page, or use --all to fetch everything.", resp.NextPageToken))
 	}
 
 	return nil
@@ -548,51 +468,38 @@ func runSpacesSetup(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	displayName, _ := cmd.Flags().GetString("display-name")
 	spaceType, _ := cmd.Flags().GetString("space-type")
 	members, _ := cmd.Flags().GetStringSlice("members")
 
-	space := map[string]interface{}{
-		"spaceType": spaceType,
-	}
-	if displayName != "" {
-		space["displayName"] = displayName
-	}
-
-	request := map[string]interface{}{
-		"space": space,
+	request := &api.SetUpSpaceRequest{
+		Space: &api.Space{
+			SpaceType:   spaceType,
+			DisplayName: displayName,
+		},
 	}
 
-	if len(members) > 0 {
-		memberships := make([]map[string]interface{}, 0, len(members))
-		for _, m := range members {
-			memberships = append(memberships, map[string]interface{}{
-				"member": map[string]interface{}{
-					"name": m,
-					"type": "HUMAN",
-				},
-			})
-		}
-		request["memberships"] = memberships
+	for _, m := range members {
+		request.Memberships = append(request.Memberships, &api.Membership{
+			Member: &api.User{
+				Name: m,
+				Type: "HUMAN",
+			},
+		})
 	}
 
-	raw, err := svc.Setup(ctx, request)
+	sp, err := svc.Setup(ctx, request)
 	if err != nil {
 		return fmt.Errorf("setting up space: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
-	}
-
-	var sp map[string]interface{}
-	if err := json.Unmarshal(raw, &sp); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
+		return f.Print(sp)
 	}
 
-	f.PrintSuccess(fmt.Sprintf("Space created: %s", spaceMapStr(sp, "name")))
+	f.PrintSuccess(fmt.Sprintf("Space created: %s", sp.Name))
 	printSpaceDetail(sp)
 	return nil
 }
@@ -624,22 +531,17 @@ func runSpacesFindDM(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	user, _ := cmd.Flags().GetString("user")
 
-	raw, err := svc.FindDirectMessage(ctx, user)
+	sp, err := svc.FindDirectMessage(ctx, user)
 	if err != nil {
 		return fmt.Errorf("finding direct message: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
-	}
-
-	var sp map[string]interface{}
-	if err := json.Unmarshal(raw, &sp); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
+		return f.Print(sp)
 	}
 
 	printSpaceDetail(sp)
@@ -668,25 +570,22 @@ func runSpacesCompleteImport(cmd *cobra.Command, args []string) error {
 
 	f := getFormatter()
 	svc := api.NewSpacesService(client)
-	ctx := context.Background()
+	ctx := cmd.Context()
 
-	raw, err := svc.CompleteImport(ctx, args[0])
+	resp, err := svc.CompleteImport(ctx, args[0])
 	if err != nil {
 		return fmt.Errorf("completing import: %w", err)
 	}
 
 	if f.IsJSON() {
-		return f.PrintRaw(raw)
-	}
-
-	var sp map[string]interface{}
-	if err := json.Unmarshal(raw, &sp); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
+		return f.Print(resp)
 	}
 
 	spaceName := api.NormalizeName(args[0], "spaces/")
 	f.PrintSuccess(fmt.Sprintf("Import completed for space: %s", spaceName))
-	printSpaceDetail(sp)
+	if resp.Space != nil {
+		printSpaceDetail(resp.Space)
+	}
 	return nil
 }
 
@@ -694,34 +593,30 @@ func runSpacesCompleteImport(cmd *cobra.Command, args []string) error {
 // helpers (spaces-specific)
 // ---------------------------------------------------------------------------
 
-// spaceMapStr safely extracts a string value from a map[string]interface{}.
-func spaceMapStr(m map[string]interface{}, key string) string {
-	if v, ok := m[key]; ok {
-		if s, ok := v.(string); ok {
-			return s
-		}
-		return fmt.Sprintf("%v", v)
+// renderSpacesTable renders spaces as the table shared by "spaces list" and
+// "spaces search".
+func renderSpacesTable(spaces []*api.Space) string {
+	table := newTable("NAME", "DISPLAY_NAME", "TYPE", "MEMBER_COUNT", "CREATE_TIME")
+	for _, sp := range spaces {
+		table.AddRow(sp.Name, sp.DisplayName, sp.SpaceType, spaceMemberCount(sp), output.FormatTime(sp.CreateTime))
 	}
-	return ""
+	return table.Render()
 }
 
-// spaceExtractNested extracts a value from a map supporting dot-notation for
-// one level of nesting (e.g. "spaceDetails.description").
-func spaceExtractNested(m map[string]interface{}, key string) string {
-	parts := strings.SplitN(key, ".", 2)
-	if len(parts) == 1 {
-		return spaceMapStr(m, key)
-	}
-
-	nested, ok := m[parts[0]]
-	if !ok {
+// spaceMemberCount returns the number of directly joined human members of a
+// space, or "" when the API did not report a count.
+func spaceMemberCount(sp *api.Space) string {
+	if sp.MembershipCount == nil {
 		return ""
 	}
+	return fmt.Sprintf("%d", sp.MembershipCount.JoinedDirectHumanUserCount)
+}
 
-	nestedMap, ok := nested.(map[string]interface{})
-	if !ok {
-		return ""
+// formatBool renders true as "true" and false as "" so that unset boolean
+// fields are omitted from detail views.
+func formatBool(b bool) string {
+	if b {
+		return "true"
 	}
-
-	return spaceMapStr(nestedMap, parts[1])
+	return ""
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -31,9 +32,71 @@ var DefaultScopes = []string{
 
 // Config holds the application configuration.
 type Config struct {
+	// Profile is the active profile, whose settings in the "profiles"
+	// section override the top-level ones. See applyProfile.
+	Profile string `mapstructure:"profile"`
+
 	ClientID     string `mapstructure:"client_id"`
 	ClientSecret string `mapstructure:"client_secret"`
 	TokenFile    string `mapstructure:"token_file"`
+
+	// TokenStore is where the token is kept: "file", "encrypted-file" or
+	// "command". TokenKeyFile holds the secret of an encrypted file (or
+	// GOGCHAT_TOKEN_PASSPHRASE does), and TokenCommandRead, Write and Delete
+	// are the shell commands of a command store.
+	TokenStore         string `mapstructure:"token_store"`
+	TokenKeyFile       string `mapstructure:"token_key_file"`
+	TokenCommandRead   string `mapstructure:"token_command_read"`
+	TokenCommandWrite  string `mapstructure:"token_command_write"`
+	TokenCommandDelete string `mapstructure:"token_command_delete"`
+
+	// AccessToken (usually from GOGCHAT_ACCESS_TOKEN) or the output of
+	// TokenCommand is used as the access token instead of the token store,
+	// e.g. for short-lived tokens injected by CI. AccessToken wins if both
+	// are set.
+	AccessToken  string `mapstructure:"access_token"`
+	TokenCommand string `mapstructure:"token_command"`
+
+	// AuthMode is "user" to call the API as the user who logged in, or
+	// "app" to call it as a Chat app with the service account key at
+	// ServiceAccountKey.
+	AuthMode          string `mapstructure:"auth_mode"`
+	ServiceAccountKey string `mapstructure:"service_account_key"`
+
+	// Impersonate is the Workspace user that the service account at
+	// ServiceAccountKey acts as through domain-wide delegation, whatever
+	// the AuthMode. Empty means no impersonation.
+	Impersonate string `mapstructure:"impersonate"`
+
+	// BaseURL overrides the Chat API endpoint, e.g. to point at a local
+	// fake server. Empty means the public API.
+	BaseURL string `mapstructure:"base_url"`
+
+	// Network settings shared by the OAuth2 token endpoint and the API:
+	// proxy URL, extra trusted CA files and a client certificate for mTLS.
+	Proxy      string   `mapstructure:"proxy"`
+	CAFiles    []string `mapstructure:"ca_files"`
+	ClientCert string   `mapstructure:"client_cert"`
+	ClientKey  string   `mapstructure:"client_key"`
+
+	// QuotaProject is the Google Cloud project that API usage is billed
+	// and rate limited against, sent as X-Goog-User-Project.
+	QuotaProject string `mapstructure:"quota_project"`
+
+	// Timeout bounds a whole command; 0 means no limit. RequestTimeout
+	// abandons a single API request that makes no progress for that long.
+	Timeout        time.Duration `mapstructure:"timeout"`
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+
+	// Retry policy for transient API failures (429, 5xx, connection resets).
+	RetryAttempts int           `mapstructure:"retry_attempts"`
+	RetryDelay    time.Duration `mapstructure:"retry_delay"`
+	RetryJitter   float64       `mapstructure:"retry_jitter"`
+
+	// Client-side rate limits in requests per second; 0 disables a limit.
+	RateLimitRead       float64 `mapstructure:"rate_limit_read"`
+	RateLimitWrite      float64 `mapstructure:"rate_limit_write"`
+	RateLimitSpaceWrite float64 `mapstructure:"rate_limit_space_write"`
 }
 
 // ConfigDir returns the path to the gogchat configuration directory
@@ -61,9 +124,34 @@ func Load() (*Config, error) {
 	viper.SetEnvPrefix("GOGCHAT")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("profile", DefaultProfile)
 	viper.SetDefault("client_id", "")
 	viper.SetDefault("client_secret", "")
 	viper.SetDefault("token_file", defaultTokenFile)
+	viper.SetDefault("token_store", "file")
+	viper.SetDefault("token_key_file", "")
+	viper.SetDefault("token_command_read", "")
+	viper.SetDefault("token_command_write", "")
+	viper.SetDefault("token_command_delete", "")
+	viper.SetDefault("access_token", "")
+	viper.SetDefault("token_command", "")
+	viper.SetDefault("auth_mode", "user")
+	viper.SetDefault("service_account_key", "")
+	viper.SetDefault("impersonate", "")
+	viper.SetDefault("base_url", "")
+	viper.SetDefault("proxy", "")
+	viper.SetDefault("ca_files", []string{})
+	viper.SetDefault("client_cert", "")
+	viper.SetDefault("client_key", "")
+	viper.SetDefault("quota_project", "")
+	viper.SetDefault("timeout", time.Duration(0))
+	viper.SetDefault("request_timeout", 60*time.Second)
+	viper.SetDefault("retry_attempts", 4)
+	viper.SetDefault("retry_delay", 500*time.Millisecond)
+	viper.SetDefault("retry_jitter", 0.2)
+	viper.SetDefault("rate_limit_read", 10.0)
+	viper.SetDefault("rate_limit_write", 5.0)
+	viper.SetDefault("rate_limit_space_write", 1.0)
 
 	// Read the config file; ignore "not found" errors since env vars or
 	// defaults may be sufficient.
@@ -72,6 +160,9 @@ func Load() (*Config, error) {
 			return nil, fmt.Errorf("reading config file: %w", err)
 		}
 	}
+	if err := applyProfile(); err != nil {
+		return nil, err
+	}
 
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultProfile is the name of the unnamed profile: the top-level settings
+// of the config file, with the token at ~/.config/gogchat/token.json.
+const DefaultProfile = "default"
+
+// profileName is the syntax of profile names. Viper lower-cases keys, so
+// names are restricted to lower case to round-trip through the config file.
+var profileName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateProfile reports whether name can be used as a profile name.
+func ValidateProfile(name string) error {
+	if !profileName.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lower-case letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ProfileTokenFile returns the default token file of profile name.
+func ProfileTokenFile(name string) string {
+	if name == "" || name == DefaultProfile {
+		return filepath.Join(ConfigDir(), "token.json")
+	}
+	return filepath.Join(ConfigDir(), "profiles", name, "token.json")
+}
+
+// Profiles returns the names of the profiles defined in the config file's
+// "profiles" section, sorted, preceded by DefaultProfile.
+func Profiles() []string {
+	names := slices.Sorted(maps.Keys(viper.GetStringMap("profiles")))
+	names = slices.DeleteFunc(names, func(n string) bool { return n == DefaultProfile })
+	return append([]string{DefaultProfile}, names...)
+}
+
+// applyProfile layers the settings of the active profile over the
+// top-level settings of the config file. Flags and environment variables
+// still take precedence over both. A profile without its own token_file
+// gets one of its own, so that profiles never share a token.
+func applyProfile() error {
+	name := viper.GetString("profile")
+	if name == "" || name == DefaultProfile {
+		return nil
+	}
+	if err := ValidateProfile(name); err != nil {
+		return err
+	}
+
+	settings := map[string]any{"token_file": ProfileTokenFile(name)}
+	maps.Copy(settings, viper.GetStringMap("profiles."+name))
+	if err := viper.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("applying profile %s: %w", name, err)
+	}
+	return nil
+}
+
+// FilePath returns the path of the config file: the one given with
+// --config, or config.yaml in ConfigDir.
+func FilePath() string {
+	if path := viper.GetString("config"); path != "" {
+		return path
+	}
+	return filepath.Join(ConfigDir(), "config.yaml")
+}
+
+// Save sets key to value in the config file, creating the file if needed.
+// The file's other settings are kept, but its comments and formatting are
+// not.
+func Save(key string, value any) error {
+	v, err := readFile()
+	if err != nil {
+		return err
+	}
+	v.Set(key, value)
+	if err := v.WriteConfigAs(FilePath()); err != nil {
+		return fmt.Errorf("writing config file %s: %w", FilePath(), err)
+	}
+	return nil
+}
+
+// TokenFile returns the token file of profile name as configured in the
+// config file, ignoring flags and environment variables.
+func TokenFile(name string) string {
+	if path := ProfileSetting(name, "token_file"); path != "" {
+		return path
+	}
+	return ProfileTokenFile(name)
+}
+
+// ProfileSetting returns setting key of profile name as written in the
+// config file, ignoring flags, environment variables and defaults. A named
+// profile without the setting inherits the top-level one, except for
+// token_file.
+func ProfileSetting(name, key string) string {
+	v, err := readFile()
+	if err != nil {
+		return ""
+	}
+	if name != DefaultProfile {
+		if value := v.GetString("profiles." + name + "." + key); value != "" || key == "token_file" {
+			return value
+		}
+	}
+	return v.GetString(key)
+}
+
+// ProfileKey returns the config file key of setting key for profile name.
+func ProfileKey(name, key string) string {
+	if name == "" || name == DefaultProfile {
+		return key
+	}
+	return "profiles." + name + "." + key
+}
+
+// readFile loads the config file alone into a new Viper instance. A
+// missing file yields an empty one.
+func readFile() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(FilePath())
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,479 @@
+package har
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxBodySize is the number of bytes of each request and response body kept
+// in the trace. Longer bodies are truncated.
+const maxBodySize = 1 << 20
+
+// redacted replaces every scrubbed value.
+const redacted = "REDACTED"
+
+// sensitiveHeaders carry credentials and are never written.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Goog-Api-Key":      true,
+}
+
+// sensitiveParams are query, form and JSON fields that carry credentials.
+// token is the access or refresh token sent to the revocation endpoint.
+// upload_id is included because a resumable upload session URL grants
+// access to the upload on its own.
+var sensitiveParams = map[string]bool{
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+	"code":          true,
+	"code_verifier": true,
+	"device_code":   true,
+	"assertion":     true,
+	"key":           true,
+	"upload_id":     true,
+}
+
+// Recorder is an http.RoundTripper that forwards requests to another
+// transport and collects every exchange for a HAR file. An exchange is
+// recorded once its response body has been read to the end or closed, so
+// that the receive timing covers the whole download. Nothing is written
+// until Close.
+type Recorder struct {
+	next  http.RoundTripper
+	trace *trace
+}
+
+// trace is the file that the recorders returned by NewRecorder and Wrap
+// share.
+type trace struct {
+	path string
+
+	mu   sync.Mutex
+	file File
+}
+
+// NewRecorder returns a Recorder that writes to path and sends requests
+// through next (http.DefaultTransport if nil). version is the gogchat
+// version recorded as the file's creator.
+func NewRecorder(path, version string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{
+		next: next,
+		trace: &trace{
+			path: path,
+			file: File{Log: Log{
+				Version: Version,
+				Creator: Creator{Name: "gogchat", Version: version},
+				Entries: []Entry{},
+			}},
+		},
+	}
+}
+
+// Wrap returns a Recorder that sends requests through next and records them
+// in the same file as r, so that the OAuth2 and API clients of one session
+// end up in a single trace.
+func (r *Recorder) Wrap(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{next: next, trace: r.trace}
+}
+
+// Close writes the recorded exchanges to the HAR file. Exchanges whose
+// response body is still open are not included.
+func (r *Recorder) Close() error {
+	r.trace.mu.Lock()
+	defer r.trace.mu.Unlock()
+	return r.trace.file.Save(r.trace.path)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	x := &exchange{recorder: r, req: req, start: time.Now()}
+
+	traced := req.WithContext(httptrace.WithClientTrace(req.Context(), x.clientTrace()))
+	if req.Body != nil && req.Body != http.NoBody {
+		x.reqBody = &capture{}
+		traced.Body = &teeBody{ReadCloser: req.Body, capture: x.reqBody}
+	}
+
+	resp, err := r.next.RoundTrip(traced)
+	if err != nil {
+		x.finish(nil, err)
+		return nil, err
+	}
+
+	x.respBody = &capture{}
+	resp.Body = &teeBody{ReadCloser: resp.Body, capture: x.respBody, done: func(err error) { x.finish(resp, err) }}
+	return resp, nil
+}
+
+// record adds e to the file.
+func (r *Recorder) record(e Entry) {
+	r.trace.mu.Lock()
+	defer r.trace.mu.Unlock()
+	r.trace.file.Log.Entries = append(r.trace.file.Log.Entries, e)
+}
+
+// exchange collects what is known about one request as it progresses.
+type exchange struct {
+	recorder *Recorder
+	req      *http.Request
+	reqBody  *capture
+	respBody *capture
+	once     sync.Once
+
+	mu                  sync.Mutex
+	start, gotConn      time.Time
+	dnsStart, dnsDone   time.Time
+	connStart, connDone time.Time
+	tlsStart, tlsDone   time.Time
+	wrote, firstByte    time.Time
+	remoteAddr          string
+}
+
+func (x *exchange) clientTrace() *httptrace.ClientTrace {
+	at := func(t *time.Time) {
+		x.mu.Lock()
+		defer x.mu.Unlock()
+		if t.IsZero() {
+			*t = time.Now()
+		}
+	}
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			at(&x.gotConn)
+			x.mu.Lock()
+			x.remoteAddr = info.Conn.RemoteAddr().String()
+			x.mu.Unlock()
+		},
+		DNSStart:             func(httptrace.DNSStartInfo) { at(&x.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { at(&x.dnsDone) },
+		ConnectStart:         func(string, string) { at(&x.connStart) },
+		ConnectDone:          func(string, string, error) { at(&x.connDone) },
+		TLSHandshakeStart:    func() { at(&x.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { at(&x.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { at(&x.wrote) },
+		GotFirstResponseByte: func() { at(&x.firstByte) },
+	}
+}
+
+// finish records the exchange. It runs once, when the response body is
+// done or the request failed without a response.
+func (x *exchange) finish(resp *http.Response, err error) {
+	x.once.Do(func() {
+		end := time.Now()
+		x.mu.Lock()
+		entry := Entry{
+			StartedDateTime: x.start,
+			Request:         x.request(),
+			Response:        x.response(resp),
+			Timings:         x.timings(end),
+		}
+		if host, _, splitErr := net.SplitHostPort(x.remoteAddr); splitErr == nil {
+			entry.ServerIPAddress = host
+		}
+		x.mu.Unlock()
+
+		entry.Time = ms(end.Sub(x.start))
+		if err != nil && err != io.EOF {
+			entry.Error = err.Error()
+		}
+		x.recorder.record(entry)
+	})
+}
+
+func (x *exchange) request() Request {
+	req := x.req
+	u := scrubURL(req.URL)
+	out := Request{
+		Method:      req.Method,
+		URL:         u.String(),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []NameValue{},
+		Headers:     headers(req.Header),
+		QueryString: queryString(u),
+		HeadersSize: -1,
+	}
+	if x.reqBody != nil {
+		data, size, truncated := x.reqBody.snapshot()
+		out.BodySize = size
+		mimeType := req.Header.Get("Content-Type")
+		text, comment := requestText(req.URL.Path, mimeType, data, size, truncated)
+		out.PostData = &PostData{MimeType: mimeType, Text: text, Comment: comment}
+	}
+	return out
+}
+
+func (x *exchange) response(resp *http.Response) Response {
+	out := Response{
+		Cookies:     []NameValue{},
+		Headers:     []NameValue{},
+		HTTPVersion: "HTTP/1.1",
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	if resp == nil {
+		return out
+	}
+	out.Status = resp.StatusCode
+	out.StatusText = strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprint(resp.StatusCode)))
+	out.HTTPVersion = resp.Proto
+	out.Headers = headers(resp.Header)
+	out.RedirectURL = scrubLocation(resp.Header.Get("Location"))
+	body, size, truncated := x.respBody.snapshot()
+	out.BodySize = size
+	out.Content = Content{Size: size, MimeType: resp.Header.Get("Content-Type")}
+
+	if isJSON(out.Content.MimeType) {
+		body = scrubJSON(body)
+	}
+	if utf8.Valid(body) {
+		out.Content.Text = string(body)
+	} else {
+		out.Content.Text = base64.StdEncoding.EncodeToString(body)
+		out.Content.Encoding = "base64"
+	}
+	if truncated {
+		out.Content.Comment = fmt.Sprintf("truncated to the first %d bytes", maxBodySize)
+	}
+	return out
+}
+
+// timings computes the HAR phases from the trace events. Phases whose
+// events did not happen are reported as -1.
+func (x *exchange) timings(end time.Time) Timings {
+	connEnd := x.connDone
+	if x.tlsDone.After(connEnd) {
+		connEnd = x.tlsDone
+	}
+	dns := span(x.dnsStart, x.dnsDone)
+	connect := span(x.connStart, connEnd)
+	blocked := span(x.start, x.gotConn)
+	if blocked >= 0 {
+		blocked = max(blocked-max(dns, 0)-max(connect, 0), 0)
+	}
+
+	return Timings{
+		Blocked: ms(blocked),
+		DNS:     ms(dns),
+		Connect: ms(connect),
+		SSL:     ms(span(x.tlsStart, x.tlsDone)),
+		Send:    ms(span(x.gotConn, x.wrote)),
+		Wait:    ms(span(x.wrote, x.firstByte)),
+		Receive: ms(span(x.firstByte, end)),
+	}
+}
+
+// span returns the time from a to b, or -1 if either event is missing.
+func span(a, b time.Time) time.Duration {
+	if a.IsZero() || b.IsZero() {
+		return -1
+	}
+	return max(b.Sub(a), 0)
+}
+
+// ms converts a span to milliseconds, keeping -1 for a missing phase.
+func ms(d time.Duration) float64 {
+	if d < 0 {
+		return -1
+	}
+	return float64(d.Microseconds()) / 1000
+}
+
+// capture keeps the first maxBodySize bytes of a body and counts the rest.
+// The transport may still be sending a request body while the response is
+// read and the exchange finished, so access is locked.
+type capture struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+	n   int64
+}
+
+func (c *capture) Write(p []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n += int64(len(p))
+	if room := maxBodySize - c.buf.Len(); room > 0 {
+		c.buf.Write(p[:min(len(p), room)])
+	}
+}
+
+// snapshot returns a copy of the captured bytes, the full body size and
+// whether the bytes are truncated.
+func (c *capture) snapshot() (data []byte, size int64, truncated bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return bytes.Clone(c.buf.Bytes()), c.n, c.n > int64(c.buf.Len())
+}
+
+// teeBody copies everything read from a body into a capture. done, if set,
+// is called once when the body reaches EOF, fails or is closed.
+type teeBody struct {
+	io.ReadCloser
+	capture *capture
+	done    func(error)
+}
+
+func (b *teeBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.capture.Write(p[:n])
+	if err != nil && b.done != nil {
+		b.done(err)
+	}
+	return n, err
+}
+
+func (b *teeBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.done != nil {
+		b.done(nil)
+	}
+	return err
+}
+
+// requestText returns the request body to record, redacted. data holds the
+// captured part of a body of size bytes.
+func requestText(path, mimeType string, data []byte, size int64, truncated bool) (text, comment string) {
+	if strings.Contains(path, "attachments:upload") {
+		return redacted, fmt.Sprintf("media upload content (%d bytes) is not recorded", size)
+	}
+	switch {
+	case strings.HasPrefix(mimeType, "application/x-www-form-urlencoded"):
+		if form, err := url.ParseQuery(string(data)); err == nil {
+			scrubValues(form)
+			data = []byte(form.Encode())
+		}
+	case isJSON(mimeType):
+		data = scrubJSON(data)
+	}
+	if !utf8.Valid(data) {
+		return base64.StdEncoding.EncodeToString(data), "base64-encoded binary content"
+	}
+	if truncated {
+		comment = fmt.Sprintf("truncated to the first %d bytes", maxBodySize)
+	}
+	return string(data), comment
+}
+
+// headers returns h as sorted name/value pairs with credentials redacted.
+func headers(h http.Header) []NameValue {
+	out := []NameValue{}
+	for name, values := range h {
+		for _, v := range values {
+			if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				v = redacted
+			} else if http.CanonicalHeaderKey(name) == "Location" {
+				v = scrubLocation(v)
+			}
+			out = append(out, NameValue{Name: name, Value: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// queryString returns the query parameters of u as name/value pairs.
+func queryString(u *url.URL) []NameValue {
+	out := []NameValue{}
+	q := u.Query()
+	names := make([]string, 0, len(q))
+	for name := range q {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range q[name] {
+			out = append(out, NameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+// scrubURL returns a copy of u with credential query parameters redacted.
+func scrubURL(u *url.URL) *url.URL {
+	out := *u
+	out.User = nil
+	q := u.Query()
+	if scrubValues(q) {
+		out.RawQuery = q.Encode()
+	}
+	return &out
+}
+
+// scrubLocation redacts a Location header, e.g. an upload session URL.
+func scrubLocation(loc string) string {
+	if loc == "" {
+		return ""
+	}
+	u, err := url.Parse(loc)
+	if err != nil {
+		return loc
+	}
+	return scrubURL(u).String()
+}
+
+// scrubValues redacts sensitive parameters in v and reports whether any
+// were found.
+func scrubValues(v url.Values) bool {
+	changed := false
+	for name := range v {
+		if sensitiveParams[name] {
+			v[name] = []string{redacted}
+			changed = true
+		}
+	}
+	return changed
+}
+
+// scrubJSON redacts sensitive top-level fields of a JSON object, such as
+// the tokens in an OAuth2 token response. Other bodies are returned as-is.
+func scrubJSON(data []byte) []byte {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return data
+	}
+	changed := false
+	for name := range obj {
+		if sensitiveParams[name] {
+			obj[name] = json.RawMessage(`"` + redacted + `"`)
+			changed = true
+		}
+	}
+	if !changed {
+		return data
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+func isJSON(mimeType string) bool {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
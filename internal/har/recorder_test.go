@@ -0,0 +1,100 @@
+package har
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRecorderRedactsTokens(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"access_token":"secret-issued","expires_in":3599}`)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "trace.har")
+	rec := NewRecorder(path, "test", nil)
+	client := &http.Client{Transport: rec}
+	for _, req := range []struct {
+		path string
+		form url.Values
+	}{
+		// auth logout revokes the refresh token.
+		{"/revoke", url.Values{"token": {"secret-refresh"}}},
+		// auth whoami introspects the access token.
+		{"/tokeninfo", url.Values{"access_token": {"secret-access"}}},
+		{"/tokeninfo?access_token=secret-query", nil},
+		{"/token", url.Values{"grant_type": {"refresh_token"}, "refresh_token": {"secret-refresh"}, "client_secret": {"secret-client"}}},
+	} {
+		resp, err := client.PostForm(srv.URL+req.path, req.form)
+		if err != nil {
+			t.Fatalf("POST %s: %v", req.path, err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-") {
+		t.Errorf("trace contains a credential:\n%s", data)
+	}
+	if n := strings.Count(string(data), redacted); n < 9 {
+		t.Errorf("trace has %d redactions, want at least 9:\n%s", n, data)
+	}
+}
+
+func TestWrappedRecordersShareTrace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "trace.har")
+	rec := NewRecorder(path, "test", nil)
+	clients := []*http.Client{{Transport: rec}, {Transport: rec.Wrap(nil)}}
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := clients[i%2].Get(fmt.Sprintf("%s/%d", srv.URL, i))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.Log.Entries); n != 10 {
+		t.Errorf("trace has %d entries, want 10", n)
+	}
+}
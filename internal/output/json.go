@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 	"strings"
 	"time"
 )
 
-// PrintJSON marshals data with indentation and prints it to stdout.
+// PrintJSON marshals data with indentation and prints it to stdout. An
+// API resource decoded from a response is printed as that response; see
+// JSONValue.
 func PrintJSON(data interface{}) error {
-	out, err := json.MarshalIndent(data, "", "  ")
+	out, err := json.MarshalIndent(JSONValue(data), "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling JSON: %w", err)
 	}
@@ -19,6 +23,38 @@ func PrintJSON(data interface{}) error {
 	return err
 }
 
+// JSONValue returns the JSON that data was decoded from, if data is an API
+// resource that kept it, and data itself otherwise. Printing the response
+// as received keeps fields that the generated models do not know about. A
+// value that was changed after decoding no longer matches its JSON and is
+// printed as it is now.
+func JSONValue(data interface{}) interface{} {
+	r, ok := data.(interface{ RawJSON() json.RawMessage })
+	if !ok {
+		return data
+	}
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return data
+	}
+	raw := r.RawJSON()
+	if len(raw) == 0 {
+		return data
+	}
+
+	// Decoding the JSON again must give back the value as it is.
+	fresh := reflect.New(v.Elem().Type()).Interface()
+	if err := json.Unmarshal(raw, fresh); err != nil {
+		return data
+	}
+	was, err1 := json.Marshal(fresh)
+	now, err2 := json.Marshal(data)
+	if err1 != nil || err2 != nil || !bytes.Equal(was, now) {
+		return data
+	}
+	return raw
+}
+
 // PrintRawJSON pretty-prints raw JSON bytes to stdout.
 func PrintRawJSON(raw json.RawMessage) error {
 	var buf bytes.Buffer
@@ -84,3 +120,50 @@ func Truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// JSONStream writes a JSON object with a single array field, {"key": [...]},
+// to stdout one element at a time so that long listings never need to be
+// held in memory. The output is indented the same way as PrintJSON.
+type JSONStream struct {
+	w     io.Writer
+	key   string
+	count int
+}
+
+// NewJSONStream creates a JSONStream that writes the array under key.
+// Nothing is written until the first call to Write or Close.
+func NewJSONStream(key string) *JSONStream {
+	return &JSONStream{w: os.Stdout, key: key}
+}
+
+// Write appends one element to the array.
+func (s *JSONStream) Write(item interface{}) error {
+	out, err := json.MarshalIndent(JSONValue(item), "    ", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling JSON: %w", err)
+	}
+	sep := ",\n"
+	if s.count == 0 {
+		sep = fmt.Sprintf("{\n  %q: [\n", s.key)
+	}
+	s.count++
+	_, err = fmt.Fprintf(s.w, "%s    %s", sep, out)
+	return err
+}
+
+// Close terminates the array and the enclosing object. It must be called
+// even when an error interrupted the listing so that the output written so
+// far stays valid JSON.
+func (s *JSONStream) Close() error {
+	if s.count == 0 {
+		_, err := fmt.Fprintf(s.w, "{\n  %q: []\n}\n", s.key)
+		return err
+	}
+	_, err := fmt.Fprint(s.w, "\n  ]\n}\n")
+	return err
+}
+
+// Count returns the number of elements written so far.
+func (s *JSONStream) Count() int {
+	return s.count
+}
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressInterval is the minimum time between two progress updates.
+const progressInterval = 100 * time.Millisecond
+
+// barWidth is the number of cells in a progress bar.
+const barWidth = 30
+
+// Progress reports the progress of a byte transfer such as an upload.
+type Progress interface {
+	// Set records that n of total bytes have been transferred.
+	Set(n, total int64)
+	// Done finishes the report after the transfer completed or failed.
+	Done()
+}
+
+// IsTerminal reports whether f is connected to a terminal.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// ProgressBar draws a single-line progress bar, redrawn in place, for
+// interactive terminals:
+//
+//	report.pdf [##########--------------------]  33%  4.0 MiB / 12.1 MiB
+type ProgressBar struct {
+	w     io.Writer
+	label string
+
+	mu       sync.Mutex
+	n, total int64
+	last     time.Time
+	drawn    bool
+}
+
+// NewProgressBar returns a ProgressBar that draws to w.
+func NewProgressBar(w io.Writer, label string) *ProgressBar {
+	return &ProgressBar{w: w, label: label}
+}
+
+// Set implements Progress. Redraws are throttled to progressInterval,
+// except for the final byte.
+func (p *ProgressBar) Set(n, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.n, p.total = n, total
+	if n < total && time.Since(p.last) < progressInterval {
+		return
+	}
+	p.draw()
+}
+
+// Done implements Progress. It draws the final state and ends the line.
+func (p *ProgressBar) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.drawn {
+		return
+	}
+	p.draw()
+	fmt.Fprintln(p.w)
+}
+
+func (p *ProgressBar) draw() {
+	p.last, p.drawn = time.Now(), true
+
+	frac := 1.0
+	if p.total > 0 {
+		frac = float64(p.n) / float64(p.total)
+	}
+	filled := int(frac * barWidth)
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", barWidth-filled)
+	fmt.Fprintf(p.w, "\r%s [%s] %3.0f%%  %s / %s\033[K", p.label, bar, frac*100, FormatBytes(p.n), FormatBytes(p.total))
+}
+
+// ProgressEvents writes transfer progress as newline-delimited JSON events
+// for machine consumers, e.g.
+//
+//	{"event":"progress","bytes":4194304,"totalBytes":12700000}
+type ProgressEvents struct {
+	w io.Writer
+
+	mu       sync.Mutex
+	last     time.Time
+	n, total int64
+}
+
+// progressEvent is one line written by ProgressEvents.
+type progressEvent struct {
+	Event      string `json:"event"`
+	Bytes      int64  `json:"bytes"`
+	TotalBytes int64  `json:"totalBytes"`
+}
+
+// NewProgressEvents returns a ProgressEvents that writes to w.
+func NewProgressEvents(w io.Writer) *ProgressEvents {
+	return &ProgressEvents{w: w}
+}
+
+// Set implements Progress. Events are throttled to progressInterval,
+// except for the final byte.
+func (p *ProgressEvents) Set(n, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.n, p.total = n, total
+	if n < total && time.Since(p.last) < progressInterval {
+		return
+	}
+	p.last = time.Now()
+	p.write("progress")
+}
+
+// Done implements Progress. It writes a final "done" event.
+func (p *ProgressEvents) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.write("done")
+}
+
+func (p *ProgressEvents) write(event string) {
+	data, _ := json.Marshal(progressEvent{Event: event, Bytes: p.n, TotalBytes: p.total})
+	fmt.Fprintf(p.w, "%s\n", data)
+}
+
+// FormatBytes formats a byte count with a binary unit, e.g. "4.0 MiB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
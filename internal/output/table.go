@@ -15,6 +15,9 @@ const (
 type Table struct {
 	Headers []string
 	Rows    [][]string
+	// OmitEmptyColumns drops the columns that are empty in every row, such
+	// as fields left out of a partial response.
+	OmitEmptyColumns bool
 }
 
 // NewTable creates a new table with the given column headers.
@@ -32,6 +35,9 @@ func (t *Table) AddRow(values ...string) *Table {
 
 // Render returns the table as a formatted, aligned string with header underlines.
 func (t *Table) Render() string {
+	if t.OmitEmptyColumns {
+		t = t.withoutEmptyColumns()
+	}
 	if len(t.Headers) == 0 {
 		return ""
 	}
@@ -106,3 +112,38 @@ func (t *Table) Render() string {
 
 	return b.String()
 }
+
+// withoutEmptyColumns returns a copy of t without the columns that hold no
+// value in any row. A table without rows, or without any value at all, is
+// returned unchanged so that its headers are still printed.
+func (t *Table) withoutEmptyColumns() *Table {
+	if len(t.Rows) == 0 {
+		return t
+	}
+	var keep []int
+	for i := range t.Headers {
+		for _, row := range t.Rows {
+			if i < len(row) && row[i] != "" {
+				keep = append(keep, i)
+				break
+			}
+		}
+	}
+	if len(keep) == 0 || len(keep) == len(t.Headers) {
+		return t
+	}
+
+	out := &Table{Headers: make([]string, len(keep)), Rows: make([][]string, len(t.Rows))}
+	for j, i := range keep {
+		out.Headers[j] = t.Headers[i]
+	}
+	for r, row := range t.Rows {
+		out.Rows[r] = make([]string, len(keep))
+		for j, i := range keep {
+			if i < len(row) {
+				out.Rows[r][j] = row[i]
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// The types below are the subset of the OTLP/JSON trace format
+// (ExportTraceServiceRequest) that gogchat writes. Trace and span IDs are
+// hex strings and timestamps decimal strings of Unix nanoseconds, as the
+// OTLP/JSON encoding requires.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+// otlpStatus codes: 0 unset, 1 ok, 2 error.
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// WriteOTLP writes the ended spans of t to w as one OTLP/JSON
+// ExportTraceServiceRequest, attributed to service "gogchat" at version.
+func (t *Tracer) WriteOTLP(w io.Writer, version string) error {
+	spans := t.Spans()
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		out = append(out, s.otlp())
+	}
+
+	req := otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpKeyValue{
+			keyValue("service.name", "gogchat"),
+			keyValue("service.version", version),
+		}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/cipher-shad0w/gogchat", Version: version},
+			Spans: out,
+		}},
+	}}}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(req); err != nil {
+		return fmt.Errorf("writing spans: %w", err)
+	}
+	return nil
+}
+
+// Export writes the spans of t to path as OTLP/JSON, or to stderr when path
+// is "-". Stdout is left to the command's own output, which may be JSON.
+func (t *Tracer) Export(path, version string) error {
+	if path == "-" {
+		return t.WriteOTLP(os.Stderr, version)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("writing spans: %w", err)
+	}
+	if err := t.WriteOTLP(f, version); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing spans: %w", err)
+	}
+	return nil
+}
+
+func (s *Span) otlp() otlpSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := otlpSpan{
+		TraceID:           s.TraceID,
+		SpanID:            s.SpanID,
+		ParentSpanID:      s.ParentID,
+		Name:              s.Name,
+		Kind:              s.Kind,
+		StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+	if s.failed {
+		out.Status = otlpStatus{Code: 2, Message: s.errMsg}
+	}
+	for _, a := range s.attrs {
+		out.Attributes = append(out.Attributes, keyValue(a.Key, a.Value))
+	}
+	return out
+}
+
+// keyValue converts an attribute to its OTLP form. Unsupported value types
+// are formatted as strings.
+func keyValue(key string, value any) otlpKeyValue {
+	var v otlpValue
+	switch x := value.(type) {
+	case string:
+		v.StringValue = &x
+	case bool:
+		v.BoolValue = &x
+	case int:
+		s := strconv.Itoa(x)
+		v.IntValue = &s
+	case int64:
+		s := strconv.FormatInt(x, 10)
+		v.IntValue = &s
+	case float64:
+		v.DoubleValue = &x
+	default:
+		s := fmt.Sprint(x)
+		v.StringValue = &s
+	}
+	return otlpKeyValue{Key: key, Value: v}
+}
@@ -0,0 +1,158 @@
+// Package telemetry records OpenTelemetry-style tracing spans for gogchat
+// commands and API calls, and exports them as OTLP-JSON without requiring
+// a collector or the OpenTelemetry SDK.
+//
+// A Tracer travels in the context: commands install one with WithTracer,
+// and instrumented code calls Start, which is a no-op when the context has
+// no tracer. Spans started from a context that carries a span become its
+// children, so a command's spans form a single trace.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// SpanKind describes the relationship of a span to the outside world, with
+// the values of the OTLP enum.
+type SpanKind int
+
+const (
+	// KindInternal is an operation inside gogchat, e.g. a command.
+	KindInternal SpanKind = 1
+	// KindClient is an outgoing request, e.g. an API call.
+	KindClient SpanKind = 3
+)
+
+// Tracer collects the spans of one gogchat run.
+type Tracer struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// NewTracer returns an empty Tracer.
+func NewTracer() *Tracer {
+	return &Tracer{}
+}
+
+// Spans returns the spans that have ended so far, in the order they ended.
+func (t *Tracer) Spans() []*Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*Span(nil), t.spans...)
+}
+
+// Span is a timed operation with attributes. A nil *Span is valid and
+// ignores every call, so callers never need to check whether tracing is
+// enabled.
+type Span struct {
+	tracer *Tracer
+
+	TraceID  string
+	SpanID   string
+	ParentID string
+	Name     string
+	Kind     SpanKind
+	Start    time.Time
+
+	mu      sync.Mutex
+	end     time.Time
+	attrs   []Attr
+	errMsg  string
+	failed  bool
+	stopped bool
+}
+
+// Attr is a span attribute. Value is a string, bool, int, int64 or float64.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+type tracerKey struct{}
+
+type spanKey struct{}
+
+// WithTracer returns a context whose spans are recorded by t.
+func WithTracer(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// Start begins a span named name as a child of the span in ctx, if any, and
+// returns a context carrying the new span. Without a tracer in ctx it
+// returns ctx and a nil span.
+func Start(ctx context.Context, name string, kind SpanKind, attrs ...Attr) (context.Context, *Span) {
+	t, _ := ctx.Value(tracerKey{}).(*Tracer)
+	if t == nil {
+		return ctx, nil
+	}
+
+	s := &Span{tracer: t, Name: name, Kind: kind, Start: time.Now(), SpanID: randomHex(8), attrs: attrs}
+	if parent := SpanFromContext(ctx); parent != nil {
+		s.TraceID, s.ParentID = parent.TraceID, parent.SpanID
+	} else {
+		s.TraceID = randomHex(16)
+	}
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// SpanFromContext returns the current span in ctx, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// SetAttr adds or replaces an attribute.
+func (s *Span) SetAttr(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.attrs {
+		if s.attrs[i].Key == key {
+			s.attrs[i].Value = value
+			return
+		}
+	}
+	s.attrs = append(s.attrs, Attr{Key: key, Value: value})
+}
+
+// SetError marks the span as failed with err. A nil err is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed, s.errMsg = true, err.Error()
+}
+
+// End finishes the span and hands it to its tracer. Only the first call
+// has an effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.stopped, s.end = true, time.Now()
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, s)
+}
+
+// randomHex returns n random bytes, hex-encoded.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,68 @@
+// Command fakechat serves the in-memory Google Chat API from the chattest
+// package so that gogchat, or scripts built on it, can run without network
+// access or credentials for the real API.
+//
+// Usage:
+//
+//	go run ./internal/tools/fakechat -addr 127.0.0.1:8085
+//	gogchat --base-url http://127.0.0.1:8085/v1 spaces list
+//
+// With -seed, a space with a few members and messages is created at start-up.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/cipher-shad0w/gogchat/internal/api"
+	"github.com/cipher-shad0w/gogchat/internal/chattest"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8085", "address to listen on")
+	seed := flag.Bool("seed", false, "populate the server with sample data")
+	flag.Parse()
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listening on %s: %v", *addr, err)
+	}
+
+	srv := chattest.NewUnstartedServer()
+	srv.Listener.Close()
+	srv.Listener = ln
+	srv.Start()
+	defer srv.Close()
+
+	if *seed {
+		seedData(srv)
+	}
+
+	fmt.Printf("Fake Google Chat API listening on %s\n", srv.BaseURL())
+	fmt.Printf("Caller: %s (%s)\n", chattest.DefaultCaller.Name, chattest.DefaultCaller.DisplayName)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	<-stop
+}
+
+// seedData creates a small space with members, a thread and a reaction.
+func seedData(srv *chattest.Server) {
+	alice := api.User{Name: "users/100000000000000000002", DisplayName: "Alice", Type: "HUMAN"}
+	bob := api.User{Name: "users/100000000000000000003", DisplayName: "Bob", Type: "HUMAN"}
+	srv.AddUser(alice)
+	srv.AddUser(bob)
+
+	sp := srv.AddSpace(api.Space{DisplayName: "Team", SpaceType: "SPACE"})
+	srv.AddMember(sp.Name, alice.Name, "ROLE_MEMBER", "JOINED")
+	srv.AddMember(sp.Name, bob.Name, "ROLE_MEMBER", "INVITED")
+
+	srv.AddMessage(sp.Name, api.Message{Text: "Welcome to the team space!"})
+	srv.AddMessage(sp.Name, api.Message{Text: "Hi everyone", Sender: &alice, Thread: &api.Thread{ThreadKey: "intro"}})
+	srv.AddMessage(sp.Name, api.Message{Text: "Glad to be here", Thread: &api.Thread{ThreadKey: "intro"}})
+	fmt.Printf("Seeded %s (%s)\n", sp.Name, sp.DisplayName)
+}
@@ -0,0 +1,245 @@
+// Command genmodels generates Go types for the Google Chat API resources
+// described by a Google API discovery document (api.json).
+//
+// Usage:
+//
+//	go run ./internal/tools/genmodels -in api.json -out internal/api/models.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// discovery is the subset of a discovery document needed for code generation.
+type discovery struct {
+	Name     string             `json:"name"`
+	Version  string             `json:"version"`
+	Revision string             `json:"revision"`
+	Schemas  map[string]*schema `json:"schemas"`
+}
+
+// schema describes a discovery schema or property.
+type schema struct {
+	ID                   string             `json:"id"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Ref                  string             `json:"$ref"`
+	Enum                 []string           `json:"enum"`
+	Items                *schema            `json:"items"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Deprecated           bool               `json:"deprecated"`
+}
+
+// initialisms are name segments rendered in upper case, following Go naming
+// conventions (DownloadURI rather than DownloadUri).
+var initialisms = map[string]string{
+	"Api":  "API",
+	"Html": "HTML",
+	"Http": "HTTP",
+	"Id":   "ID",
+	"Ids":  "IDs",
+	"Json": "JSON",
+	"Uid":  "UID",
+	"Uri":  "URI",
+	"Url":  "URL",
+}
+
+func main() {
+	in := flag.String("in", "api.json", "path to the discovery document")
+	out := flag.String("out", "models.go", "path of the generated Go file")
+	pkg := flag.String("pkg", "api", "package name of the generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatalf("reading discovery document: %v", err)
+	}
+
+	var doc discovery
+	if err := json.Unmarshal(data, &doc); err != nil {
+		log.Fatalf("parsing discovery document: %v", err)
+	}
+
+	src, err := generate(&doc, *pkg)
+	if err != nil {
+		log.Fatalf("generating models: %v", err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("writing %s: %v", *out, err)
+	}
+}
+
+// generate renders all schemas of doc as Go struct types. Every type embeds
+// rawJSON, a hand-written type of the target package that holds the JSON a
+// response was decoded from, so that the exact server response can still be
+// printed.
+func generate(doc *discovery, pkg string) ([]byte, error) {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by genmodels from the %s %s discovery document (revision %s). DO NOT EDIT.\n\n", doc.Name, doc.Version, doc.Revision)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+
+	names := make([]string, 0, len(doc.Schemas))
+	for name := range doc.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := doc.Schemas[name]
+		writeComment(&b, "", name+": "+s.Description)
+		fmt.Fprintf(&b, "type %s struct {\n\trawJSON\n", name)
+
+		props := make([]string, 0, len(s.Properties))
+		for prop := range s.Properties {
+			props = append(props, prop)
+		}
+		sort.Strings(props)
+
+		for _, prop := range props {
+			p := s.Properties[prop]
+			b.WriteString("\n")
+			desc := firstSentence(p.Description)
+			if len(p.Enum) > 0 {
+				desc += " Possible values: " + strings.Join(p.Enum, ", ") + "."
+			}
+			if p.Deprecated && !strings.HasPrefix(desc, "Deprecated") {
+				desc += "\n\nDeprecated: see the Google Chat API reference."
+			}
+			field := goName(prop)
+			writeComment(&b, "\t", field+": "+desc)
+
+			typ, err := goType(p)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", name, prop, err)
+			}
+			tag := prop + ",omitempty"
+			if p.Type == "string" && (p.Format == "int64" || p.Format == "uint64") {
+				tag += ",string"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:\"%s\"`\n", field, typ, tag)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	return format.Source(b.Bytes())
+}
+
+// goType maps a discovery property to a Go type expression.
+func goType(s *schema) (string, error) {
+	if s.Ref != "" {
+		return "*" + s.Ref, nil
+	}
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "int64":
+			return "int64", nil
+		case "uint64":
+			return "uint64", nil
+		}
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "any":
+		return "interface{}", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		elem, err := goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case "object":
+		if s.AdditionalProperties == nil {
+			return "map[string]interface{}", nil
+		}
+		elem, err := goType(s.AdditionalProperties)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + elem, nil
+	}
+	return "", fmt.Errorf("unsupported type %q", s.Type)
+}
+
+// goName converts a JSON property name such as "downloadUri" into an
+// exported Go identifier such as "DownloadURI".
+func goName(prop string) string {
+	var words []string
+	start := 0
+	runes := []rune(prop)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	var b strings.Builder
+	for _, w := range words {
+		w = strings.ToUpper(w[:1]) + w[1:]
+		if r, ok := initialisms[w]; ok {
+			w = r
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
+
+// firstSentence returns the first sentence of a discovery description,
+// keeping leading qualifiers such as "Optional." or "Output only." together
+// with the sentence they qualify.
+func firstSentence(desc string) string {
+	desc = strings.TrimSpace(desc)
+	end := 0
+	for {
+		i := strings.Index(desc[end:], ". ")
+		if i < 0 {
+			return desc
+		}
+		sentence := desc[end : end+i+1]
+		end += i + 1
+		if len(strings.Fields(sentence)) > 2 {
+			return desc[:end]
+		}
+	}
+}
+
+// writeComment writes text as a Go comment wrapped at roughly 80 columns.
+func writeComment(b *bytes.Buffer, indent, text string) {
+	for _, para := range strings.Split(text, "\n") {
+		line := indent + "//"
+		if strings.TrimSpace(para) == "" {
+			b.WriteString(line + "\n")
+			continue
+		}
+		for _, word := range strings.Fields(para) {
+			if len(line) > len(indent)+2 && len(line)+1+len(word) > 80 {
+				b.WriteString(line + "\n")
+				line = indent + "//"
+			}
+			line += " " + word
+		}
+		b.WriteString(line + "\n")
+	}
+}
@@ -713,8 +713,10 @@ def create_reaction(message_name: str, emoji: str) -> bool:
 def get_self_user_id() -> str | None:
     """Determine the current authenticated user's resource name.
 
-    Uses the space read-state API to resolve ``users/me`` into the real
-    user resource name (e.g. ``users/123456789``).
+    Asks ``gogchat auth whoami`` for the user resource name (e.g.
+    ``users/123456789``), using the identity it cached before when there is
+    one. Falls back to resolving ``users/me`` through the space read-state
+    API for gogchat builds without ``whoami``.
 
     Returns:
         The user resource name or ``None`` if it cannot be determined.
@@ -724,6 +726,26 @@ def get_self_user_id() -> str | None:
     except FileNotFoundError:
         return None
 
+    for args in (["--cached"], []):
+        try:
+            result = subprocess.run(
+                [gogchat_path, "auth", "whoami", *args, "--json"],
+                capture_output=True,
+                text=True,
+                check=True,
+                timeout=15,
+            )
+            user = json.loads(result.stdout).get("user", "")
+            if user.startswith("users/"):
+                return user
+        except Exception:
+            logger.debug("auth whoami %s failed", " ".join(args), exc_info=True)
+
+    return _self_user_id_from_read_state(gogchat_path)
+
+
+def _self_user_id_from_read_state(gogchat_path: str) -> str | None:
+    """Resolve ``users/me`` through the space read-state API."""
     try:
         # Fetch one space so we have a space name to query read-state with
         result = subprocess.run(